@@ -0,0 +1,148 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/y-miyazaki/arc/internal/aws/resources"
+	"github.com/y-miyazaki/arc/internal/config"
+)
+
+// configOnlyFlags are the root flags that cannot be set from a configuration file.
+var configOnlyFlags = []string{"config", "preset"}
+
+// Sentinel errors for configuration files (alphabetical order).
+var (
+	ErrConfigPathRequired  = errors.New("config validate requires a file: arc config validate <file> or --config <file>")
+	ErrPresetWithoutConfig = errors.New("--preset requires --config")
+)
+
+// newConfigCommand returns the "arc config" command group.
+func newConfigCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "config",
+		Usage: "Work with arc configuration files",
+		Commands: []*cli.Command{
+			{
+				Name:      "validate",
+				Usage:     "Check option names, values, presets and categories of a configuration file",
+				ArgsUsage: "[file]",
+				Action: func(_ context.Context, cmd *cli.Command) error {
+					path := cmd.Args().First()
+					if path == "" {
+						path = cmd.String("config")
+					}
+					if path == "" {
+						return ErrConfigPathRequired
+					}
+					return runConfigValidate(path, os.Stdout)
+				},
+			},
+		},
+	}
+}
+
+// runConfigValidate validates the configuration file at path and reports the result to stdout.
+// Categories are checked against the collector registry used by selectCollectors.
+func runConfigValidate(path string, stdout io.Writer) error {
+	file, err := config.Load(path)
+	if err != nil {
+		return err
+	}
+	errs := []error{file.Validate(configOptionNames(), resources.CollectorNames())}
+	errs = append(errs, checkConfigValues("config", file.Options)...)
+	for _, name := range file.PresetNames() {
+		errs = append(errs, checkConfigValues("preset "+name, file.Presets[name])...)
+	}
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("invalid config file %s:\n%w", path, err)
+	}
+
+	presets := "none"
+	if names := file.PresetNames(); len(names) > 0 {
+		presets = strings.Join(names, ", ")
+	}
+	if _, err := fmt.Fprintf(stdout, "%s: OK (presets: %s)\n", path, presets); err != nil {
+		return fmt.Errorf("failed to write result: %w", err)
+	}
+	return nil
+}
+
+// applyConfigFile sets every flag of cmd that was not given on the command line
+// or through its environment variable from the --config file, applying --preset
+// on top of the base options.
+func applyConfigFile(cmd *cli.Command) error {
+	path := cmd.String("config")
+	preset := cmd.String("preset")
+	if path == "" {
+		if preset != "" {
+			return ErrPresetWithoutConfig
+		}
+		return nil
+	}
+
+	file, err := config.Load(path)
+	if err != nil {
+		return err
+	}
+	values, err := file.Resolve(preset)
+	if err != nil {
+		return err
+	}
+	if err := values.Validate(configOptionNames(), resources.CollectorNames()); err != nil {
+		return fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+	for _, key := range values.Keys() {
+		if cmd.IsSet(key) {
+			continue
+		}
+		value, err := values.String(key)
+		if err != nil {
+			return err
+		}
+		if err := cmd.Set(key, value); err != nil {
+			return fmt.Errorf("invalid config option %s: %w", key, err)
+		}
+	}
+	return nil
+}
+
+// configOptionNames returns the names of the root flags a configuration file can set.
+func configOptionNames() []string {
+	flags := newRootFlags()
+	names := make([]string, 0, len(flags))
+	for _, f := range flags {
+		if name := f.Names()[0]; !slices.Contains(configOnlyFlags, name) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// checkConfigValues parses every known option of values with a fresh copy of its
+// flag, so that for example a malformed duration is reported without running.
+func checkConfigValues(scope string, values config.Values) []error {
+	flags := newRootFlags()
+	var errs []error
+	for _, key := range values.Keys() {
+		i := slices.IndexFunc(flags, func(f cli.Flag) bool { return f.Names()[0] == key })
+		if i < 0 || slices.Contains(configOnlyFlags, key) {
+			continue // reported as unknown by Validate
+		}
+		value, err := values.String(key)
+		if err != nil {
+			continue // reported by Validate
+		}
+		if err := flags[i].Set(key, value); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w: %s: %w", scope, config.ErrInvalidValue, key, err))
+		}
+	}
+	return errs
+}
@@ -0,0 +1,171 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/y-miyazaki/arc/internal/config"
+)
+
+const testConfigFile = `
+categories: [ec2, vpc]
+concurrency: 8
+timeout: 10m
+format: [csv, json]
+presets:
+  security:
+    categories: iam_role,kms
+    html: true
+`
+
+func writeConfigFixture(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "arc.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	return path
+}
+
+// configuredValues runs a root command with args and returns the flag values
+// seen by its action after applyConfigFile.
+func configuredValues(t *testing.T, args ...string) (map[string]string, error) {
+	t.Helper()
+	got := make(map[string]string)
+	cmd := &cli.Command{
+		Name:  "arc",
+		Flags: newRootFlags(),
+		Action: func(_ context.Context, cmd *cli.Command) error {
+			if err := applyConfigFile(cmd); err != nil {
+				return err
+			}
+			got["categories"] = cmd.String("categories")
+			got["concurrency"] = strconv.Itoa(cmd.Int("concurrency"))
+			got["format"] = cmd.String("format")
+			got["html"] = strconv.FormatBool(cmd.Bool("html"))
+			got["timeout"] = cmd.Duration("timeout").String()
+			return nil
+		},
+	}
+	err := cmd.Run(context.Background(), append([]string{"arc"}, args...))
+	return got, err
+}
+
+func TestApplyConfigFile(t *testing.T) {
+	t.Parallel()
+
+	path := writeConfigFixture(t, testConfigFile)
+
+	tests := []struct {
+		name    string
+		args    []string
+		want    map[string]string
+		wantErr error
+	}{
+		{
+			name: "no config file keeps defaults",
+			want: map[string]string{"categories": "", "format": "csv", "timeout": DefaultExecutionTimeout.String()},
+		},
+		{
+			name: "file values",
+			args: []string{"--config", path},
+			want: map[string]string{"categories": "ec2,vpc", "concurrency": "8", "format": "csv,json", "html": "false", "timeout": (10 * time.Minute).String()},
+		},
+		{
+			name: "preset overrides file values",
+			args: []string{"--config", path, "--preset", "security"},
+			want: map[string]string{"categories": "iam_role,kms", "concurrency": "8", "format": "csv,json", "html": "true"},
+		},
+		{
+			name: "flags override file and preset values",
+			args: []string{"--config", path, "--preset", "security", "--categories", "s3_bucket", "--timeout", "1h"},
+			want: map[string]string{"categories": "s3_bucket", "timeout": time.Hour.String()},
+		},
+		{name: "unknown preset", args: []string{"--config", path, "--preset", "compute"}, wantErr: config.ErrUnknownPreset},
+		{name: "preset without config", args: []string{"--preset", "security"}, wantErr: ErrPresetWithoutConfig},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := configuredValues(t, tt.args...)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("applyConfigFile() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("applyConfigFile() unexpected error = %v", err)
+			}
+			for key, want := range tt.want {
+				if got[key] != want {
+					t.Fatalf("applyConfigFile() %s = %q, want %q", key, got[key], want)
+				}
+			}
+		})
+	}
+}
+
+func TestApplyConfigFile_Invalid(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		content string
+		wantErr error
+	}{
+		{name: "unknown option", content: "regions: us-east-1\n", wantErr: config.ErrUnknownOption},
+		{name: "config only option", content: "preset: security\n", wantErr: config.ErrUnknownOption},
+		{name: "unknown category", content: "categories: [ec2, ec3]\n", wantErr: config.ErrUnknownCategory},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := configuredValues(t, "--config", writeConfigFixture(t, tt.content))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("applyConfigFile() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	if _, err := configuredValues(t, "--config", writeConfigFixture(t, "concurrency: many\n")); err == nil {
+		t.Fatal("applyConfigFile() error = nil, want error for a non-numeric concurrency")
+	}
+}
+
+func TestRunConfigValidate(t *testing.T) {
+	t.Parallel()
+
+	var stdout bytes.Buffer
+	path := writeConfigFixture(t, testConfigFile)
+	if err := runConfigValidate(path, &stdout); err != nil {
+		t.Fatalf("runConfigValidate() unexpected error = %v", err)
+	}
+	if !strings.Contains(stdout.String(), "OK (presets: security)") {
+		t.Fatalf("runConfigValidate() output = %q, want OK with presets", stdout.String())
+	}
+
+	invalid := writeConfigFixture(t, "timeout: soon\npresets:\n  network:\n    categories: [vpc, vpn]\n    regions: us-east-1\n")
+	err := runConfigValidate(invalid, &stdout)
+	for _, want := range []error{config.ErrInvalidValue, config.ErrUnknownCategory, config.ErrUnknownOption} {
+		if !errors.Is(err, want) {
+			t.Fatalf("runConfigValidate() error = %v, want %v", err, want)
+		}
+	}
+	for _, want := range []string{"config: invalid value: timeout", "preset network: unknown category: vpn", "preset network: unknown option: regions"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Fatalf("runConfigValidate() error = %q, want it to contain %q", err, want)
+		}
+	}
+}
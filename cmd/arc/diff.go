@@ -0,0 +1,81 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/y-miyazaki/arc/internal/diff"
+)
+
+// diffArgsCount is the number of positional arguments of the diff command.
+const diffArgsCount = 2
+
+// ErrDiffArgs is returned when the diff command does not get exactly two sources.
+var ErrDiffArgs = errors.New("diff requires two arguments: <before> <after>")
+
+// newDiffCommand returns the "arc diff" subcommand comparing two collection runs.
+func newDiffCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "diff",
+		Usage:     "Compare two collection runs and report added, removed and modified resources",
+		ArgsUsage: "<before> <after>",
+		Description: "Each argument is an account output directory ({output-dir}/{account-id}), " +
+			"its resources directory, or an all.csv/all.json/all.ndjson export.",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "format",
+				Aliases: []string{"f"},
+				Usage:   "Report format (text, json, html)",
+				Value:   diff.FormatText,
+			},
+			&cli.StringFlag{
+				Name:    "output",
+				Aliases: []string{"o"},
+				Usage:   "Write the report to a file instead of stdout",
+			},
+		},
+		Action: func(_ context.Context, cmd *cli.Command) error {
+			if cmd.Args().Len() != diffArgsCount {
+				return ErrDiffArgs
+			}
+			return runDiff(cmd.Args().Get(0), cmd.Args().Get(1), cmd.String("format"), cmd.String("output"), os.Stdout)
+		},
+	}
+}
+
+// runDiff loads both sources, compares them and writes the report to outputPath,
+// or to stdout when outputPath is empty.
+func runDiff(beforePath, afterPath, format, outputPath string, stdout io.Writer) (err error) {
+	if !slices.Contains([]string{diff.FormatText, diff.FormatJSON, diff.FormatHTML}, format) {
+		return fmt.Errorf("%w: %q (supported: text, json, html)", diff.ErrUnknownFormat, format)
+	}
+	before, err := diff.Load(beforePath)
+	if err != nil {
+		return fmt.Errorf("failed to load %s: %w", beforePath, err)
+	}
+	after, err := diff.Load(afterPath)
+	if err != nil {
+		return fmt.Errorf("failed to load %s: %w", afterPath, err)
+	}
+	result := diff.Compare(before, after, beforePath, afterPath)
+
+	if outputPath == "" {
+		return diff.Write(stdout, result, format)
+	}
+	f, err := os.Create(outputPath) //nolint:gosec // G304 - path is provided by the user on purpose
+	if err != nil {
+		return fmt.Errorf("failed to create report: %w", err)
+	}
+	defer func() {
+		if closeErr := f.Close(); closeErr != nil {
+			err = errors.Join(err, fmt.Errorf("failed to close report: %w", closeErr))
+		}
+	}()
+	return diff.Write(f, result, format)
+}
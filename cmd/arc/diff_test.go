@@ -0,0 +1,66 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/y-miyazaki/arc/internal/diff"
+)
+
+func writeDiffFixture(t *testing.T, dir, content string) {
+	t.Helper()
+	resourcesDir := filepath.Join(dir, "resources")
+	if err := os.MkdirAll(resourcesDir, 0o750); err != nil {
+		t.Fatalf("MkdirAll() error = %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(resourcesDir, "sqs.csv"), []byte(content), 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+}
+
+func TestRunDiff(t *testing.T) {
+	t.Parallel()
+
+	before := t.TempDir()
+	after := t.TempDir()
+	writeDiffFixture(t, before, "Category,Name,Region,ARN,Visibility\nsqs,jobs,us-east-1,arn:aws:sqs:us-east-1:123456789012:jobs,30\n")
+	writeDiffFixture(t, after, "Category,Name,Region,ARN,Visibility\nsqs,jobs,us-east-1,arn:aws:sqs:us-east-1:123456789012:jobs,60\n")
+
+	var stdout bytes.Buffer
+	if err := runDiff(before, after, diff.FormatText, "", &stdout); err != nil {
+		t.Fatalf("runDiff() unexpected error = %v", err)
+	}
+	if !strings.Contains(stdout.String(), `Visibility: "30" -> "60"`) {
+		t.Fatalf("runDiff() output = %q, want Visibility change", stdout.String())
+	}
+
+	reportPath := filepath.Join(t.TempDir(), "diff.html")
+	if err := runDiff(before, after, diff.FormatHTML, reportPath, &stdout); err != nil {
+		t.Fatalf("runDiff() unexpected error = %v", err)
+	}
+	report, err := os.ReadFile(reportPath)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	if !strings.Contains(string(report), "Visibility") {
+		t.Fatal("runDiff() html report does not contain the changed column")
+	}
+}
+
+func TestRunDiff_Errors(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeDiffFixture(t, dir, "Category,Name,Region,ARN\n")
+
+	if err := runDiff(dir, dir, "xml", "", &bytes.Buffer{}); !errors.Is(err, diff.ErrUnknownFormat) {
+		t.Fatalf("runDiff() error = %v, want %v", err, diff.ErrUnknownFormat)
+	}
+	if err := runDiff(dir, filepath.Join(dir, "missing"), diff.FormatText, "", &bytes.Buffer{}); err == nil {
+		t.Fatal("runDiff() error = nil, want error for missing source")
+	}
+}
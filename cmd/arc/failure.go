@@ -0,0 +1,129 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/y-miyazaki/arc/internal/aws/resources"
+)
+
+// Exit codes of arc. When an error has several causes, for example a partial
+// failure and findings at --fail-severity, the lowest code other than
+// ExitCodeError is used.
+const (
+	// ExitCodeOK is the exit code of a successful run.
+	ExitCodeOK = 0
+	// ExitCodeError is the exit code of errors not covered by another code,
+	// such as failures writing the outputs.
+	ExitCodeError = 1
+	// ExitCodeConfig is the exit code of invalid flags or configuration files.
+	ExitCodeConfig = 2
+	// ExitCodeCredentials is the exit code of AWS credentials that cannot be
+	// loaded or validated, or of a role that cannot be assumed.
+	ExitCodeCredentials = 3
+	// ExitCodeTotalFailure is the exit code of a run in which no (category,
+	// region) collection completed.
+	ExitCodeTotalFailure = 4
+	// ExitCodePartialFailure is the exit code of a run in which some
+	// collections failed, as selected by --fail-on.
+	ExitCodePartialFailure = 5
+	// ExitCodeFindings is the exit code of findings at or above --fail-severity.
+	ExitCodeFindings = 6
+)
+
+// --fail-on policies.
+const (
+	// FailOnAny fails the run on any collection failure.
+	FailOnAny = "any"
+	// FailOnCategories fails the run on the collection failures of some categories.
+	FailOnCategories = "categories"
+	// FailOnNone never fails the run on collection failures.
+	FailOnNone = "none"
+	// failOnCategoriesPrefix prefixes the categories of FailOnCategories.
+	failOnCategoriesPrefix = FailOnCategories + ":"
+)
+
+// Sentinel errors classifying the errors of a run by exit code (alphabetical order).
+var (
+	ErrAllCollectionsFailed  = errors.New("no collection completed")
+	ErrConfiguration         = errors.New("invalid configuration")
+	ErrCredentials           = errors.New("aws credentials error")
+	ErrInvalidFailOn         = errors.New("invalid --fail-on")
+	ErrSomeCollectionsFailed = errors.New("some collections failed")
+)
+
+// FailPolicy selects the collection failures that fail the run (--fail-on).
+// The zero value fails the run on any collection failure.
+type FailPolicy struct {
+	// Categories are the categories of FailOnCategories.
+	Categories []string
+	Mode       string
+}
+
+// parseFailPolicy parses the --fail-on value: any, none or categories:<list>.
+// Categories are checked against the collector registry.
+func parseFailPolicy(s string) (FailPolicy, error) {
+	value := strings.TrimSpace(s)
+	switch strings.ToLower(value) {
+	case "", FailOnAny:
+		return FailPolicy{Mode: FailOnAny}, nil
+	case FailOnNone:
+		return FailPolicy{Mode: FailOnNone}, nil
+	}
+	list, ok := strings.CutPrefix(value, failOnCategoriesPrefix)
+	if !ok {
+		return FailPolicy{}, fmt.Errorf("%w: %q (supported: any, none, categories:<list>)", ErrInvalidFailOn, s)
+	}
+	categories := parseCommaList(list)
+	if len(categories) == 0 {
+		return FailPolicy{}, fmt.Errorf("%w: %q lists no category", ErrInvalidFailOn, s)
+	}
+	known := resources.CollectorNames()
+	for _, category := range categories {
+		if !slices.Contains(known, category) {
+			return FailPolicy{}, fmt.Errorf("%w: unknown category %q", ErrInvalidFailOn, category)
+		}
+	}
+	return FailPolicy{Mode: FailOnCategories, Categories: categories}, nil
+}
+
+// Failures returns the failures of failed that fail the run under the policy.
+func (p FailPolicy) Failures(failed map[string][]CollectionFailure) map[string][]CollectionFailure {
+	failing := make(map[string][]CollectionFailure, len(failed))
+	if p.Mode == FailOnNone {
+		return failing
+	}
+	for category, failures := range failed {
+		if p.Mode != FailOnCategories || slices.Contains(p.Categories, category) {
+			failing[category] = failures
+		}
+	}
+	return failing
+}
+
+// configError marks err as a configuration error, exiting with ExitCodeConfig.
+func configError(err error) error {
+	return fmt.Errorf("%w: %w", ErrConfiguration, err)
+}
+
+// exitCode returns the exit code of the error returned by the CLI.
+func exitCode(err error) int {
+	switch {
+	case err == nil:
+		return ExitCodeOK
+	case errors.Is(err, ErrConfiguration):
+		return ExitCodeConfig
+	case errors.Is(err, ErrCredentials):
+		return ExitCodeCredentials
+	case errors.Is(err, ErrAllCollectionsFailed):
+		return ExitCodeTotalFailure
+	case errors.Is(err, ErrSomeCollectionsFailed):
+		return ExitCodePartialFailure
+	case errors.Is(err, ErrFindingsAtFailSeverity):
+		return ExitCodeFindings
+	default:
+		return ExitCodeError
+	}
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"testing"
+)
+
+func TestParseFailPolicy(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		value   string
+		want    FailPolicy
+		wantErr bool
+	}{
+		{name: "empty defaults to any", value: "", want: FailPolicy{Mode: FailOnAny}},
+		{name: "any", value: "any", want: FailPolicy{Mode: FailOnAny}},
+		{name: "none is case-insensitive", value: " None ", want: FailPolicy{Mode: FailOnNone}},
+		{name: "categories", value: "categories: ec2 , s3_bucket", want: FailPolicy{Mode: FailOnCategories, Categories: []string{"ec2", "s3_bucket"}}},
+		{name: "empty category list", value: "categories:", wantErr: true},
+		{name: "unknown category", value: "categories:ec2,nope", wantErr: true},
+		{name: "unknown policy", value: "some", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := parseFailPolicy(tt.value)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidFailOn) {
+					t.Fatalf("parseFailPolicy(%q) error = %v, want %v", tt.value, err, ErrInvalidFailOn)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseFailPolicy(%q) error = %v", tt.value, err)
+			}
+			if got.Mode != tt.want.Mode || !slices.Equal(got.Categories, tt.want.Categories) {
+				t.Fatalf("parseFailPolicy(%q) = %+v, want %+v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFailPolicy_Failures(t *testing.T) {
+	t.Parallel()
+
+	failed := map[string][]CollectionFailure{
+		"ec2": {{Region: "us-east-1", Err: errors.New("boom")}},
+		"s3":  {{Region: "us-east-1", Err: errors.New("boom")}},
+	}
+	tests := []struct {
+		name   string
+		policy FailPolicy
+		want   []string
+	}{
+		{name: "zero value fails on any", policy: FailPolicy{}, want: []string{"ec2", "s3"}},
+		{name: "any", policy: FailPolicy{Mode: FailOnAny}, want: []string{"ec2", "s3"}},
+		{name: "none", policy: FailPolicy{Mode: FailOnNone}, want: []string{}},
+		{name: "categories", policy: FailPolicy{Mode: FailOnCategories, Categories: []string{"s3", "sqs"}}, want: []string{"s3"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := slices.Sorted(maps.Keys(tt.policy.Failures(failed)))
+			if !slices.Equal(got, tt.want) {
+				t.Fatalf("Failures(...) categories = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExitCode(t *testing.T) {
+	t.Parallel()
+
+	partial := fmt.Errorf("%w: %w", ErrSomeCollectionsFailed, CollectionError{})
+	findingsErr := fmt.Errorf("%w: 1 finding(s) at high or above", ErrFindingsAtFailSeverity)
+
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{name: "success", err: nil, want: ExitCodeOK},
+		{name: "unclassified error", err: errors.New("disk full"), want: ExitCodeError},
+		{name: "configuration error", err: configError(ErrHTMLRequiresCSV), want: ExitCodeConfig},
+		{name: "credentials", err: fmt.Errorf("account 111111111111: %w: assume role failed", ErrCredentials), want: ExitCodeCredentials},
+		{name: "total failure", err: fmt.Errorf("%w: %w", ErrAllCollectionsFailed, CollectionError{}), want: ExitCodeTotalFailure},
+		{name: "partial failure", err: partial, want: ExitCodePartialFailure},
+		{name: "findings", err: findingsErr, want: ExitCodeFindings},
+		{name: "partial failure wins over findings", err: errors.Join(partial, findingsErr), want: ExitCodePartialFailure},
+		{name: "credentials win over partial failure of another account", err: errors.Join(partial, fmt.Errorf("%w: expired", ErrCredentials)), want: ExitCodeCredentials},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := exitCode(tt.err); got != tt.want {
+				t.Fatalf("exitCode(%v) = %d, want %d", tt.err, got, tt.want)
+			}
+		})
+	}
+}
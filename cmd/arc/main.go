@@ -7,7 +7,10 @@
 	"encoding/csv"
 	"errors"
 	"fmt"
+	"io"
+	"io/fs"
 	"log/slog"
+	"maps"
 	"os"
 	"os/signal"
 	"path/filepath"
@@ -17,27 +20,46 @@
 	"syscall"
 	"time"
 
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/account"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/organizations"
 	"github.com/urfave/cli/v3"
 
 	"github.com/y-miyazaki/arc/internal/aws"
 	"github.com/y-miyazaki/arc/internal/aws/helpers"
 	"github.com/y-miyazaki/arc/internal/aws/resources"
+	"github.com/y-miyazaki/arc/internal/checkpoint"
 	"github.com/y-miyazaki/arc/internal/exporter"
+	"github.com/y-miyazaki/arc/internal/filter"
+	"github.com/y-miyazaki/arc/internal/findings"
+	"github.com/y-miyazaki/arc/internal/graph"
+	"github.com/y-miyazaki/arc/internal/redact"
+	"github.com/y-miyazaki/arc/internal/runreport"
 	"github.com/y-miyazaki/go-common/pkg/logger"
 	"github.com/y-miyazaki/go-common/pkg/utils/aws/validation"
 )
 
 // Version information (set by GoReleaser during build)
 const (
+	// AccountIDLength is the number of digits in an AWS account ID
+	AccountIDLength = 12
 	// DefaultDirPerm is the default directory permission
 	DefaultDirPerm = 0o750
 	// DefaultExecutionTimeout is the default upper bound for a single arc run.
 	DefaultExecutionTimeout = 30 * time.Minute
+	// DefaultGracePeriod is the default time in-flight collectors may take to
+	// finish once the run is interrupted.
+	DefaultGracePeriod = 30 * time.Second
 	// DefaultMaxConcurrency is the default maximum number of concurrent AWS API requests
 	DefaultMaxConcurrency = 5
+	// DefaultRetryMaxAttempts is the default maximum number of attempts per AWS API call
+	DefaultRetryMaxAttempts = 3
+	// DefaultRetryMaxBackoff is the default maximum delay between two attempts of an AWS API call
+	DefaultRetryMaxBackoff = 20 * time.Second
 	// GlobalServiceRegion is the region used for global services (IAM, S3, CloudFront, etc.)
-	GlobalServiceRegion = "us-east-1"
+	// in the aws partition. Other partitions use helpers.GlobalRegionForPartition.
+	GlobalServiceRegion = helpers.GlobalRegionAWS
 	// LogKeyCategory is the log key for category
 	LogKeyCategory = "category"
 	// LogKeyError is the log key for error
@@ -47,7 +69,19 @@
 )
 
 var (
-	ErrInvalidOutputPath = errors.New("invalid output file path")
+	ErrConflictingOrgOptions  = errors.New("--org cannot be combined with --accounts or --assume-role-arn")
+	ErrConflictingRoleOptions = errors.New("--assume-role-arn cannot be combined with --accounts")
+	ErrFailSeverityNoRules    = errors.New("--fail-severity requires --findings or --rules")
+	ErrFindingsAtFailSeverity = errors.New("findings at or above the fail severity")
+	ErrHTMLRequiresCSV        = errors.New("--html requires the csv format")
+	ErrInvalidAccountID       = errors.New("invalid account id")
+	ErrInvalidOutputPath      = errors.New("invalid output file path")
+	ErrInvalidTagFilter       = errors.New("invalid tag filter")
+	ErrMissingRoleName        = errors.New("--assume-role-name is required with --accounts or --org")
+	ErrNoTargetAccounts       = errors.New("no target accounts found")
+	ErrRegionPartition        = errors.New("region does not belong to the partition of the caller identity")
+	ErrSecretHashSaltRequired = errors.New("--secret-values redacted requires --secret-hash-salt")
+	ErrUnknownFormat          = errors.New("unknown output format")
 
 	version = "v1.0.14"
 )
@@ -59,28 +93,80 @@ type CollectionError struct {
 }
 
 // CollectionFailure holds a failed category collection attempt for one region.
+// PermissionSkipped is set for attempts denied by IAM (AccessDenied,
+// UnauthorizedOperation), which are reported as skipped instead of failed.
 type CollectionFailure struct {
-	Err    error
-	Region string
+	Err               error
+	Region            string
+	PermissionSkipped bool
 }
 
 // CollectionOptions holds the configuration for resource collection
 type CollectionOptions struct {
-	Region         string
-	Profile        string
-	OutputDir      string
-	Categories     string
-	HTML           bool
-	MaxConcurrency int
-	Timeout        time.Duration
+	Filter           *filter.Filter
+	Redactor         *redact.Redactor
+	Region           string
+	Profile          string
+	OutputDir        string
+	Categories       string
+	Accounts         string
+	AssumeRoleARN    string
+	AssumeRoleName   string
+	ExternalID       string
+	RoleSessionName  string
+	OrgOUPaths       string
+	OrgStates        string
+	OrgTags          string
+	ExcludeRegions   string
+	RetryMode        string
+	FailSeverity     string
+	GraphFocus       string
+	HTMLMode         string
+	Formats          []string
+	GraphFormats     []string
+	TagColumns       []string
+	Rules            []findings.Rule
+	FailOn           FailPolicy
+	SecretValues     resources.SecretValueOptions
+	Organization     bool
+	AllRegions       bool
+	HTML             bool
+	Resume           bool
+	MaxConcurrency   int
+	RetryMaxAttempts int
+	RateLimit        float64
+	RetryMaxBackoff  time.Duration
+	Timeout          time.Duration
+	GracePeriod      time.Duration
 }
 
-// collectionResult holds the result of collecting resources for a category
+// accountTarget is one AWS account to collect resources from.
+// roleARN is empty when the base credentials are used as-is.
+// name and ouPath are only set for accounts discovered through AWS Organizations.
+type accountTarget struct {
+	accountID string
+	name      string
+	ouPath    string
+	roleARN   string
+}
+
+// collectionResult holds the result of collecting resources for a category.
+// resumed is set for results loaded from a checkpoint instead of collected.
+// duration and apiCalls are those of collecting one region.
 type collectionResult struct {
 	err       error
 	category  string
 	region    string
 	resources []resources.Resource
+	duration  time.Duration
+	apiCalls  int64
+	resumed   bool
+	// checkpointFindings are the findings of the resumed pairs, evaluated
+	// before their resources were redacted and checkpointed.
+	checkpointFindings []findings.Finding
+	// resumedKeys holds the findings.ResourceKey of the resources of the
+	// resumed pairs, whose findings are not evaluated again.
+	resumedKeys map[string]struct{}
 }
 
 // main initializes and runs the CLI application for collecting AWS resources.
@@ -90,52 +176,22 @@ func main() {
 		Name:    "arc",
 		Usage:   "Collect AWS resources and output to CSV",
 		Version: version,
-		Flags: []cli.Flag{
-			&cli.BoolFlag{
-				Name:  "verbose",
-				Usage: "Enable verbose output",
-			},
-			&cli.StringFlag{
-				Name:    "region",
-				Aliases: []string{"r"},
-				Usage:   "AWS region(s) to use (comma-separated list accepted). The first region is used as the primary region for API client initialization",
-				Sources: cli.EnvVars("AWS_DEFAULT_REGION"),
-				Value:   "ap-northeast-1",
-			},
-			&cli.StringFlag{
-				Name:    "profile",
-				Usage:   "AWS profile to use",
-				Sources: cli.EnvVars("AWS_PROFILE"),
-			},
-			&cli.StringFlag{
-				Name:    "output-dir",
-				Aliases: []string{"D"},
-				Usage:   "Base output directory",
-				Value:   "./output",
-			},
-			&cli.StringFlag{
-				Name:    "categories",
-				Aliases: []string{"c"},
-				Usage:   "Comma-separated list of categories to collect (e.g. 'acm,ec2,cloudfront')",
-			},
-			&cli.BoolFlag{
-				Name:    "html",
-				Aliases: []string{"H"},
-				Usage:   "Generate HTML index",
-			},
-			&cli.IntFlag{
-				Name:    "concurrency",
-				Aliases: []string{"C"},
-				Usage:   "Maximum number of concurrent AWS API requests",
-				Value:   DefaultMaxConcurrency,
-			},
-			&cli.DurationFlag{
-				Name:  "timeout",
-				Usage: "Maximum total execution time (for example: 5m, 30m, 1h). Set 0 to disable",
-				Value: DefaultExecutionTimeout,
-			},
+		Commands: []*cli.Command{
+			newConfigCommand(),
+			newDiffCommand(),
+			newPermissionsCommand(),
+			newServeCommand(),
+		},
+		Flags: newRootFlags(),
+		OnUsageError: func(_ context.Context, _ *cli.Command, err error, _ bool) error {
+			return configError(err)
 		},
 		Action: func(c context.Context, cmd *cli.Command) error {
+			// Fill the flags not given on the command line from the configuration file
+			if err := applyConfigFile(cmd); err != nil {
+				return configError(err)
+			}
+
 			// Set up logger based on verbose flag
 			logLevel := slog.LevelInfo
 			if cmd.Bool("verbose") {
@@ -154,18 +210,101 @@ func main() {
 			html := cmd.Bool("html")
 			concurrency := cmd.Int("concurrency")
 			timeout := cmd.Duration("timeout")
+			formats, formatErr := parseFormats(cmd.String("format"))
+			if formatErr != nil {
+				return configError(formatErr)
+			}
+			graphFormats, graphErr := parseGraphFormats(cmd.String("graph"))
+			if graphErr != nil {
+				return configError(graphErr)
+			}
+			if html && !slices.Contains(formats, exporter.FormatCSV) {
+				return configError(ErrHTMLRequiresCSV)
+			}
+			htmlMode, htmlModeErr := parseHTMLMode(cmd.String("html-mode"))
+			if htmlModeErr != nil {
+				return configError(htmlModeErr)
+			}
+			// A standalone viewer needs the vendored libraries: check them before collecting.
+			if html && htmlMode == exporter.HTMLModeStandalone {
+				if assetsErr := exporter.CheckVendoredAssets(); assetsErr != nil {
+					return configError(assetsErr)
+				}
+			}
+			failOn, failOnErr := parseFailPolicy(cmd.String("fail-on"))
+			if failOnErr != nil {
+				return configError(failOnErr)
+			}
+			secretValues, secretValuesErr := parseSecretValues(cmd.String("secret-values"), cmd.String("secret-hash-salt"))
+			if secretValuesErr != nil {
+				return configError(secretValuesErr)
+			}
+			resourceFilter, filterErr := filter.New(&filter.Options{
+				ExcludeNames:  parseCommaList(cmd.String("exclude-names")),
+				ExcludeTags:   parseCommaList(cmd.String("exclude-tags")),
+				IncludeNames:  parseCommaList(cmd.String("include-names")),
+				IncludeTags:   parseCommaList(cmd.String("include-tags")),
+				SubCategories: parseCommaList(cmd.String("sub-categories")),
+			})
+			if filterErr != nil {
+				return configError(filterErr)
+			}
+			redactor, redactErr := redact.New(&redact.Options{
+				Detectors:   parseCommaList(cmd.String("redact-detectors")),
+				KeyPatterns: parseCommaList(cmd.String("redact-keys")),
+				Mode:        strings.ToLower(strings.TrimSpace(cmd.String("redact-mode"))),
+				Salt:        cmd.String("secret-hash-salt"),
+			})
+			if errors.Is(redactErr, redact.ErrSaltRequired) {
+				redactErr = fmt.Errorf("%w: set --secret-hash-salt", redactErr)
+			}
+			if redactErr != nil {
+				return configError(redactErr)
+			}
+			rules, rulesErr := loadFindingRules(cmd)
+			if rulesErr != nil {
+				return configError(rulesErr)
+			}
 			ctx, cancel := createRunContext(c, timeout)
 			defer cancel()
 
 			// Create collection options
 			opts := &CollectionOptions{
-				Region:         region,
-				Profile:        profile,
-				OutputDir:      outputDir,
-				Categories:     categories,
-				HTML:           html,
-				MaxConcurrency: concurrency,
-				Timeout:        timeout,
+				Region:           region,
+				Profile:          profile,
+				OutputDir:        outputDir,
+				Categories:       categories,
+				Accounts:         cmd.String("accounts"),
+				AssumeRoleARN:    cmd.String("assume-role-arn"),
+				AssumeRoleName:   cmd.String("assume-role-name"),
+				ExternalID:       cmd.String("external-id"),
+				RoleSessionName:  cmd.String("role-session-name"),
+				Organization:     cmd.Bool("org"),
+				OrgOUPaths:       cmd.String("org-ou-paths"),
+				OrgStates:        cmd.String("org-states"),
+				OrgTags:          cmd.String("org-tags"),
+				AllRegions:       cmd.Bool("all-regions"),
+				ExcludeRegions:   cmd.String("exclude-regions"),
+				Filter:           resourceFilter,
+				Redactor:         redactor,
+				Formats:          formats,
+				TagColumns:       parseCommaList(cmd.String("tag-columns")),
+				Rules:            rules,
+				FailSeverity:     cmd.String("fail-severity"),
+				FailOn:           failOn,
+				SecretValues:     secretValues,
+				GraphFormats:     graphFormats,
+				GraphFocus:       cmd.String("graph-focus"),
+				HTML:             html,
+				HTMLMode:         htmlMode,
+				MaxConcurrency:   concurrency,
+				RetryMode:        cmd.String("retry-mode"),
+				RetryMaxAttempts: cmd.Int("retry-max-attempts"),
+				RetryMaxBackoff:  cmd.Duration("retry-max-backoff"),
+				RateLimit:        cmd.Float("rate-limit"),
+				Resume:           cmd.Bool("resume"),
+				Timeout:          timeout,
+				GracePeriod:      cmd.Duration("grace-period"),
 			}
 
 			if timeout > 0 {
@@ -182,7 +321,224 @@ func main() {
 		// Create a default logger for fatal errors
 		defaultLogger := logger.NewSlogLogger(nil)
 		defaultLogger.Error("Application failed", "error", err)
-		os.Exit(1)
+		os.Exit(exitCode(err))
+	}
+}
+
+// newRootFlags returns the flags of the collection command. Each flag except
+// --config and --preset can also be set by its name in a configuration file.
+func newRootFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.BoolFlag{
+			Name:  "verbose",
+			Usage: "Enable verbose output",
+		},
+		&cli.StringFlag{
+			Name:    "config",
+			Usage:   "YAML configuration file setting any of these options by name. Command-line flags and their environment variables take precedence",
+			Sources: cli.EnvVars("ARC_CONFIG"),
+		},
+		&cli.StringFlag{
+			Name:  "preset",
+			Usage: "Name of a preset of the --config file to apply on top of its base options (e.g. 'security')",
+		},
+		&cli.StringFlag{
+			Name:    "region",
+			Aliases: []string{"r"},
+			Usage:   "AWS region(s) to use (comma-separated list accepted). The first region is used as the primary region for API client initialization",
+			Sources: cli.EnvVars("AWS_DEFAULT_REGION"),
+			Value:   "ap-northeast-1",
+		},
+		&cli.BoolFlag{
+			Name:  "all-regions",
+			Usage: "Collect from every region enabled for the account (EC2 DescribeRegions) instead of the --region list",
+		},
+		&cli.StringFlag{
+			Name:  "exclude-regions",
+			Usage: "Comma-separated list of regions to skip (e.g. 'ap-east-1,me-south-1')",
+		},
+		&cli.StringFlag{
+			Name:    "profile",
+			Usage:   "AWS profile to use",
+			Sources: cli.EnvVars("AWS_PROFILE"),
+		},
+		&cli.StringFlag{
+			Name:    "output-dir",
+			Aliases: []string{"D"},
+			Usage:   "Base output directory",
+			Value:   "./output",
+		},
+		&cli.StringFlag{
+			Name:    "categories",
+			Aliases: []string{"c"},
+			Usage:   "Comma-separated list of categories to collect (e.g. 'acm,ec2,cloudfront')",
+		},
+		&cli.StringFlag{
+			Name:  "format",
+			Usage: "Comma-separated output formats (csv,json,ndjson,sqlite,xlsx)",
+			Value: exporter.FormatCSV,
+		},
+		&cli.StringFlag{
+			Name:  "include-tags",
+			Usage: "Comma-separated key or key=value tag conditions resources must match (e.g. 'Team=payments'). Values accept * and ? wildcards",
+		},
+		&cli.StringFlag{
+			Name:  "exclude-tags",
+			Usage: "Comma-separated key or key=value tag conditions of resources to drop",
+		},
+		&cli.StringFlag{
+			Name:  "include-names",
+			Usage: "Comma-separated name/ARN patterns of resources to keep (glob, or regular expression with the 're:' prefix)",
+		},
+		&cli.StringFlag{
+			Name:  "exclude-names",
+			Usage: "Comma-separated name/ARN patterns of resources to drop (glob, or regular expression with the 're:' prefix)",
+		},
+		&cli.StringFlag{
+			Name:  "sub-categories",
+			Usage: "Comma-separated SubCategory1 values to keep, optionally prefixed with the category (e.g. 'ecs:TaskDefinition')",
+		},
+		&cli.StringFlag{
+			Name:  "tag-columns",
+			Usage: "Comma-separated list of tag keys to output as dedicated 'Tag:<key>' columns (e.g. 'Owner,Env')",
+		},
+		&cli.StringFlag{
+			Name:  "secret-values",
+			Usage: "Secrets Manager secret values to record: none (GetSecretValue is not called), redacted (JSON key names, length and salted hash) or plaintext",
+			Value: resources.SecretValuesNone,
+		},
+		&cli.StringFlag{
+			Name:    "secret-hash-salt",
+			Usage:   "Salt of the hashes of --secret-values redacted and --redact-mode hash. Use the same salt across runs to compare the hashes",
+			Sources: cli.EnvVars("ARC_SECRET_HASH_SALT"),
+		},
+		&cli.StringFlag{
+			Name:  "redact-mode",
+			Usage: "How sensitive values are redacted from every output: mask, hash, drop or none",
+			Value: redact.ModeMask,
+		},
+		&cli.StringFlag{
+			Name:  "redact-keys",
+			Usage: "Comma-separated case-insensitive glob patterns of the keys of key=value entries (e.g. environment variables) whose value is redacted",
+			Value: strings.Join(redact.DefaultKeyPatterns, ","),
+		},
+		&cli.StringFlag{
+			Name:  "redact-detectors",
+			Usage: "Comma-separated detectors of sensitive values redacted wherever they appear (" + strings.Join(redact.Detectors, ",") + ")",
+			Value: strings.Join(redact.Detectors, ","),
+		},
+		&cli.BoolFlag{
+			Name:  "findings",
+			Usage: "Evaluate the built-in security rules and write findings.csv and findings.json next to the resources directory",
+		},
+		&cli.StringFlag{
+			Name:  "rules",
+			Usage: "Comma-separated list of YAML rule files with CEL conditions, evaluated with the findings",
+		},
+		&cli.StringFlag{
+			Name:  "fail-severity",
+			Usage: "Exit with an error when a finding of this severity or higher is reported (critical, high, medium, low)",
+		},
+		&cli.StringFlag{
+			Name:  "fail-on",
+			Usage: "Collection failures that make the run exit with an error: any, none or categories:<list> (e.g. 'categories:ec2,s3'). Permission errors never do",
+			Value: FailOnAny,
+		},
+		&cli.StringFlag{
+			Name:  "graph",
+			Usage: "Comma-separated resource relationship graph formats (dot,json,mermaid) written next to the resources directory",
+		},
+		&cli.StringFlag{
+			Name:  "graph-focus",
+			Usage: "Limit the --graph output to the resources depending on this ARN, ID or name (e.g. an IAM role or KMS key)",
+		},
+		&cli.BoolFlag{
+			Name:    "html",
+			Aliases: []string{"H"},
+			Usage:   "Generate HTML index",
+		},
+		&cli.StringFlag{
+			Name:  "html-mode",
+			Usage: "HTML viewer mode with --html: server (libraries from CDN, data fetched over HTTP) or standalone (single offline index.html with inlined libraries and data)",
+			Value: exporter.HTMLModeServer,
+		},
+		&cli.IntFlag{
+			Name:    "concurrency",
+			Aliases: []string{"C"},
+			Usage:   "Maximum number of concurrent AWS API requests",
+			Value:   DefaultMaxConcurrency,
+		},
+		&cli.DurationFlag{
+			Name:  "timeout",
+			Usage: "Maximum total execution time (for example: 5m, 30m, 1h). Set 0 to disable",
+			Value: DefaultExecutionTimeout,
+		},
+		&cli.DurationFlag{
+			Name:  "grace-period",
+			Usage: "Time in-flight collectors may take to finish after an interrupt or --timeout before they are canceled. Outputs are then written with the unfinished categories marked incomplete in run.json",
+			Value: DefaultGracePeriod,
+		},
+		&cli.BoolFlag{
+			Name:  "resume",
+			Usage: "Resume an interrupted run: reuse the category and region pairs checkpointed in --output-dir instead of collecting them again",
+		},
+		&cli.StringFlag{
+			Name:  "retry-mode",
+			Usage: "AWS SDK retry mode (standard, adaptive). adaptive also slows down client-side when throttled",
+			Value: aws.RetryModeStandard,
+		},
+		&cli.IntFlag{
+			Name:  "retry-max-attempts",
+			Usage: "Maximum number of attempts per AWS API call, including the first one",
+			Value: DefaultRetryMaxAttempts,
+		},
+		&cli.DurationFlag{
+			Name:  "retry-max-backoff",
+			Usage: "Maximum delay between two attempts of an AWS API call (for example: 5s, 1m)",
+			Value: DefaultRetryMaxBackoff,
+		},
+		&cli.FloatFlag{
+			Name:  "rate-limit",
+			Usage: "Maximum AWS API requests per second per service, shared by all collectors. Set 0 to disable",
+		},
+		&cli.StringFlag{
+			Name:  "accounts",
+			Usage: "Comma-separated list of account IDs to collect by assuming --assume-role-name in each account",
+		},
+		&cli.StringFlag{
+			Name:  "assume-role-arn",
+			Usage: "ARN of an IAM role to assume before collecting (single account)",
+		},
+		&cli.StringFlag{
+			Name:  "assume-role-name",
+			Usage: "Name of the IAM role to assume in every account listed in --accounts",
+		},
+		&cli.StringFlag{
+			Name:  "external-id",
+			Usage: "External ID passed when assuming a role",
+		},
+		&cli.StringFlag{
+			Name:  "role-session-name",
+			Usage: "Session name used when assuming a role",
+			Value: aws.DefaultRoleSessionName,
+		},
+		&cli.BoolFlag{
+			Name:  "org",
+			Usage: "Discover target accounts from AWS Organizations and assume --assume-role-name in each of them",
+		},
+		&cli.StringFlag{
+			Name:  "org-ou-paths",
+			Usage: "Comma-separated list of OU paths to collect with --org, including nested OUs (e.g. '/Root/Workloads')",
+		},
+		&cli.StringFlag{
+			Name:  "org-states",
+			Usage: "Comma-separated list of account states to collect with --org",
+			Value: "ACTIVE",
+		},
+		&cli.StringFlag{
+			Name:  "org-tags",
+			Usage: "Comma-separated list of key=value account tags that must all match with --org",
+		},
 	}
 }
 
@@ -199,13 +555,24 @@ func (ce CollectionError) Error() string {
 }
 
 // collectResources runs collectors across regions and returns a map of successful
-// results per category and a map of per-category errors for collectors that failed.
+// results per category, a map of per-category errors for collectors that failed,
+// a map of per-category regions that were skipped because the region is not
+// enabled for the account (for example opt-in regions) or the collection was
+// denied by IAM, and the run report task of every (category, region) pair.
 // The caller can decide how to handle partial failures; this function will not
 // stop on first error in order to try to gather as many successful results as
 // possible.
 //
+// When checkpoints is not nil, every successful (category, region) result is
+// saved to it as it arrives, redacted by opts.Redactor, and, with opts.Resume,
+// the pairs it already holds are loaded instead of collected.
+//
+// Once ctx is canceled no new collection is started, and the collections in
+// flight get opts.GracePeriod to finish before they are canceled too. The pairs
+// that did not finish are reported in failed with their cancellation error.
+//
 // Note: Collectors must be initialized with AWS clients before calling this function.
-func collectResources(ctx context.Context, l *logger.SlogLogger, collectors map[string]resources.Collector, regionsToCheck []string, opts *CollectionOptions) (map[string]collectionResult, map[string][]CollectionFailure) {
+func collectResources(ctx context.Context, l *logger.SlogLogger, collectors map[string]resources.Collector, regionsToCheck []string, opts *CollectionOptions, checkpoints *checkpoint.Store) (map[string]collectionResult, map[string][]CollectionFailure, map[string][]CollectionFailure, []runreport.Task) {
 	// Collect resources in parallel using goroutines
 	// For each region and collector combination
 	var wg sync.WaitGroup
@@ -216,6 +583,8 @@ func collectResources(ctx context.Context, l *logger.SlogLogger, collectors map[
 		concurrency = DefaultMaxConcurrency
 	}
 	semaphore := make(chan struct{}, concurrency)
+	workCtx, stopWork := withGracePeriod(ctx, opts.GracePeriod)
+	defer stopWork()
 
 	for name, collector := range collectors {
 		for _, regionToCheck := range regionsToCheck {
@@ -223,6 +592,13 @@ func collectResources(ctx context.Context, l *logger.SlogLogger, collectors map[
 			go func(name string, collector resources.Collector, reg string) {
 				defer wg.Done()
 
+				if checkpoints != nil && opts.Resume {
+					if cp, ok := loadCheckpoint(l, checkpoints, name, reg); ok {
+						resultsChan <- resumedResult(l, cp, opts.Rules)
+						return
+					}
+				}
+
 				// Acquire semaphore, but stop waiting immediately when the parent context is canceled.
 				select {
 				case semaphore <- struct{}{}:
@@ -238,12 +614,16 @@ func collectResources(ctx context.Context, l *logger.SlogLogger, collectors map[
 				}
 
 				l.Info("Collecting resources", LogKeyCategory, name, "region", reg)
-				res, collectErr := collector.Collect(ctx, reg)
+				calls := &aws.CallCounter{}
+				startedAt := time.Now()
+				res, collectErr := collector.Collect(aws.WithCallCounter(workCtx, calls), reg)
 				resultsChan <- collectionResult{
 					category:  name,
 					region:    reg,
 					resources: res,
 					err:       collectErr,
+					duration:  time.Since(startedAt),
+					apiCalls:  calls.Count(),
 				}
 			}(name, collector, regionToCheck)
 		}
@@ -258,7 +638,37 @@ func collectResources(ctx context.Context, l *logger.SlogLogger, collectors map[
 	// Collect all results and merge resources from multiple regions
 	categoryResults := make(map[string]collectionResult)
 	failed := make(map[string][]CollectionFailure)
+	skipped := make(map[string][]CollectionFailure)
+	tasks := make([]runreport.Task, 0, len(collectors)*len(regionsToCheck))
 	for result := range resultsChan {
+		if aws.IsRegionNotEnabledError(result.err) {
+			l.Warn("Region skipped; region is not enabled for this account", LogKeyCategory, result.category, "region", result.region, LogKeyError, result.err)
+			skipped[result.category] = append(skipped[result.category], CollectionFailure{
+				Err:    result.err,
+				Region: result.region,
+			})
+			tasks = append(tasks, result.task(runreport.TaskSkipped))
+			continue
+		}
+		if result.err != nil && ctx.Err() != nil && isInterrupted(result.err) {
+			l.Warn("Collection interrupted", LogKeyCategory, result.category, "region", result.region, LogKeyError, result.err)
+			failed[result.category] = append(failed[result.category], CollectionFailure{
+				Err:    result.err,
+				Region: result.region,
+			})
+			tasks = append(tasks, result.task(runreport.TaskIncomplete))
+			continue
+		}
+		if aws.IsAccessDeniedError(result.err) {
+			l.Warn("Collection skipped; permission denied", LogKeyCategory, result.category, "region", result.region, LogKeyError, result.err)
+			skipped[result.category] = append(skipped[result.category], CollectionFailure{
+				Err:               result.err,
+				Region:            result.region,
+				PermissionSkipped: true,
+			})
+			tasks = append(tasks, result.task(runreport.TaskPermissionSkipped))
+			continue
+		}
 		if result.err != nil {
 			// track failures per category so caller can act on partial failures
 			l.Error("Error collecting resources", "category", result.category, "region", result.region, "error", result.err)
@@ -266,18 +676,157 @@ func collectResources(ctx context.Context, l *logger.SlogLogger, collectors map[
 				Err:    result.err,
 				Region: result.region,
 			})
+			tasks = append(tasks, result.task(runreport.TaskFailed))
 			continue
 		}
+		tasks = append(tasks, result.task(runreport.TaskComplete))
+		if checkpoints != nil && !result.resumed {
+			// Checkpoints are written to disk, so they only hold redacted values,
+			// and the findings evaluated before the redaction.
+			found := checkpointFindings(opts.Rules, opts.Redactor, result.category, result.resources)
+			if saveErr := checkpoints.Save(result.category, result.region, opts.Redactor.Redacted(result.resources), found); saveErr != nil {
+				l.Warn("Failed to save checkpoint", LogKeyCategory, result.category, "region", result.region, LogKeyError, saveErr)
+			}
+		}
 		// Merge resources from multiple regions
 		if existing, ok := categoryResults[result.category]; ok {
 			existing.resources = append(existing.resources, result.resources...)
+			existing.checkpointFindings = append(existing.checkpointFindings, result.checkpointFindings...)
+			if len(result.resumedKeys) > 0 {
+				if existing.resumedKeys == nil {
+					existing.resumedKeys = make(map[string]struct{}, len(result.resumedKeys))
+				}
+				maps.Copy(existing.resumedKeys, result.resumedKeys)
+			}
 			categoryResults[result.category] = existing
 		} else {
 			categoryResults[result.category] = result
 		}
 	}
 
-	return categoryResults, failed
+	return categoryResults, failed, skipped, tasks
+}
+
+// task returns the run report task of the result with status.
+func (r *collectionResult) task(status string) runreport.Task {
+	task := runreport.Task{
+		Category:   r.category,
+		Region:     r.region,
+		Status:     status,
+		DurationMs: r.duration.Milliseconds(),
+		Resources:  len(r.resources),
+		APICalls:   r.apiCalls,
+		Resumed:    r.resumed,
+	}
+	if r.err != nil {
+		task.Error = r.err.Error()
+	}
+	return task
+}
+
+// withGracePeriod returns a context that is canceled grace after ctx, so that
+// the work already started when ctx is canceled can finish. The returned
+// function releases the context and must be called once the work is done.
+func withGracePeriod(ctx context.Context, grace time.Duration) (context.Context, context.CancelFunc) {
+	workCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	stop := context.AfterFunc(ctx, func() {
+		timer := time.NewTimer(grace)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+			cancel()
+		case <-workCtx.Done():
+		}
+	})
+	return workCtx, func() {
+		stop()
+		cancel()
+	}
+}
+
+// isInterrupted reports whether err is the cancellation of a collection by an
+// interrupt or the timeout, rather than a failure of the collector.
+func isInterrupted(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
+
+// loadCheckpoint returns the checkpoint of category in region written by an
+// interrupted run. Unreadable checkpoints are logged and collected again.
+func loadCheckpoint(l *logger.SlogLogger, checkpoints *checkpoint.Store, category, region string) (*checkpoint.Checkpoint, bool) {
+	cp, ok, err := checkpoints.Load(category, region)
+	if err != nil {
+		l.Warn("Ignoring checkpoint", LogKeyCategory, category, "region", region, LogKeyError, err)
+		return nil, false
+	}
+	if !ok {
+		return nil, false
+	}
+	l.Info("Resuming from checkpoint", LogKeyCategory, category, "region", region, "collectedAt", cp.CollectedAt, "resources", len(cp.Resources))
+	return cp, true
+}
+
+// resumedResult returns the collection result of the checkpoint cp. Its
+// findings are those of the checkpoint for the rules that are still enabled;
+// the rules that were not enabled when cp was written are evaluated on its
+// redacted resources.
+func resumedResult(l *logger.SlogLogger, cp *checkpoint.Checkpoint, rules []findings.Rule) collectionResult {
+	result := collectionResult{
+		category:    cp.Category,
+		region:      cp.Region,
+		resources:   cp.Resources,
+		resumed:     true,
+		resumedKeys: make(map[string]struct{}, len(cp.Resources)),
+	}
+	for i := range cp.Resources {
+		result.resumedKeys[findings.ResourceKey(&cp.Resources[i])] = struct{}{}
+	}
+	if len(rules) == 0 {
+		return result
+	}
+
+	var evaluated []string
+	if cp.Findings != nil {
+		evaluated = cp.Findings.RuleIDs
+	}
+	var missing []findings.Rule
+	for i := range rules {
+		if !slices.Contains(evaluated, rules[i].ID) {
+			missing = append(missing, rules[i])
+		}
+	}
+	if cp.Findings != nil {
+		for i := range cp.Findings.List {
+			f := cp.Findings.List[i]
+			if slices.ContainsFunc(rules, func(r findings.Rule) bool { return r.ID == f.RuleID }) {
+				result.checkpointFindings = append(result.checkpointFindings, f)
+			}
+		}
+	}
+	if len(missing) > 0 {
+		l.Warn("Evaluating rules added since the checkpoint on redacted values", LogKeyCategory, cp.Category, "region", cp.Region, "rules", len(missing))
+		result.checkpointFindings = append(result.checkpointFindings, findings.Evaluate(missing, cp.Category, cp.Resources)...)
+	}
+	return result
+}
+
+// checkpointFindings evaluates rules over the resources of a (category, region)
+// pair before they are redacted and checkpointed, and returns the findings to
+// checkpoint with them, with redacted evidence. It returns nil without rules.
+func checkpointFindings(rules []findings.Rule, redactor *redact.Redactor, category string, res []resources.Resource) *checkpoint.Findings {
+	if len(rules) == 0 {
+		return nil
+	}
+	found := &checkpoint.Findings{
+		RuleIDs: make([]string, 0, len(rules)),
+		List:    findings.Evaluate(rules, category, res),
+	}
+	for i := range rules {
+		found.RuleIDs = append(found.RuleIDs, rules[i].ID)
+	}
+	for i := range found.List {
+		found.List[i].Evidence = redactor.Text(found.List[i].Evidence)
+	}
+	return found
 }
 
 // createRunContext returns a context canceled by OS signals and, optionally, by timeout.
@@ -296,8 +845,8 @@ func createRunContext(parent context.Context, timeout time.Duration) (context.Co
 
 // initializeRegions returns the list of regions to check based on the
 // user-provided list. It preserves order, deduplicates and ensures the
-// GlobalServiceRegion is present.
-func initializeRegions(userRegions []string) []string {
+// global service region of the partition (GlobalServiceRegion for aws) is present.
+func initializeRegions(userRegions []string, globalRegion string) []string {
 	seen := make(map[string]struct{})
 	var out []string
 	for _, r := range userRegions {
@@ -310,12 +859,54 @@ func initializeRegions(userRegions []string) []string {
 		seen[r] = struct{}{}
 		out = append(out, r)
 	}
-	if _, ok := seen[GlobalServiceRegion]; !ok {
-		out = append(out, GlobalServiceRegion)
+	if _, ok := seen[globalRegion]; !ok {
+		out = append(out, globalRegion)
 	}
 	return out
 }
 
+// excludeRegions returns regions without the excluded ones, preserving order.
+func excludeRegions(regions, excluded []string) []string {
+	if len(excluded) == 0 {
+		return regions
+	}
+	out := make([]string, 0, len(regions))
+	for _, r := range regions {
+		if !slices.Contains(excluded, r) {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+// resolveRegions returns the regions to collect for one account. With --all-regions
+// the regions enabled for the account are discovered through EC2 DescribeRegions,
+// otherwise the --region list is used. --exclude-regions is applied afterwards and
+// globalRegion is always kept so that global services are collected.
+func resolveRegions(ctx context.Context, l *logger.SlogLogger, cfg awssdk.Config, userRegions []string, globalRegion string, opts *CollectionOptions) ([]string, error) {
+	regions := userRegions
+	if opts.AllRegions {
+		discovered, err := aws.DiscoverEnabledRegions(ctx, ec2.NewFromConfig(cfg))
+		if err != nil {
+			return nil, fmt.Errorf("failed to discover enabled regions: %w", err)
+		}
+		l.Info("Enabled regions discovered", "count", len(discovered))
+		regions = discovered
+	}
+	return initializeRegions(excludeRegions(regions, parseCommaList(opts.ExcludeRegions)), globalRegion), nil
+}
+
+// validateRegionsPartition returns an error when a region does not belong to partition,
+// for example a commercial region used with GovCloud credentials.
+func validateRegionsPartition(regions []string, partition string) error {
+	for _, region := range regions {
+		if regionPartition := helpers.PartitionForRegion(region); regionPartition != partition {
+			return fmt.Errorf("%w: %s is in %s, caller is in %s", ErrRegionPartition, region, regionPartition, partition)
+		}
+	}
+	return nil
+}
+
 // parseCommaList splits a comma-separated string and trims spaces, returning
 // only non-empty elements in order.
 func parseCommaList(s string) []string {
@@ -365,6 +956,201 @@ func resolveResourcesDir(outputDir, accountID string) (string, error) {
 	return filepath.Join(filepath.Clean(outputDir), accountID, "resources"), nil
 }
 
+// resolveAccountTargets returns the accounts to collect. Without --accounts or
+// --assume-role-arn it returns the caller's own account with no role to assume.
+func resolveAccountTargets(identity string, opts *CollectionOptions) ([]accountTarget, error) {
+	accountIDs := parseCommaList(opts.Accounts)
+	if len(accountIDs) > 0 && opts.AssumeRoleARN != "" {
+		return nil, configError(ErrConflictingRoleOptions)
+	}
+
+	if opts.AssumeRoleARN != "" {
+		accountID, err := helpers.ExtractAccountID(opts.AssumeRoleARN)
+		if err != nil {
+			return nil, configError(fmt.Errorf("failed to extract account ID from role ARN: %w", err))
+		}
+		if !isAccountID(accountID) {
+			return nil, configError(fmt.Errorf("%w: %s", ErrInvalidAccountID, accountID))
+		}
+		return []accountTarget{{accountID: accountID, roleARN: opts.AssumeRoleARN}}, nil
+	}
+
+	if len(accountIDs) > 0 {
+		if opts.AssumeRoleName == "" {
+			return nil, configError(ErrMissingRoleName)
+		}
+		callerARN, err := helpers.ParseARN(identity)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse caller identity ARN: %w", err)
+		}
+		targets := make([]accountTarget, 0, len(accountIDs))
+		for _, accountID := range accountIDs {
+			if !isAccountID(accountID) {
+				return nil, configError(fmt.Errorf("%w: %s", ErrInvalidAccountID, accountID))
+			}
+			targets = append(targets, accountTarget{
+				accountID: accountID,
+				roleARN:   helpers.IAMRoleARN(callerARN.Partition, accountID, opts.AssumeRoleName),
+			})
+		}
+		return targets, nil
+	}
+
+	accountID, err := helpers.ExtractAccountID(identity)
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract account ID from ARN: %w", err)
+	}
+	return []accountTarget{{accountID: accountID}}, nil
+}
+
+// discoverAccountTargets lists the accounts of the caller's organization that match
+// the --org-* filters. The caller's own account is collected with the base
+// credentials; every other account is collected by assuming --assume-role-name.
+func discoverAccountTargets(ctx context.Context, l *logger.SlogLogger, cfg awssdk.Config, identity string, opts *CollectionOptions) ([]accountTarget, error) {
+	if opts.Accounts != "" || opts.AssumeRoleARN != "" {
+		return nil, configError(ErrConflictingOrgOptions)
+	}
+	if opts.AssumeRoleName == "" {
+		return nil, configError(ErrMissingRoleName)
+	}
+	tags, err := parseTagFilter(opts.OrgTags)
+	if err != nil {
+		return nil, configError(err)
+	}
+
+	l.Info("Discovering accounts from AWS Organizations...")
+	accounts, err := aws.DiscoverOrganizationAccounts(ctx, organizations.NewFromConfig(cfg), &aws.OrganizationAccountFilter{
+		OUPaths: parseCommaList(opts.OrgOUPaths),
+		States:  parseCommaList(opts.OrgStates),
+		Tags:    tags,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover organization accounts: %w", err)
+	}
+	l.Info("Organization accounts discovered", "count", len(accounts))
+
+	return organizationTargets(identity, accounts, opts.AssumeRoleName)
+}
+
+// organizationTargets converts discovered organization accounts into collection targets.
+func organizationTargets(identity string, accounts []aws.OrganizationAccount, roleName string) ([]accountTarget, error) {
+	if len(accounts) == 0 {
+		return nil, ErrNoTargetAccounts
+	}
+	callerARN, err := helpers.ParseARN(identity)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse caller identity ARN: %w", err)
+	}
+
+	targets := make([]accountTarget, 0, len(accounts))
+	for _, account := range accounts {
+		if !isAccountID(account.ID) {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidAccountID, account.ID)
+		}
+		target := accountTarget{
+			accountID: account.ID,
+			name:      account.Name,
+			ouPath:    account.OUPath,
+		}
+		if account.ID != callerARN.AccountID {
+			target.roleARN = helpers.IAMRoleARN(callerARN.Partition, account.ID, roleName)
+		}
+		targets = append(targets, target)
+	}
+	return targets, nil
+}
+
+// parseTagFilter parses a comma-separated list of key=value pairs.
+func parseTagFilter(s string) (map[string]string, error) {
+	pairs := parseCommaList(s)
+	tags := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		key, value, ok := strings.Cut(pair, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("%w: %q (expected key=value)", ErrInvalidTagFilter, pair)
+		}
+		tags[key] = strings.TrimSpace(value)
+	}
+	return tags, nil
+}
+
+// parseFormats parses a comma-separated list of output formats.
+// Formats are lower-cased and deduplicated; an empty list defaults to csv.
+func parseFormats(s string) ([]string, error) {
+	formats := parseCommaList(strings.ToLower(s))
+	for _, format := range formats {
+		switch format {
+		case exporter.FormatCSV, exporter.FormatJSON, exporter.FormatNDJSON, exporter.FormatSQLite, exporter.FormatXLSX:
+		default:
+			return nil, fmt.Errorf("%w: %q (supported: csv, json, ndjson, sqlite, xlsx)", ErrUnknownFormat, format)
+		}
+	}
+	if len(formats) == 0 {
+		return []string{exporter.FormatCSV}, nil
+	}
+	return formats, nil
+}
+
+// parseGraphFormats parses the comma-separated --graph value.
+func parseGraphFormats(s string) ([]string, error) {
+	formats := parseCommaList(strings.ToLower(s))
+	for _, format := range formats {
+		if !slices.Contains(graph.Formats, format) {
+			return nil, fmt.Errorf("%w: %q (supported graph formats: %s)", ErrUnknownFormat, format, strings.Join(graph.Formats, ", "))
+		}
+	}
+	return formats, nil
+}
+
+// parseHTMLMode parses the --html-mode value.
+func parseHTMLMode(s string) (string, error) {
+	mode := strings.ToLower(strings.TrimSpace(s))
+	if !slices.Contains(exporter.HTMLModes, mode) {
+		return "", fmt.Errorf("%w: %q (supported: %s)", exporter.ErrUnknownHTMLMode, s, strings.Join(exporter.HTMLModes, ", "))
+	}
+	return mode, nil
+}
+
+// parseSecretValueMode parses the --secret-values value. An empty value is none.
+func parseSecretValueMode(s string) (string, error) {
+	mode := strings.ToLower(strings.TrimSpace(s))
+	if mode == "" {
+		return resources.SecretValuesNone, nil
+	}
+	if !slices.Contains(resources.SecretValueModes, mode) {
+		return "", fmt.Errorf("%w: %q (supported: %s)", resources.ErrUnknownSecretValueMode, s, strings.Join(resources.SecretValueModes, ", "))
+	}
+	return mode, nil
+}
+
+// parseSecretValues parses --secret-values and --secret-hash-salt. The redacted
+// mode requires a salt so that the hashes of short values cannot be matched
+// by hashing candidate values without it.
+func parseSecretValues(mode, salt string) (resources.SecretValueOptions, error) {
+	parsed, err := parseSecretValueMode(mode)
+	if err != nil {
+		return resources.SecretValueOptions{}, err
+	}
+	if parsed == resources.SecretValuesRedacted && salt == "" {
+		return resources.SecretValueOptions{}, ErrSecretHashSaltRequired
+	}
+	return resources.SecretValueOptions{Mode: parsed, Salt: salt}, nil
+}
+
+// isAccountID reports whether s is a 12-digit AWS account ID.
+func isAccountID(s string) bool {
+	if len(s) != AccountIDLength {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
 func writeCategoryCSVFile(path string, list []resources.Resource, cols []resources.Column) error {
 	catFile, err := os.Create(path) //nolint:gosec // G304 - path is controlled and sanitized
 	if err != nil {
@@ -381,60 +1167,404 @@ func writeCategoryCSVFile(path string, list []resources.Resource, cols []resourc
 	return nil
 }
 
-// runCollection executes the main resource collection logic
+// runCollection executes the main resource collection logic.
+// It resolves the target accounts and runs collectAccount for each of them in turn.
 func runCollection(ctx context.Context, l *logger.SlogLogger, opts *CollectionOptions) error {
-	region := opts.Region
-	profile := opts.Profile
-	outputDir := opts.OutputDir
-	categoryStr := opts.Categories
-	html := opts.HTML
-
 	// Parse regions (allow comma-separated list). The first region is used
 	// to initialize the AWS config (primary region). The full list will be
-	// expanded with GlobalServiceRegion when collecting.
-	userRegions := parseCommaList(region)
+	// expanded with the global service region of the partition when collecting.
+	userRegions := parseCommaList(opts.Region)
 	if len(userRegions) == 0 {
 		userRegions = []string{"ap-northeast-1"}
 	}
 
 	// Initialize AWS Config with the primary region (first in the list) and profile
 	primaryRegion := userRegions[0]
-	cfg, err := aws.NewConfig(ctx, primaryRegion, profile)
+	apiStats := aws.NewAPIStats()
+	retryOpts := &aws.RetryOptions{
+		Limiter:     aws.NewServiceRateLimiter(opts.RateLimit),
+		Mode:        opts.RetryMode,
+		Stats:       apiStats,
+		MaxAttempts: opts.RetryMaxAttempts,
+		MaxBackoff:  opts.RetryMaxBackoff,
+	}
+	baseCfg, err := aws.NewConfig(ctx, primaryRegion, opts.Profile, retryOpts)
+	if errors.Is(err, aws.ErrInvalidRetryMode) {
+		return configError(err)
+	}
 	if err != nil {
-		return fmt.Errorf("failed to load aws config: %w", err)
+		return fmt.Errorf("%w: failed to load aws config: %w", ErrCredentials, err)
 	}
+	defer logThrottleSummary(l, apiStats)
 
 	// Check AWS credentials before any AWS API usage
 	l.Info("Checking AWS credentials...")
-	identity, err := validation.CheckAWSCredentials(ctx, &cfg)
+	identity, err := validation.CheckAWSCredentials(ctx, &baseCfg)
 	if err != nil {
-		return fmt.Errorf("aws credentials check failed: %w", err)
+		return fmt.Errorf("%w: check failed: %w", ErrCredentials, err)
 	}
 	l.Info("AWS identity", "identity", identity)
 
-	// Extract account ID from identity ARN
-	accountID, err := helpers.ExtractAccountID(identity)
+	// Detect the partition (aws, aws-cn, aws-us-gov) so that global services are
+	// collected from the right region and ARNs are partition-correct.
+	callerARN, err := helpers.ParseARN(identity)
+	if err != nil {
+		return fmt.Errorf("failed to parse caller identity ARN: %w", err)
+	}
+	if partitionErr := validateRegionsPartition(userRegions, callerARN.Partition); partitionErr != nil {
+		return configError(partitionErr)
+	}
+	globalRegion := helpers.GlobalRegionForPartition(callerARN.Partition)
+	l.Info("AWS partition", "partition", callerARN.Partition, "globalRegion", globalRegion)
+
+	var targets []accountTarget
+	if opts.Organization {
+		targets, err = discoverAccountTargets(ctx, l, baseCfg, identity, opts)
+	} else {
+		targets, err = resolveAccountTargets(identity, opts)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to resolve target accounts: %w", err)
+	}
+	multiAccount := isMultiAccount(opts)
+
+	var accountErrs []error
+	var indexEntries []exporter.AccountIndexEntry
+	for _, target := range targets {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			accountErrs = append(accountErrs, fmt.Errorf("account %s: %w", target.accountID, ctxErr))
+			continue
+		}
+
+		cfg := baseCfg
+		if target.roleARN != "" {
+			l.Info("Assuming role", "accountID", target.accountID, "roleARN", target.roleARN)
+			cfg = aws.NewAssumeRoleConfig(baseCfg, &aws.AssumeRoleOptions{
+				RoleARN:     target.roleARN,
+				ExternalID:  opts.ExternalID,
+				SessionName: opts.RoleSessionName,
+			})
+			if _, credErr := validation.CheckAWSCredentials(ctx, &cfg); credErr != nil {
+				l.Error("Failed to assume role", "accountID", target.accountID, LogKeyError, credErr)
+				accountErrs = append(accountErrs, fmt.Errorf("account %s: %w: assume role failed: %w", target.accountID, ErrCredentials, credErr))
+				continue
+			}
+		}
+
+		accountDisplay, collectErr := collectAccount(ctx, l, cfg, target, userRegions, globalRegion, opts)
+		if !multiAccount {
+			return collectErr
+		}
+		if accountDisplay != "" {
+			indexEntries = append(indexEntries, exporter.AccountIndexEntry{
+				AccountID:   target.accountID,
+				DisplayName: accountDisplay,
+				OUPath:      target.ouPath,
+			})
+		}
+		if collectErr != nil {
+			l.Error("Account collection failed", "accountID", target.accountID, LogKeyError, collectErr)
+			accountErrs = append(accountErrs, fmt.Errorf("account %s: %w", target.accountID, collectErr))
+		}
+	}
+
+	// The accounts collected before an interruption are still listed.
+	outCtx := context.WithoutCancel(ctx)
+	if manifestErr := exporter.WriteAccountsManifest(outCtx, opts.OutputDir, indexEntries); manifestErr != nil {
+		accountErrs = append(accountErrs, fmt.Errorf("failed to write accounts manifest: %w", manifestErr))
+	}
+	if opts.HTML && len(indexEntries) > 0 {
+		if indexErr := exporter.GenerateAccountsIndex(outCtx, opts.OutputDir, indexEntries); indexErr != nil {
+			accountErrs = append(accountErrs, fmt.Errorf("failed to generate accounts index: %w", indexErr))
+		} else {
+			l.Info("Accounts index generated successfully", "indexPath", filepath.Join(opts.OutputDir, "index.html"))
+		}
+	}
+
+	return errors.Join(accountErrs...)
+}
+
+// isMultiAccount reports whether opts targets accounts explicitly, with --org,
+// --accounts or --assume-role-arn, even a single one. Such runs write the
+// multi-account outputs: accounts.json and, with --html, the accounts index.
+func isMultiAccount(opts *CollectionOptions) bool {
+	return opts.Organization || len(parseCommaList(opts.Accounts)) > 0 || opts.AssumeRoleARN != ""
+}
+
+// logThrottleSummary logs the number of throttling errors per AWS service seen during the run.
+func logThrottleSummary(l *logger.SlogLogger, stats *aws.APIStats) {
+	total := stats.TotalThrottles()
+	if total == 0 {
+		l.Info("No AWS API throttling detected")
+		return
+	}
+	throttles := stats.Throttles()
+	services := slices.Sorted(maps.Keys(throttles))
+	for _, service := range services {
+		l.Warn("AWS API throttled", "service", service, "count", throttles[service])
+	}
+	l.Warn("AWS API throttling summary", "total", total, "hint", "lower --concurrency or set --rate-limit / --retry-mode=adaptive")
+}
+
+// applyResourceTags fills the tags of resources that collectors did not tag, using
+// the Resource Groups Tagging API of each region. Tags are optional, so failures
+// (for example when tag:GetResources is not allowed) are only logged.
+func applyResourceTags(ctx context.Context, l *logger.SlogLogger, cfg *awssdk.Config, regions []string, categoryResults map[string]collectionResult) {
+	loader, err := resources.NewTagLoader(cfg, regions)
 	if err != nil {
-		return fmt.Errorf("failed to extract account ID from ARN: %w", err)
+		l.Warn("Failed to initialize tag loader", LogKeyError, err)
+		return
+	}
+	tagsByARN := make(map[string]map[string]string)
+	for _, region := range regions {
+		tags, loadErr := loader.Load(ctx, region)
+		if loadErr != nil {
+			l.Warn("Failed to load resource tags", "region", region, LogKeyError, loadErr)
+			continue
+		}
+		maps.Copy(tagsByARN, tags)
+	}
+	tagged := 0
+	for _, result := range categoryResults {
+		tagged += resources.ApplyTags(result.resources, tagsByARN)
+	}
+	l.Debug("Applied resource tags", "taggedResources", tagged)
+}
+
+// filterResources applies the resource filter to every category in place.
+func filterResources(l *logger.SlogLogger, resourceFilter *filter.Filter, categoryResults map[string]collectionResult) {
+	kept, dropped := 0, 0
+	for category, result := range categoryResults {
+		filtered := resourceFilter.Apply(category, result.resources)
+		kept += len(filtered)
+		dropped += len(result.resources) - len(filtered)
+		result.resources = filtered
+		categoryResults[category] = result
+	}
+	l.Info("Applied resource filters", "kept", kept, "dropped", dropped)
+}
+
+// redactResources redacts the sensitive values of every category in place and
+// returns the number of values redacted per category.
+func redactResources(l *logger.SlogLogger, redactor *redact.Redactor, categoryResults map[string]collectionResult) map[string]int {
+	counts := make(map[string]int, len(categoryResults))
+	total := 0
+	for category, result := range categoryResults {
+		n := redactor.Apply(result.resources)
+		if n > 0 {
+			counts[category] = n
+			l.Debug("Redacted sensitive values", LogKeyCategory, category, "count", n)
+		}
+		total += n
+	}
+	l.Info("Applied redaction", "redactedValues", total)
+	return counts
+}
+
+// loadFindingRules returns the built-in rules enabled by --findings and the rules
+// of the --rules files, and validates --fail-severity.
+func loadFindingRules(cmd *cli.Command) ([]findings.Rule, error) {
+	var rules []findings.Rule
+	if cmd.Bool("findings") {
+		rules = findings.Builtin()
+	}
+	if paths := parseCommaList(cmd.String("rules")); len(paths) > 0 {
+		custom, err := findings.LoadRules(paths, resources.CollectorNames())
+		if err != nil {
+			return nil, fmt.Errorf("failed to load rules: %w", err)
+		}
+		rules = append(rules, custom...)
 	}
+	if severity := cmd.String("fail-severity"); severity != "" {
+		if err := findings.ValidateSeverity(severity); err != nil {
+			return nil, fmt.Errorf("invalid --fail-severity: %w", err)
+		}
+		if len(rules) == 0 {
+			return nil, ErrFailSeverityNoRules
+		}
+	}
+	return rules, nil
+}
+
+// writeFindings evaluates rules over the collected resources and writes the
+// findings, most severe first, to findings.csv and findings.json in accountDir.
+// The resources are not redacted yet, so the evidence is redacted with redactor.
+// It returns the findings written.
+func writeFindings(l *logger.SlogLogger, accountDir string, categories []string, categoryResults map[string]collectionResult, rules []findings.Rule, redactor *redact.Redactor) ([]findings.Finding, error) {
+	var list []findings.Finding
+	for _, category := range categories {
+		list = append(list, categoryFindings(rules, category, categoryResults[category])...)
+	}
+	findings.Sort(list)
+	for i := range list {
+		list[i].Evidence = redactor.Text(list[i].Evidence)
+	}
+
+	csvPath := filepath.Join(accountDir, findings.CSVFile)
+	if err := writeResourcesFile(csvPath, list, findings.WriteCSV); err != nil {
+		return nil, err
+	}
+	if err := writeResourcesFile(filepath.Join(accountDir, findings.JSONFile), list, findings.WriteJSON); err != nil {
+		return nil, err
+	}
+	counts := findings.CountBySeverity(list)
+	l.Info("Findings written", LogKeyFile, csvPath, "findings", len(list),
+		findings.SeverityCritical, counts[findings.SeverityCritical],
+		findings.SeverityHigh, counts[findings.SeverityHigh],
+		findings.SeverityMedium, counts[findings.SeverityMedium],
+		findings.SeverityLow, counts[findings.SeverityLow])
+	return list, nil
+}
+
+// categoryFindings returns the findings of rules over the resources of result.
+// The resources of resumed pairs are redacted, so their checkpointed findings
+// are used instead, for the resources that the filters kept.
+func categoryFindings(rules []findings.Rule, category string, result collectionResult) []findings.Finding {
+	if len(result.resumedKeys) == 0 {
+		return findings.Evaluate(rules, category, result.resources)
+	}
+	kept := make(map[string]struct{}, len(result.resources))
+	var collected []resources.Resource
+	for i := range result.resources {
+		key := findings.ResourceKey(&result.resources[i])
+		kept[key] = struct{}{}
+		if _, resumed := result.resumedKeys[key]; !resumed {
+			collected = append(collected, result.resources[i])
+		}
+	}
+	list := findings.Evaluate(rules, category, collected)
+	for i := range result.checkpointFindings {
+		if _, ok := kept[result.checkpointFindings[i].ResourceKey()]; ok {
+			list = append(list, result.checkpointFindings[i])
+		}
+	}
+	return list
+}
+
+// removeFindings removes findings.csv and findings.json from accountDir.
+func removeFindings(accountDir string) error {
+	for _, name := range []string{findings.CSVFile, findings.JSONFile} {
+		if err := os.Remove(filepath.Join(accountDir, name)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("failed to remove previous %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// writeGraph writes the relationship graph of the collected resources to
+// graph.<format> files in accountDir. A non-empty focus keeps only the
+// resources depending on it.
+func writeGraph(l *logger.SlogLogger, accountDir string, categoryResults map[string]collectionResult, formats []string, focus string) error {
+	g := graph.Build(resourcesByCategory(categoryResults))
+	if focus != "" {
+		g = g.Focus(focus)
+		if len(g.Nodes) == 0 {
+			l.Warn("No resource matches the graph focus", "focus", focus)
+		}
+	}
+	for _, format := range formats {
+		path := filepath.Join(accountDir, graph.FileName(format))
+		write := graph.Writer(format)
+		if err := writeOutputFile(path, func(w io.Writer) error { return write(w, g) }); err != nil {
+			return err
+		}
+		l.Info("Relationship graph written", LogKeyFile, path, "nodes", len(g.Nodes), "edges", len(g.Edges))
+	}
+	return nil
+}
+
+// writeRowIndex writes relations.json, the relationship index the HTML viewer
+// uses to list the rows related to a row, to accountDir.
+func writeRowIndex(l *logger.SlogLogger, accountDir string, categoryResults map[string]collectionResult) error {
+	idx := graph.BuildRowIndex(resourcesByCategory(categoryResults))
+	path := filepath.Join(accountDir, graph.RowIndexFile)
+	if err := writeOutputFile(path, func(w io.Writer) error { return graph.WriteRowIndex(w, idx) }); err != nil {
+		return err
+	}
+	l.Info("Relationship index written", LogKeyFile, path, "nodes", len(idx.Nodes), "edges", len(idx.Edges))
+	return nil
+}
+
+// buildRunReport returns the run report of the collection tasks of run.
+// Output failures, recorded in failed under a pseudo region such as "output",
+// are reported as failed tasks too.
+func buildRunReport(run runreport.Run, tasks []runreport.Task, failed map[string][]CollectionFailure, interruption error) *runreport.Report {
+	type key struct{ category, region string }
+	seen := make(map[key]bool, len(tasks))
+	for _, t := range tasks {
+		seen[key{t.Category, t.Region}] = true
+	}
+	all := slices.Clone(tasks)
+	for _, category := range slices.Sorted(maps.Keys(failed)) {
+		for _, f := range failed[category] {
+			if seen[key{category, f.Region}] {
+				continue
+			}
+			all = append(all, runreport.Task{Category: category, Region: f.Region, Status: runreport.TaskFailed, Error: f.Err.Error()})
+		}
+	}
+	return runreport.New(run, all, interruption)
+}
+
+// writeRunReport writes run.json to resourcesDir and logs the incomplete tasks of an interrupted run.
+func writeRunReport(l *logger.SlogLogger, resourcesDir string, report *runreport.Report) error {
+	path := filepath.Join(resourcesDir, runreport.FileName)
+	if err := writeOutputFile(path, func(w io.Writer) error { return runreport.Write(w, report) }); err != nil {
+		return err
+	}
+	for _, task := range report.Incomplete() {
+		l.Warn("Incomplete category", LogKeyCategory, task.Category, "region", task.Region)
+	}
+	l.Info("Run report written", LogKeyFile, path, "status", report.Status)
+	return nil
+}
+
+// resourcesByCategory returns the collected resources keyed by category.
+func resourcesByCategory(categoryResults map[string]collectionResult) map[string][]resources.Resource {
+	categoryResources := make(map[string][]resources.Resource, len(categoryResults))
+	for category, result := range categoryResults {
+		categoryResources[category] = result.resources
+	}
+	return categoryResources
+}
+
+// collectAccount collects resources of a single account with the given config and
+// writes them to {outputDir}/{accountID}/resources. It returns the display name
+// used for the account in HTML output (empty when no output was written).
+func collectAccount(ctx context.Context, l *logger.SlogLogger, cfg awssdk.Config, target accountTarget, userRegions []string, globalRegion string, opts *CollectionOptions) (string, error) {
+	accountID := target.accountID
+	outputDir := opts.OutputDir
+	categoryStr := opts.Categories
+	html := opts.HTML
+	startedAt := time.Now()
 	l.Info("Account ID", "accountID", accountID)
 
 	// Create output directory structure: {outputDir}/{accountID}/resources
 	resourcesDir, pathErr := resolveResourcesDir(outputDir, accountID)
 	if pathErr != nil {
-		return fmt.Errorf("invalid output path: %w", pathErr)
+		return "", fmt.Errorf("invalid output path: %w", pathErr)
 	}
 	if mkdirErr := os.MkdirAll(resourcesDir, DefaultDirPerm); mkdirErr != nil {
-		return fmt.Errorf("failed to create output directory: %w", mkdirErr)
+		return "", fmt.Errorf("failed to create output directory: %w", mkdirErr)
+	}
+	// Without rules no findings are written; those of a previous run would be
+	// shown as current by the HTML viewer and arc serve.
+	if len(opts.Rules) == 0 {
+		if removeErr := removeFindings(filepath.Dir(resourcesDir)); removeErr != nil {
+			return "", removeErr
+		}
 	}
 
-	// Initialize regions to check (support multiple regions and always include GlobalServiceRegion)
-	regionsToCheck := initializeRegions(userRegions)
+	// Initialize regions to check (support multiple regions and always include the global service region)
+	regionsToCheck, regionsErr := resolveRegions(ctx, l, cfg, userRegions, globalRegion, opts)
+	if regionsErr != nil {
+		return "", regionsErr
+	}
 	l.Info("Regions to check", "regions", regionsToCheck)
 
 	// Initialize collectors with AWS clients for all regions
 	if initErr := resources.InitializeCollectors(&cfg, regionsToCheck); initErr != nil {
-		return fmt.Errorf("failed to initialize collectors: %w", initErr)
+		return "", fmt.Errorf("failed to initialize collectors: %w", initErr)
 	}
 
 	// Iterate over registered collectors
@@ -443,9 +1573,41 @@ func runCollection(ctx context.Context, l *logger.SlogLogger, opts *CollectionOp
 	for _, cat := range unknownCategories {
 		l.Warn("Unknown category specified", "category", cat)
 	}
+	// Secret values are only read with --secret-values.
+	if secrets, ok := collectors["secretsmanager"].(*resources.SecretsManagerCollector); ok {
+		secrets.SetSecretValueOptions(opts.SecretValues)
+	}
+	// Every output gets the Tags column and the --tag-columns columns.
+	for name, collector := range collectors {
+		collectors[name] = resources.WithTagColumns(collector, opts.TagColumns)
+	}
+
+	// Checkpoint every completed (category, region) pair so that --resume can
+	// skip it after an interruption. A fresh run starts from no checkpoint.
+	checkpoints := checkpoint.NewStore(filepath.Join(filepath.Dir(resourcesDir), checkpoint.DirName), version)
+	if !opts.Resume {
+		if clearErr := checkpoints.Clear(); clearErr != nil {
+			return "", clearErr
+		}
+	}
 
 	// Collect resources from all collectors and regions
-	categoryResults, failedCategories := collectResources(ctx, l, collectors, regionsToCheck, opts)
+	categoryResults, failedCategories, skippedCategories, tasks := collectResources(ctx, l, collectors, regionsToCheck, opts, checkpoints)
+	if regions := skippedRegionNames(skippedCategories); len(regions) > 0 {
+		l.Warn("Some regions were skipped because they are not enabled for this account", "skippedRegions", regions)
+	}
+	// An interrupted run still writes every output from what was collected,
+	// without calling AWS again.
+	interruption := context.Cause(ctx)
+	outCtx := context.WithoutCancel(ctx)
+	if interruption != nil {
+		l.Warn("Run interrupted; writing partial outputs", "cause", interruption)
+	} else {
+		applyResourceTags(ctx, l, &cfg, regionsToCheck, categoryResults)
+	}
+	if opts.Filter != nil {
+		filterResources(l, opts.Filter, categoryResults)
+	}
 
 	// Sort categories by name for deterministic output
 	var categories []string
@@ -479,6 +1641,129 @@ func runCollection(ctx context.Context, l *logger.SlogLogger, opts *CollectionOp
 		}
 	}
 
+	// Findings at or above --fail-severity fail the run once every output is written.
+	// Rules see the collected values; their evidence is redacted.
+	var severityErr error
+	if len(opts.Rules) > 0 {
+		list, findingsErr := writeFindings(l, filepath.Dir(resourcesDir), categories, categoryResults, opts.Rules, opts.Redactor)
+		if findingsErr != nil {
+			return "", findingsErr
+		}
+		if opts.FailSeverity != "" {
+			if failing := findings.AtLeast(list, opts.FailSeverity); len(failing) > 0 {
+				severityErr = fmt.Errorf("%w: %d finding(s) at %s or above", ErrFindingsAtFailSeverity, len(failing), opts.FailSeverity)
+			}
+		}
+	}
+
+	// Sensitive values are redacted before any other output.
+	var redactions map[string]int
+	if opts.Redactor != nil {
+		redactions = redactResources(l, opts.Redactor, categoryResults)
+	}
+
+	if len(opts.GraphFormats) > 0 {
+		if graphErr := writeGraph(l, filepath.Dir(resourcesDir), categoryResults, opts.GraphFormats, opts.GraphFocus); graphErr != nil {
+			return "", graphErr
+		}
+	}
+
+	if slices.Contains(opts.Formats, exporter.FormatCSV) {
+		if csvErr := writeCSVOutputs(l, resourcesDir, categories, categoryResults, collectors, failedCategories); csvErr != nil {
+			return "", csvErr
+		}
+	}
+	for _, format := range opts.Formats {
+		switch format {
+		case exporter.FormatCSV:
+			// Written above so that the HTML viewer can rely on it.
+		case exporter.FormatSQLite:
+			meta := exporter.RunMetadata{
+				AccountID: accountID,
+				Regions:   regionsToCheck,
+				StartedAt: startedAt,
+				Version:   version,
+			}
+			outputs := categoryOutputs(categories, categoryResults, collectors, true)
+			if sqliteErr := writeSQLiteOutput(outCtx, l, resourcesDir, meta, outputs); sqliteErr != nil {
+				return "", sqliteErr
+			}
+		case exporter.FormatXLSX:
+			xlsxPath := filepath.Join(resourcesDir, "all.xlsx")
+			l.Info("Writing all results to workbook", LogKeyFile, xlsxPath)
+			outputs := categoryOutputs(categories, categoryResults, collectors, false)
+			if xlsxErr := writeResourcesFile(xlsxPath, outputs, exporter.WriteXLSX); xlsxErr != nil {
+				return "", fmt.Errorf("failed to write all.xlsx: %w", xlsxErr)
+			}
+		default:
+			if jsonErr := writeJSONOutputs(l, resourcesDir, format, categories, categoryResults, failedCategories); jsonErr != nil {
+				return "", jsonErr
+			}
+		}
+	}
+
+	run := runreport.Run{
+		StartedAt:  startedAt,
+		EndedAt:    time.Now(),
+		Redactions: redactions,
+		Version:    version,
+		AccountID:  accountID,
+		Regions:    regionsToCheck,
+	}
+	report := buildRunReport(run, tasks, failedCategories, interruption)
+	if reportErr := writeRunReport(l, resourcesDir, report); reportErr != nil {
+		return "", reportErr
+	}
+
+	if len(failedCategories) == 0 {
+		l.Info("Collection completed successfully", "outputDir", resourcesDir)
+		// Every pair is in the outputs; the next run starts over.
+		if clearErr := checkpoints.Clear(); clearErr != nil {
+			l.Warn("Failed to remove checkpoints", LogKeyError, clearErr)
+		}
+	} else {
+		l.Warn("Collection completed with category failures", "outputDir", resourcesDir, "failedCategories", len(failedCategories),
+			"hint", "re-run with --resume to collect only the failed categories and regions")
+	}
+	accountDisplay := accountID
+	if name := strings.TrimSpace(target.name); name != "" {
+		accountDisplay = fmt.Sprintf("%s(%s)", name, accountID)
+	}
+	if html {
+		// Accounts discovered through Organizations already carry their name.
+		if target.name == "" {
+			accountDisplay = lookupAccountDisplay(ctx, l, cfg, accountID)
+		}
+
+		l.Info("Generating HTML index...")
+		if indexErr := writeRowIndex(l, filepath.Dir(resourcesDir), categoryResults); indexErr != nil {
+			return "", fmt.Errorf("failed to generate HTML: %w", indexErr)
+		}
+		if htmlErr := exporter.GenerateHTML(outCtx, outputDir, accountID, accountDisplay, "all.csv", opts.HTMLMode, categories); htmlErr != nil {
+			return "", fmt.Errorf("failed to generate HTML: %w", htmlErr)
+		}
+		l.Info("HTML index generated successfully", "indexPath", filepath.Join(outputDir, accountID, "index.html"))
+	}
+	// If there were per-category failures selected by --fail-on, return an
+	// aggregated error so the caller and CLI can surface partial failure state
+	// while outputs may still contain successful results.
+	if failing := opts.FailOn.Failures(failedCategories); len(failing) > 0 {
+		failureErr := ErrSomeCollectionsFailed
+		if !slices.ContainsFunc(tasks, func(t runreport.Task) bool { return t.Status == runreport.TaskComplete }) {
+			failureErr = ErrAllCollectionsFailed
+		}
+		// details are available in the returned error (CollectionError.Details)
+		return accountDisplay, errors.Join(fmt.Errorf("%w: %w", failureErr, CollectionError{Details: failing}), severityErr)
+	} else if len(failedCategories) > 0 {
+		l.Warn("Collection failures do not fail the run", "failOn", opts.FailOn.Mode, "failedCategories", slices.Sorted(maps.Keys(failedCategories)))
+	}
+
+	return accountDisplay, severityErr
+}
+
+// writeCSVOutputs writes one CSV file per category and all.csv to resourcesDir.
+// Per-category write failures are recorded in failedCategories; failures writing all.csv are returned.
+func writeCSVOutputs(l *logger.SlogLogger, resourcesDir string, categories []string, categoryResults map[string]collectionResult, collectors map[string]resources.Collector, failedCategories map[string][]CollectionFailure) error {
 	// Write all.csv by directly combining results (no intermediate file read needed)
 	allCSVPath := filepath.Join(resourcesDir, "all.csv")
 	allCSVPath = filepath.Clean(allCSVPath)
@@ -552,44 +1837,119 @@ func runCollection(ctx context.Context, l *logger.SlogLogger, opts *CollectionOp
 	if flushErr := cw.Error(); flushErr != nil {
 		return fmt.Errorf("failed to flush all.csv: %w", flushErr)
 	}
+	return nil
+}
 
-	if len(failedCategories) == 0 {
-		l.Info("Collection completed successfully", "outputDir", resourcesDir)
-	} else {
-		l.Warn("Collection completed with category failures", "outputDir", resourcesDir, "failedCategories", len(failedCategories))
+// writeJSONOutputs writes one file per category and all.{format} to resourcesDir
+// using the JSON or NDJSON encoding. Per-category write failures are recorded in failedCategories.
+func writeJSONOutputs(l *logger.SlogLogger, resourcesDir, format string, categories []string, categoryResults map[string]collectionResult, failedCategories map[string][]CollectionFailure) error {
+	write := exporter.WriteJSON
+	if format == exporter.FormatNDJSON {
+		write = exporter.WriteNDJSON
 	}
-	if html {
-		accountDisplay := accountID
-		accountClient := account.NewFromConfig(cfg)
-		accountInfo, accountInfoErr := accountClient.GetAccountInformation(ctx, &account.GetAccountInformationInput{})
-		if accountInfoErr != nil {
-			l.Warn("Failed to resolve account name; fallback to account ID", LogKeyError, accountInfoErr, "accountID", accountID)
-		} else if accountInfo.AccountName != nil {
-			accountName := strings.TrimSpace(*accountInfo.AccountName)
-			if accountName != "" {
-				accountDisplay = fmt.Sprintf("%s(%s)", accountName, accountID)
-			}
-		}
 
-		l.Info("Generating HTML index...")
-		if htmlErr := exporter.GenerateHTML(ctx, outputDir, accountID, accountDisplay, "all.csv", categories); htmlErr != nil {
-			return fmt.Errorf("failed to generate HTML: %w", htmlErr)
+	var all []resources.Resource
+	for _, category := range categories {
+		result := categoryResults[category]
+		if len(result.resources) == 0 {
+			continue
+		}
+		all = append(all, result.resources...)
+		categoryPath := filepath.Join(resourcesDir, category+"."+format)
+		if werr := writeResourcesFile(categoryPath, result.resources, write); werr != nil {
+			l.Error("Failed to write category file", LogKeyError, werr, LogKeyCategory, category, LogKeyFile, categoryPath)
+			failedCategories[category] = append(failedCategories[category], CollectionFailure{
+				Err:    werr,
+				Region: "output",
+			})
 		}
-		l.Info("HTML index generated successfully", "indexPath", filepath.Join(outputDir, accountID, "index.html"))
 	}
-	// If there were per-category failures, return an aggregated error so the
-	// caller and CLI can surface partial failure state while outputs may still
-	// contain successful results.
-	if len(failedCategories) > 0 {
-		// Build a deterministic list of failures
-		var keys []string
-		for k := range failedCategories {
-			keys = append(keys, k)
+
+	allPath := filepath.Join(resourcesDir, "all."+format)
+	l.Info("Writing all results to file", LogKeyFile, allPath)
+	if werr := writeResourcesFile(allPath, all, write); werr != nil {
+		return fmt.Errorf("failed to write all.%s: %w", format, werr)
+	}
+	return nil
+}
+
+// categoryOutputs returns the results of categories, in order, with their collector columns.
+// Categories without resources are skipped unless includeEmpty is set.
+func categoryOutputs(categories []string, categoryResults map[string]collectionResult, collectors map[string]resources.Collector, includeEmpty bool) []exporter.CategoryResources {
+	outputs := make([]exporter.CategoryResources, 0, len(categories))
+	for _, category := range categories {
+		result := categoryResults[category]
+		if len(result.resources) == 0 && !includeEmpty {
+			continue
 		}
-		slices.Sort(keys)
-		// details are available in the returned error (CollectionError.Details)
-		return fmt.Errorf("failed to collect categories: %w", CollectionError{Details: failedCategories})
+		outputs = append(outputs, exporter.CategoryResources{
+			Name:      category,
+			Columns:   collectors[category].GetColumns(),
+			Resources: result.resources,
+		})
 	}
+	return outputs
+}
+
+// writeSQLiteOutput writes all categories, including empty ones, to all.db in resourcesDir.
+func writeSQLiteOutput(ctx context.Context, l *logger.SlogLogger, resourcesDir string, meta exporter.RunMetadata, outputs []exporter.CategoryResources) error {
+	dbPath := filepath.Join(resourcesDir, "all.db")
+	l.Info("Writing all results to database", LogKeyFile, dbPath)
+	if err := exporter.WriteSQLite(ctx, dbPath, meta, outputs); err != nil {
+		return fmt.Errorf("failed to write all.db: %w", err)
+	}
+	return nil
+}
 
+// writeResourcesFile creates path and writes list to it with write.
+func writeResourcesFile[T any](path string, list []T, write func(io.Writer, []T) error) error {
+	return writeOutputFile(path, func(w io.Writer) error { return write(w, list) })
+}
+
+// writeOutputFile creates the file at path and writes it with write.
+func writeOutputFile(path string, write func(io.Writer) error) error {
+	f, err := os.Create(path) //nolint:gosec // G304 - path is controlled and sanitized
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Base(path), err)
+	}
+	writeErr := write(f)
+	closeErr := f.Close()
+	if writeErr != nil {
+		return fmt.Errorf("failed to write %s: %w", filepath.Base(path), writeErr)
+	}
+	if closeErr != nil {
+		return fmt.Errorf("failed to close %s: %w", filepath.Base(path), closeErr)
+	}
 	return nil
 }
+
+// skippedRegionNames returns the sorted, deduplicated regions found in skipped.
+func skippedRegionNames(skipped map[string][]CollectionFailure) []string {
+	var regions []string
+	for _, failures := range skipped {
+		for _, failure := range failures {
+			if !failure.PermissionSkipped && !slices.Contains(regions, failure.Region) {
+				regions = append(regions, failure.Region)
+			}
+		}
+	}
+	slices.Sort(regions)
+	return regions
+}
+
+// lookupAccountDisplay returns "accountName(accountID)" using Account Management
+// GetAccountInformation, falling back to the account ID when the name is unavailable.
+func lookupAccountDisplay(ctx context.Context, l *logger.SlogLogger, cfg awssdk.Config, accountID string) string {
+	accountClient := account.NewFromConfig(cfg)
+	accountInfo, err := accountClient.GetAccountInformation(ctx, &account.GetAccountInformationInput{})
+	if err != nil {
+		l.Warn("Failed to resolve account name; fallback to account ID", LogKeyError, err, "accountID", accountID)
+		return accountID
+	}
+	if accountInfo.AccountName != nil {
+		if accountName := strings.TrimSpace(*accountInfo.AccountName); accountName != "" {
+			return fmt.Sprintf("%s(%s)", accountName, accountID)
+		}
+	}
+	return accountID
+}
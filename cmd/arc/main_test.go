@@ -5,14 +5,27 @@
 	"errors"
 	"fmt"
 	"io"
+	"io/fs"
+	"maps"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
+	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/aws/smithy-go"
+	"github.com/urfave/cli/v3"
+
+	"github.com/y-miyazaki/arc/internal/aws"
 	"github.com/y-miyazaki/arc/internal/aws/resources"
+	"github.com/y-miyazaki/arc/internal/checkpoint"
+	"github.com/y-miyazaki/arc/internal/exporter"
+	"github.com/y-miyazaki/arc/internal/findings"
+	"github.com/y-miyazaki/arc/internal/redact"
+	"github.com/y-miyazaki/arc/internal/runreport"
 	"github.com/y-miyazaki/go-common/pkg/logger"
 )
 
@@ -72,6 +85,7 @@ func TestSelectCollectors(t *testing.T) {
 
 // fakeCollector is a small test helper implementing Collector
 type fakeCollector struct {
+	collectErr  error
 	name        string
 	shouldError bool
 }
@@ -87,6 +101,9 @@ func (f *fakeCollector) GetColumns() []resources.Column {
 }
 func (f *fakeCollector) ShouldSort() bool { return false }
 func (f *fakeCollector) Collect(ctx context.Context, region string) ([]resources.Resource, error) {
+	if f.collectErr != nil {
+		return nil, f.collectErr
+	}
 	if f.shouldError {
 		return nil, fmt.Errorf("collector %s failed", f.name)
 	}
@@ -189,7 +206,7 @@ func TestCollectResources(t *testing.T) {
 			l := logger.NewSlogLogger(&logger.SlogConfig{
 				Output: io.Discard,
 			})
-			results, failed := collectResources(context.Background(), l, tt.collectors, tt.regions, &CollectionOptions{MaxConcurrency: tt.maxConcurrency})
+			results, failed, _, _ := collectResources(context.Background(), l, tt.collectors, tt.regions, &CollectionOptions{MaxConcurrency: tt.maxConcurrency}, nil)
 
 			if len(tt.wantResultKeys) != len(results) && tt.wantFailedKey == "" {
 				t.Fatalf("collectResources(...) results = %v, want keys %v", results, tt.wantResultKeys)
@@ -232,6 +249,59 @@ func TestCollectResources(t *testing.T) {
 	}
 }
 
+func TestCollectResources_SkipsRegionsNotEnabled(t *testing.T) {
+	t.Parallel()
+
+	l := logger.NewSlogLogger(&logger.SlogConfig{
+		Output: io.Discard,
+	})
+	collectors := map[string]resources.Collector{
+		"optin": &fakeCollector{name: "optin", collectErr: fmt.Errorf("wrapped: %w", &smithy.GenericAPIError{Code: "UnrecognizedClientException"})},
+		"ok":    &fakeCollector{name: "ok"},
+	}
+	results, failed, skipped, _ := collectResources(context.Background(), l, collectors, []string{"ap-east-1"}, &CollectionOptions{MaxConcurrency: 2}, nil)
+
+	if _, ok := results["ok"]; !ok {
+		t.Fatalf("collectResources(...) results = %v, want key %q", results, "ok")
+	}
+	if len(failed) != 0 {
+		t.Fatalf("collectResources(...) failed = %v, want empty", failed)
+	}
+	if got := skipped["optin"]; len(got) != 1 || got[0].Region != "ap-east-1" {
+		t.Fatalf("collectResources(...) skipped = %v, want optin in ap-east-1", skipped)
+	}
+	if got := skippedRegionNames(skipped); len(got) != 1 || got[0] != "ap-east-1" {
+		t.Fatalf("skippedRegionNames(...) = %v, want [ap-east-1]", got)
+	}
+}
+
+func TestCollectResources_SkipsPermissionDenied(t *testing.T) {
+	t.Parallel()
+
+	l := logger.NewSlogLogger(&logger.SlogConfig{
+		Output: io.Discard,
+	})
+	collectors := map[string]resources.Collector{
+		"denied": &fakeCollector{name: "denied", collectErr: fmt.Errorf("wrapped: %w", &smithy.GenericAPIError{Code: "UnauthorizedOperation"})},
+		"ok":     &fakeCollector{name: "ok"},
+	}
+	_, failed, skipped, tasks := collectResources(context.Background(), l, collectors, []string{"us-east-1"}, &CollectionOptions{MaxConcurrency: 2}, nil)
+
+	if len(failed) != 0 {
+		t.Fatalf("collectResources(...) failed = %v, want empty", failed)
+	}
+	if got := skipped["denied"]; len(got) != 1 || !got[0].PermissionSkipped {
+		t.Fatalf("collectResources(...) skipped = %v, want denied skipped for permissions", skipped)
+	}
+	if got := skippedRegionNames(skipped); len(got) != 0 {
+		t.Fatalf("skippedRegionNames(...) = %v, want empty: the region is enabled", got)
+	}
+	i := slices.IndexFunc(tasks, func(task runreport.Task) bool { return task.Category == "denied" })
+	if i < 0 || tasks[i].Status != runreport.TaskPermissionSkipped {
+		t.Fatalf("collectResources(...) tasks = %v, want denied %s", tasks, runreport.TaskPermissionSkipped)
+	}
+}
+
 func TestCollectResources_RespectsContextCancelWhileWaitingForSemaphore(t *testing.T) {
 	collector := &blockingCollector{name: "blocking"}
 	l := logger.NewSlogLogger(&logger.SlogConfig{
@@ -243,7 +313,7 @@ func TestCollectResources_RespectsContextCancelWhileWaitingForSemaphore(t *testi
 
 	done := make(chan struct{})
 	go func() {
-		_, _ = collectResources(ctx, l, map[string]resources.Collector{"blocking": collector}, []string{"r1", "r2"}, &CollectionOptions{MaxConcurrency: 1})
+		_, _, _, _ = collectResources(ctx, l, map[string]resources.Collector{"blocking": collector}, []string{"r1", "r2"}, &CollectionOptions{MaxConcurrency: 1}, nil)
 		close(done)
 	}()
 
@@ -270,6 +340,361 @@ func TestCollectResources_RespectsContextCancelWhileWaitingForSemaphore(t *testi
 	}
 }
 
+func TestCollectResources_ResumesFromCheckpoints(t *testing.T) {
+	t.Parallel()
+
+	l := logger.NewSlogLogger(&logger.SlogConfig{
+		Output: io.Discard,
+	})
+	checkpoints := checkpoint.NewStore(filepath.Join(t.TempDir(), checkpoint.DirName), version)
+
+	// First run: r1 completes and is checkpointed, r2 fails.
+	first := map[string]resources.Collector{"test": &regionFailCollector{name: "test", failRegion: "r2"}}
+	_, failed, _, _ := collectResources(context.Background(), l, first, []string{"r1", "r2"}, &CollectionOptions{MaxConcurrency: 2}, checkpoints)
+	if got := failed["test"]; len(got) != 1 || got[0].Region != "r2" {
+		t.Fatalf("collectResources(...) failed = %v, want test in r2", failed)
+	}
+	if _, ok, err := checkpoints.Load("test", "r2"); err != nil || ok {
+		t.Fatalf("Load(test, r2) = %v, %v, want no checkpoint for the failed pair", ok, err)
+	}
+
+	// Resumed run: only r2 is collected again.
+	second := &regionFailCollector{name: "test"}
+	results, failed, _, _ := collectResources(context.Background(), l, map[string]resources.Collector{"test": second}, []string{"r1", "r2"}, &CollectionOptions{MaxConcurrency: 2, Resume: true}, checkpoints)
+	if len(failed) != 0 {
+		t.Fatalf("collectResources(...) failed = %v, want empty", failed)
+	}
+	if got := second.regions(); !slices.Equal(got, []string{"r2"}) {
+		t.Fatalf("collected regions = %v, want [r2]", got)
+	}
+	var regions []string
+	for _, r := range results["test"].resources {
+		regions = append(regions, r.Region)
+	}
+	slices.Sort(regions)
+	if !slices.Equal(regions, []string{"r1", "r2"}) {
+		t.Fatalf("collectResources(...) resource regions = %v, want [r1 r2]", regions)
+	}
+
+	// Without --resume the checkpoints are ignored.
+	third := &regionFailCollector{name: "test"}
+	_, _, _, _ = collectResources(context.Background(), l, map[string]resources.Collector{"test": third}, []string{"r1", "r2"}, &CollectionOptions{MaxConcurrency: 2}, checkpoints)
+	if got := third.regions(); len(got) != 2 {
+		t.Fatalf("collected regions = %v, want both regions", got)
+	}
+}
+
+func TestCollectResources_RedactsCheckpoints(t *testing.T) {
+	t.Parallel()
+
+	l := logger.NewSlogLogger(&logger.SlogConfig{
+		Output: io.Discard,
+	})
+	redactor, err := redact.New(&redact.Options{Mode: redact.ModeMask, KeyPatterns: redact.DefaultKeyPatterns})
+	if err != nil {
+		t.Fatalf("redact.New() error = %v", err)
+	}
+	checkpoints := checkpoint.NewStore(filepath.Join(t.TempDir(), checkpoint.DirName), version)
+	collectors := map[string]resources.Collector{"lambda": &envCollector{env: "API_TOKEN=abc\nNAME=app"}}
+
+	results, _, _, _ := collectResources(context.Background(), l, collectors, []string{"r1"}, &CollectionOptions{MaxConcurrency: 1, Redactor: redactor}, checkpoints)
+
+	cp, ok, err := checkpoints.Load("lambda", "r1")
+	if err != nil || !ok {
+		t.Fatalf("Load(lambda, r1) = %v, %v, want a checkpoint", ok, err)
+	}
+	if got := cp.Resources[0].RawData["EnvVars"]; got != "API_TOKEN=*****\nNAME=app" {
+		t.Fatalf("checkpoint EnvVars = %q, want the token masked", got)
+	}
+	// The collected resources are left unchanged.
+	if got := results["lambda"].resources[0].RawData["EnvVars"]; got != "API_TOKEN=abc\nNAME=app" {
+		t.Fatalf("collectResources(...) EnvVars = %q, want the collected value", got)
+	}
+}
+
+func TestCollectResources_ResumesCheckpointedFindings(t *testing.T) {
+	t.Parallel()
+
+	l := logger.NewSlogLogger(&logger.SlogConfig{
+		Output: io.Discard,
+	})
+	redactor, err := redact.New(&redact.Options{Mode: redact.ModeDrop, KeyPatterns: redact.DefaultKeyPatterns})
+	if err != nil {
+		t.Fatalf("redact.New() error = %v", err)
+	}
+	checkpoints := checkpoint.NewStore(filepath.Join(t.TempDir(), checkpoint.DirName), version)
+	// The rule needs the collected value, which the drop mode removes from the checkpoints.
+	tokenRule := findings.Rule{
+		ID:       "lambda-plain-token",
+		Category: "lambda",
+		Severity: findings.SeverityHigh,
+		Check: func(r *resources.Resource) string {
+			if env, _ := r.RawData["EnvVars"].(string); strings.Contains(env, "API_TOKEN=abc") {
+				return "API_TOKEN=abc"
+			}
+			return ""
+		},
+	}
+	nameRule := findings.Rule{
+		ID:       "lambda-named-api",
+		Category: "lambda",
+		Severity: findings.SeverityLow,
+		Check: func(r *resources.Resource) string {
+			if r.Name == "api" {
+				return "Name=api"
+			}
+			return ""
+		},
+	}
+	env := "API_TOKEN=abc\nNAME=app"
+
+	// First run: r1 is checkpointed with its findings, r2 fails.
+	first := map[string]resources.Collector{"lambda": &envCollector{env: env, failRegion: "r2"}}
+	firstOpts := &CollectionOptions{MaxConcurrency: 2, Redactor: redactor, Rules: []findings.Rule{tokenRule}}
+	_, _, _, _ = collectResources(context.Background(), l, first, []string{"r1", "r2"}, firstOpts, checkpoints)
+
+	// Resumed run, with a rule added since the checkpoint.
+	second := map[string]resources.Collector{"lambda": &envCollector{env: env}}
+	rules := []findings.Rule{tokenRule, nameRule}
+	secondOpts := &CollectionOptions{MaxConcurrency: 2, Redactor: redactor, Rules: rules, Resume: true}
+	results, failed, _, _ := collectResources(context.Background(), l, second, []string{"r1", "r2"}, secondOpts, checkpoints)
+	if len(failed) != 0 {
+		t.Fatalf("collectResources(...) failed = %v, want empty", failed)
+	}
+
+	list, err := writeFindings(l, t.TempDir(), []string{"lambda"}, results, rules, redactor)
+	if err != nil {
+		t.Fatalf("writeFindings(...) error = %v", err)
+	}
+	var got []string
+	for _, f := range list {
+		got = append(got, f.RuleID+"/"+f.Region+"/"+f.Evidence)
+	}
+	slices.Sort(got)
+	// The token finding of r1 comes from its checkpoint: the redacted
+	// resource no longer holds the token.
+	want := []string{
+		"lambda-named-api/r1/Name=api",
+		"lambda-named-api/r2/Name=api",
+		"lambda-plain-token/r1/",
+		"lambda-plain-token/r2/",
+	}
+	if !slices.Equal(got, want) {
+		t.Fatalf("writeFindings(...) = %v, want %v", got, want)
+	}
+}
+
+// envCollector returns one resource with env as its EnvVars, and fails in failRegion.
+type envCollector struct {
+	env        string
+	failRegion string
+}
+
+func (*envCollector) Name() string { return "lambda" }
+func (*envCollector) GetColumns() []resources.Column {
+	return []resources.Column{{Header: "EnvVars", Value: func(r resources.Resource) string { return fmt.Sprint(r.RawData["EnvVars"]) }}}
+}
+func (*envCollector) ShouldSort() bool { return false }
+func (c *envCollector) Collect(_ context.Context, region string) ([]resources.Resource, error) {
+	if region == c.failRegion {
+		return nil, fmt.Errorf("collector lambda failed in %s", region)
+	}
+	return []resources.Resource{{Category: "lambda", Name: "api", Region: region, RawData: map[string]any{"EnvVars": c.env}}}, nil
+}
+
+// regionFailCollector fails in failRegion and records the regions it collected.
+type regionFailCollector struct {
+	mu         sync.Mutex
+	collected  []string
+	failRegion string
+	name       string
+}
+
+func (c *regionFailCollector) Name() string { return c.name }
+func (c *regionFailCollector) GetColumns() []resources.Column {
+	return []resources.Column{{Header: "h", Value: func(r resources.Resource) string { return r.Name }}}
+}
+func (c *regionFailCollector) ShouldSort() bool { return false }
+func (c *regionFailCollector) Collect(_ context.Context, region string) ([]resources.Resource, error) {
+	c.mu.Lock()
+	c.collected = append(c.collected, region)
+	c.mu.Unlock()
+	if region == c.failRegion {
+		return nil, fmt.Errorf("collector %s failed in %s", c.name, region)
+	}
+	return []resources.Resource{{Category: c.name, Name: c.name + "-r", Region: region}}, nil
+}
+
+// regions returns the sorted regions collected so far.
+func (c *regionFailCollector) regions() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return slices.Sorted(slices.Values(c.collected))
+}
+
+func TestCollectResources_GracePeriod(t *testing.T) {
+	t.Parallel()
+
+	l := logger.NewSlogLogger(&logger.SlogConfig{
+		Output: io.Discard,
+	})
+	collector := &releaseCollector{name: "slow", started: make(chan struct{}, 2), release: make(chan struct{})}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	type output struct {
+		results map[string]collectionResult
+		failed  map[string][]CollectionFailure
+		tasks   []runreport.Task
+	}
+	done := make(chan output)
+	go func() {
+		results, failed, _, tasks := collectResources(ctx, l, map[string]resources.Collector{"slow": collector}, []string{"r1", "r2"}, &CollectionOptions{MaxConcurrency: 1, GracePeriod: time.Minute}, nil)
+		done <- output{results: results, failed: failed, tasks: tasks}
+	}()
+
+	<-collector.started
+	cancel()
+	// The collection in flight finishes within the grace period; the other region is never started.
+	time.Sleep(20 * time.Millisecond)
+	close(collector.release)
+
+	var out output
+	select {
+	case out = <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("collectResources did not return")
+	}
+	if got := len(out.results["slow"].resources); got != 1 {
+		t.Fatalf("collectResources(...) resources = %d, want the resource collected during the grace period", got)
+	}
+	failures := out.failed["slow"]
+	if len(failures) != 1 || !errors.Is(failures[0].Err, context.Canceled) {
+		t.Fatalf("collectResources(...) failed = %v, want one canceled region", out.failed)
+	}
+	if got := len(collector.started); got != 0 {
+		t.Fatalf("collections started after cancel = %d, want 0", got)
+	}
+	statuses := make([]string, 0, len(out.tasks))
+	for _, task := range out.tasks {
+		statuses = append(statuses, task.Status)
+		if task.Status == runreport.TaskComplete && (task.Resources != 1 || task.DurationMs < 20) {
+			t.Fatalf("collectResources(...) complete task = %+v, want 1 resource and the collection duration", task)
+		}
+		if task.Status == runreport.TaskIncomplete && task.Error != context.Canceled.Error() {
+			t.Fatalf("collectResources(...) incomplete task error = %q, want %q", task.Error, context.Canceled.Error())
+		}
+	}
+	slices.Sort(statuses)
+	if want := []string{runreport.TaskComplete, runreport.TaskIncomplete}; !slices.Equal(statuses, want) {
+		t.Fatalf("collectResources(...) task statuses = %v, want %v", statuses, want)
+	}
+}
+
+func TestWithGracePeriod(t *testing.T) {
+	t.Parallel()
+
+	parent, cancelParent := context.WithCancel(context.Background())
+	workCtx, stop := withGracePeriod(parent, 50*time.Millisecond)
+	defer stop()
+
+	cancelParent()
+	select {
+	case <-workCtx.Done():
+		t.Fatal("work context canceled before the grace period")
+	case <-time.After(10 * time.Millisecond):
+	}
+	select {
+	case <-workCtx.Done():
+	case <-time.After(2 * time.Second):
+		t.Fatal("work context not canceled after the grace period")
+	}
+
+	workCtx, stop = withGracePeriod(context.Background(), time.Minute)
+	stop()
+	if workCtx.Err() == nil {
+		t.Fatal("work context not canceled by stop")
+	}
+}
+
+func TestBuildRunReport(t *testing.T) {
+	t.Parallel()
+
+	startedAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	run := runreport.Run{
+		StartedAt: startedAt,
+		EndedAt:   startedAt.Add(90 * time.Second),
+		Version:   "v1.2.3",
+		AccountID: "123456789012",
+		Regions:   []string{"ap-east-1", "us-east-1"},
+	}
+	tasks := []runreport.Task{
+		{Category: "sqs", Region: "us-east-1", Status: runreport.TaskComplete, DurationMs: 1200, Resources: 3, APICalls: 4},
+		{Category: "sqs", Region: "ap-east-1", Status: runreport.TaskSkipped, Error: "not enabled"},
+		{Category: "ec2", Region: "us-east-1", Status: runreport.TaskFailed, Error: "access denied", DurationMs: 300, APICalls: 2},
+	}
+	failed := map[string][]CollectionFailure{
+		"ec2": {{Region: "us-east-1", Err: errors.New("access denied")}},
+		"sqs": {{Region: "output", Err: errors.New("disk full")}},
+	}
+
+	tests := []struct {
+		name         string
+		interruption error
+		wantStatus   string
+	}{
+		{name: "failed tasks make a partial run", wantStatus: runreport.RunPartial},
+		{name: "interrupted run", interruption: context.Canceled, wantStatus: runreport.RunInterrupted},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			report := buildRunReport(run, tasks, failed, tt.interruption)
+			if report.Status != tt.wantStatus {
+				t.Fatalf("buildRunReport(...).Status = %q, want %q", report.Status, tt.wantStatus)
+			}
+			want := []runreport.Task{
+				{Category: "ec2", Region: "us-east-1", Status: runreport.TaskFailed, Error: "access denied", DurationMs: 300, APICalls: 2},
+				{Category: "sqs", Region: "ap-east-1", Status: runreport.TaskSkipped, Error: "not enabled"},
+				{Category: "sqs", Region: "output", Status: runreport.TaskFailed, Error: "disk full"},
+				{Category: "sqs", Region: "us-east-1", Status: runreport.TaskComplete, DurationMs: 1200, Resources: 3, APICalls: 4},
+			}
+			if !slices.Equal(report.Tasks, want) {
+				t.Fatalf("buildRunReport(...).Tasks = %v, want %v", report.Tasks, want)
+			}
+			if report.AccountID != run.AccountID || report.Version != run.Version || report.DurationMs != 90000 {
+				t.Fatalf("buildRunReport(...) = %+v, want the account, version and duration of the run", report)
+			}
+			if report.Resources != 3 || report.APICalls != 6 {
+				t.Fatalf("buildRunReport(...) totals = %d resources, %d API calls, want 3, 6", report.Resources, report.APICalls)
+			}
+		})
+	}
+}
+
+// releaseCollector signals started when a collection starts and returns once
+// release is closed, even when its context is canceled.
+type releaseCollector struct {
+	started chan struct{}
+	release chan struct{}
+	name    string
+}
+
+func (c *releaseCollector) Name() string { return c.name }
+func (c *releaseCollector) GetColumns() []resources.Column {
+	return []resources.Column{{Header: "h", Value: func(r resources.Resource) string { return r.Name }}}
+}
+func (c *releaseCollector) ShouldSort() bool { return false }
+func (c *releaseCollector) Collect(ctx context.Context, region string) ([]resources.Resource, error) {
+	c.started <- struct{}{}
+	select {
+	case <-c.release:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	return []resources.Resource{{Category: c.name, Name: c.name + "-r", Region: region}}, nil
+}
+
 func TestCreateRunContext(t *testing.T) {
 	t.Parallel()
 
@@ -380,9 +805,10 @@ func TestCollectionError_Error(t *testing.T) {
 
 func TestInitializeRegions(t *testing.T) {
 	tests := []struct {
-		name        string
-		userRegions []string
-		expected    []string
+		name         string
+		userRegions  []string
+		globalRegion string
+		expected     []string
 	}{
 		{
 			name:        "single region same as global",
@@ -414,11 +840,21 @@ func TestInitializeRegions(t *testing.T) {
 			userRegions: []string{"eu-west-1"},
 			expected:    []string{"eu-west-1", GlobalServiceRegion},
 		},
+		{
+			name:         "govcloud global region",
+			userRegions:  []string{"us-gov-east-1"},
+			globalRegion: "us-gov-west-1",
+			expected:     []string{"us-gov-east-1", "us-gov-west-1"},
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := initializeRegions(tt.userRegions)
+			globalRegion := tt.globalRegion
+			if globalRegion == "" {
+				globalRegion = GlobalServiceRegion
+			}
+			result := initializeRegions(tt.userRegions, globalRegion)
 			if len(result) != len(tt.expected) {
 				t.Errorf("initializeRegions() length = %v, want %v", len(result), len(tt.expected))
 				return
@@ -584,3 +1020,559 @@ func TestWriteCategoryCSVFile(t *testing.T) {
 		}
 	})
 }
+
+func TestResolveAccountTargets(t *testing.T) {
+	t.Parallel()
+
+	const identity = "arn:aws:sts::111111111111:assumed-role/Admin/session"
+
+	tests := []struct {
+		name     string
+		identity string
+		opts     *CollectionOptions
+		want     []accountTarget
+		wantErr  error
+	}{
+		{
+			name:     "defaults to caller account",
+			identity: identity,
+			opts:     &CollectionOptions{},
+			want:     []accountTarget{{accountID: "111111111111"}},
+		},
+		{
+			name:     "single assume role arn",
+			identity: identity,
+			opts:     &CollectionOptions{AssumeRoleARN: "arn:aws:iam::222222222222:role/ReadOnly"},
+			want:     []accountTarget{{accountID: "222222222222", roleARN: "arn:aws:iam::222222222222:role/ReadOnly"}},
+		},
+		{
+			name:     "accounts with role name",
+			identity: "arn:aws-us-gov:sts::111111111111:assumed-role/Admin/session",
+			opts:     &CollectionOptions{Accounts: "222222222222, 333333333333", AssumeRoleName: "ReadOnly"},
+			want: []accountTarget{
+				{accountID: "222222222222", roleARN: "arn:aws-us-gov:iam::222222222222:role/ReadOnly"},
+				{accountID: "333333333333", roleARN: "arn:aws-us-gov:iam::333333333333:role/ReadOnly"},
+			},
+		},
+		{
+			name:     "accounts without role name",
+			identity: identity,
+			opts:     &CollectionOptions{Accounts: "222222222222"},
+			wantErr:  ErrMissingRoleName,
+		},
+		{
+			name:     "accounts with role arn conflict",
+			identity: identity,
+			opts:     &CollectionOptions{Accounts: "222222222222", AssumeRoleARN: "arn:aws:iam::222222222222:role/ReadOnly"},
+			wantErr:  ErrConflictingRoleOptions,
+		},
+		{
+			name:     "invalid account id",
+			identity: identity,
+			opts:     &CollectionOptions{Accounts: "../evil", AssumeRoleName: "ReadOnly"},
+			wantErr:  ErrInvalidAccountID,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := resolveAccountTargets(tt.identity, tt.opts)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("resolveAccountTargets(...) error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("resolveAccountTargets(...) unexpected error = %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("resolveAccountTargets(...) = %v, want %v", got, tt.want)
+			}
+			for i := range tt.want {
+				if got[i] != tt.want[i] {
+					t.Fatalf("resolveAccountTargets(...)[%d] = %v, want %v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestIsMultiAccount(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		opts CollectionOptions
+		want bool
+	}{
+		{name: "own account", opts: CollectionOptions{}, want: false},
+		{name: "organization", opts: CollectionOptions{Organization: true}, want: true},
+		{name: "one account", opts: CollectionOptions{Accounts: "111111111111", AssumeRoleName: "ArcReadOnly"}, want: true},
+		{name: "several accounts", opts: CollectionOptions{Accounts: "111111111111,222222222222", AssumeRoleName: "ArcReadOnly"}, want: true},
+		{name: "blank accounts", opts: CollectionOptions{Accounts: " , "}, want: false},
+		{name: "role ARN", opts: CollectionOptions{AssumeRoleARN: "arn:aws:iam::111111111111:role/ArcReadOnly"}, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := isMultiAccount(&tt.opts); got != tt.want {
+				t.Fatalf("isMultiAccount(%+v) = %v, want %v", tt.opts, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsAccountID(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		input string
+		want  bool
+	}{
+		{name: "twelve digits", input: "123456789012", want: true},
+		{name: "too short", input: "12345", want: false},
+		{name: "non digit", input: "12345678901a", want: false},
+		{name: "empty", input: "", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := isAccountID(tt.input); got != tt.want {
+				t.Fatalf("isAccountID(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOrganizationTargets(t *testing.T) {
+	t.Parallel()
+
+	const identity = "arn:aws:sts::111111111111:assumed-role/Admin/session"
+
+	accounts := []aws.OrganizationAccount{
+		{ID: "111111111111", Name: "management", OUPath: "/Root"},
+		{ID: "222222222222", Name: "prod", OUPath: "/Root/Workloads"},
+	}
+	got, err := organizationTargets(identity, accounts, "ReadOnly")
+	if err != nil {
+		t.Fatalf("organizationTargets(...) unexpected error = %v", err)
+	}
+	want := []accountTarget{
+		{accountID: "111111111111", name: "management", ouPath: "/Root"},
+		{accountID: "222222222222", name: "prod", ouPath: "/Root/Workloads", roleARN: "arn:aws:iam::222222222222:role/ReadOnly"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("organizationTargets(...) = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("organizationTargets(...)[%d] = %v, want %v", i, got[i], want[i])
+		}
+	}
+
+	if _, err := organizationTargets(identity, nil, "ReadOnly"); !errors.Is(err, ErrNoTargetAccounts) {
+		t.Fatalf("organizationTargets(nil) error = %v, want %v", err, ErrNoTargetAccounts)
+	}
+}
+
+func TestParseFormats(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		input   string
+		want    []string
+		wantErr bool
+	}{
+		{name: "empty defaults to csv", input: "", want: []string{"csv"}},
+		{name: "single", input: "json", want: []string{"json"}},
+		{name: "multiple with spaces and case", input: "CSV, ndjson ,json", want: []string{"csv", "ndjson", "json"}},
+		{name: "duplicates removed", input: "json,json", want: []string{"json"}},
+		{name: "sqlite", input: "csv,sqlite", want: []string{"csv", "sqlite"}},
+		{name: "xlsx", input: "xlsx", want: []string{"xlsx"}},
+		{name: "unknown", input: "csv,xml", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := parseFormats(tt.input)
+			if tt.wantErr {
+				if !errors.Is(err, ErrUnknownFormat) {
+					t.Fatalf("parseFormats(%q) error = %v, want %v", tt.input, err, ErrUnknownFormat)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseFormats(%q) unexpected error = %v", tt.input, err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Fatalf("parseFormats(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseGraphFormats(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		input   string
+		want    []string
+		wantErr bool
+	}{
+		{name: "empty disables the graph", input: ""},
+		{name: "multiple with spaces and case", input: "DOT, mermaid,json", want: []string{"dot", "mermaid", "json"}},
+		{name: "unknown", input: "dot,svg", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := parseGraphFormats(tt.input)
+			if tt.wantErr {
+				if !errors.Is(err, ErrUnknownFormat) {
+					t.Fatalf("parseGraphFormats(%q) error = %v, want %v", tt.input, err, ErrUnknownFormat)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseGraphFormats(%q) unexpected error = %v", tt.input, err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Fatalf("parseGraphFormats(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseHTMLMode(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{name: "server", input: "server", want: exporter.HTMLModeServer},
+		{name: "standalone with spaces and case", input: " Standalone ", want: exporter.HTMLModeStandalone},
+		{name: "empty", input: "", wantErr: true},
+		{name: "unknown", input: "inline", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := parseHTMLMode(tt.input)
+			if tt.wantErr {
+				if !errors.Is(err, exporter.ErrUnknownHTMLMode) {
+					t.Fatalf("parseHTMLMode(%q) error = %v, want %v", tt.input, err, exporter.ErrUnknownHTMLMode)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseHTMLMode(%q) unexpected error = %v", tt.input, err)
+			}
+			if got != tt.want {
+				t.Fatalf("parseHTMLMode(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseSecretValues(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		mode    string
+		salt    string
+		want    resources.SecretValueOptions
+		wantErr error
+	}{
+		{name: "empty is none", mode: "", want: resources.SecretValueOptions{Mode: resources.SecretValuesNone}},
+		{name: "none", mode: "none", want: resources.SecretValueOptions{Mode: resources.SecretValuesNone}},
+		{name: "redacted with salt", mode: " Redacted ", salt: "s3cr3t-salt", want: resources.SecretValueOptions{Mode: resources.SecretValuesRedacted, Salt: "s3cr3t-salt"}},
+		{name: "redacted without salt", mode: "redacted", wantErr: ErrSecretHashSaltRequired},
+		{name: "plaintext without salt", mode: "plaintext", want: resources.SecretValueOptions{Mode: resources.SecretValuesPlaintext}},
+		{name: "unknown", mode: "all", wantErr: resources.ErrUnknownSecretValueMode},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := parseSecretValues(tt.mode, tt.salt)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("parseSecretValues(%q, ...) error = %v, want %v", tt.mode, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseSecretValues(%q, ...) unexpected error = %v", tt.mode, err)
+			}
+			if got != tt.want {
+				t.Fatalf("parseSecretValues(%q, ...) = %+v, want %+v", tt.mode, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseTagFilter(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		input   string
+		want    map[string]string
+		wantErr bool
+	}{
+		{name: "empty", input: "", want: map[string]string{}},
+		{name: "pairs", input: "env=prod, team = core", want: map[string]string{"env": "prod", "team": "core"}},
+		{name: "empty value", input: "env=", want: map[string]string{"env": ""}},
+		{name: "missing separator", input: "env", wantErr: true},
+		{name: "missing key", input: "=prod", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := parseTagFilter(tt.input)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidTagFilter) {
+					t.Fatalf("parseTagFilter(%q) error = %v, want %v", tt.input, err, ErrInvalidTagFilter)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseTagFilter(%q) unexpected error = %v", tt.input, err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("parseTagFilter(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+			for k, v := range tt.want {
+				if got[k] != v {
+					t.Fatalf("parseTagFilter(%q)[%q] = %q, want %q", tt.input, k, got[k], v)
+				}
+			}
+		})
+	}
+}
+
+func TestExcludeRegions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		regions  []string
+		excluded []string
+		want     []string
+	}{
+		{name: "no exclusions", regions: []string{"us-east-1", "eu-west-1"}, want: []string{"us-east-1", "eu-west-1"}},
+		{name: "removes excluded", regions: []string{"us-east-1", "ap-east-1", "eu-west-1"}, excluded: []string{"ap-east-1"}, want: []string{"us-east-1", "eu-west-1"}},
+		{name: "unknown exclusion ignored", regions: []string{"us-east-1"}, excluded: []string{"me-south-1"}, want: []string{"us-east-1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := excludeRegions(tt.regions, tt.excluded)
+			if len(got) != len(tt.want) {
+				t.Fatalf("excludeRegions(...) = %v, want %v", got, tt.want)
+			}
+			for i := range tt.want {
+				if got[i] != tt.want[i] {
+					t.Fatalf("excludeRegions(...)[%d] = %q, want %q", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestValidateRegionsPartition(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		regions   []string
+		partition string
+		wantErr   bool
+	}{
+		{name: "commercial regions", regions: []string{"ap-northeast-1", "us-east-1"}, partition: "aws"},
+		{name: "china regions", regions: []string{"cn-north-1", "cn-northwest-1"}, partition: "aws-cn"},
+		{name: "govcloud regions", regions: []string{"us-gov-west-1"}, partition: "aws-us-gov"},
+		{name: "commercial region with govcloud caller", regions: []string{"us-east-1"}, partition: "aws-us-gov", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := validateRegionsPartition(tt.regions, tt.partition)
+			if tt.wantErr != errors.Is(err, ErrRegionPartition) {
+				t.Fatalf("validateRegionsPartition(%v, %q) error = %v, wantErr %v", tt.regions, tt.partition, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRedactResources(t *testing.T) {
+	t.Parallel()
+
+	l := logger.NewSlogLogger(&logger.SlogConfig{
+		Output: io.Discard,
+	})
+	redactor, err := redact.New(&redact.Options{Mode: redact.ModeMask, KeyPatterns: redact.DefaultKeyPatterns, Detectors: redact.Detectors})
+	if err != nil {
+		t.Fatalf("redact.New() error = %v", err)
+	}
+	categoryResults := map[string]collectionResult{
+		"lambda": {category: "lambda", resources: []resources.Resource{
+			{Name: "api", RawData: map[string]any{"EnvVars": "API_TOKEN=abc\nNAME=app"}},
+		}},
+		"sqs": {category: "sqs", resources: []resources.Resource{
+			{Name: "queue", RawData: map[string]any{"Policy": "N/A"}},
+		}},
+	}
+
+	counts := redactResources(l, redactor, categoryResults)
+
+	if want := map[string]int{"lambda": 1}; !maps.Equal(counts, want) {
+		t.Fatalf("redactResources(...) = %v, want %v", counts, want)
+	}
+	if got := categoryResults["lambda"].resources[0].RawData["EnvVars"]; got != "API_TOKEN=*****\nNAME=app" {
+		t.Fatalf("redactResources(...) EnvVars = %q, want the token masked", got)
+	}
+}
+
+func TestWriteFindings_RedactsEvidence(t *testing.T) {
+	t.Parallel()
+
+	l := logger.NewSlogLogger(&logger.SlogConfig{
+		Output: io.Discard,
+	})
+	redactor, err := redact.New(&redact.Options{Mode: redact.ModeMask, KeyPatterns: redact.DefaultKeyPatterns})
+	if err != nil {
+		t.Fatalf("redact.New() error = %v", err)
+	}
+	// The rule needs the collected value, which is not redacted yet.
+	rules := []findings.Rule{{
+		ID:       "lambda-plain-token",
+		Category: "lambda",
+		Severity: findings.SeverityHigh,
+		Title:    "Token in plain text",
+		Check: func(r *resources.Resource) string {
+			if env, _ := r.RawData["EnvVars"].(string); strings.Contains(env, "API_TOKEN=abc") {
+				return env
+			}
+			return ""
+		},
+	}}
+	categoryResults := map[string]collectionResult{
+		"lambda": {category: "lambda", resources: []resources.Resource{
+			{Name: "api", RawData: map[string]any{"EnvVars": "API_TOKEN=abc\nNAME=app"}},
+		}},
+	}
+
+	list, err := writeFindings(l, t.TempDir(), []string{"lambda"}, categoryResults, rules, redactor)
+	if err != nil {
+		t.Fatalf("writeFindings(...) error = %v", err)
+	}
+	if len(list) != 1 {
+		t.Fatalf("writeFindings(...) = %v, want 1 finding", list)
+	}
+	if got := list[0].Evidence; got != "API_TOKEN=*****\nNAME=app" {
+		t.Fatalf("writeFindings(...) Evidence = %q, want the token masked", got)
+	}
+}
+
+func TestRemoveFindings(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		files []string
+	}{
+		{name: "removes previous findings", files: []string{findings.CSVFile, findings.JSONFile}},
+		{name: "no previous findings"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			dir := t.TempDir()
+			for _, name := range tt.files {
+				if err := os.WriteFile(filepath.Join(dir, name), []byte("stale"), 0o600); err != nil {
+					t.Fatalf("WriteFile() error = %v", err)
+				}
+			}
+			if err := removeFindings(dir); err != nil {
+				t.Fatalf("removeFindings() error = %v", err)
+			}
+			for _, name := range []string{findings.CSVFile, findings.JSONFile} {
+				if _, err := os.Stat(filepath.Join(dir, name)); !errors.Is(err, fs.ErrNotExist) {
+					t.Fatalf("Stat(%s) error = %v, want not exist", name, err)
+				}
+			}
+		})
+	}
+}
+
+func TestLoadFindingRules(t *testing.T) {
+	t.Parallel()
+
+	rulesPath := filepath.Join(t.TempDir(), "rules.yaml")
+	content := "rules:\n  - id: ec2-untagged\n    title: Untagged instance\n    severity: low\n    category: ec2\n    condition: size(tags) == 0\n"
+	if err := os.WriteFile(rulesPath, []byte(content), 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		args      []string
+		wantRules int
+		wantErr   error
+	}{
+		{name: "no rules"},
+		{name: "built-in rules", args: []string{"--findings"}, wantRules: len(findings.Builtin())},
+		{name: "rule file", args: []string{"--rules", rulesPath}, wantRules: 1},
+		{name: "built-in rules and rule file", args: []string{"--findings", "--rules", rulesPath, "--fail-severity", "high"}, wantRules: len(findings.Builtin()) + 1},
+		{name: "unknown fail severity", args: []string{"--findings", "--fail-severity", "urgent"}, wantErr: findings.ErrUnknownSeverity},
+		{name: "fail severity without rules", args: []string{"--fail-severity", "high"}, wantErr: ErrFailSeverityNoRules},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var got []findings.Rule
+			cmd := &cli.Command{
+				Name:  "arc",
+				Flags: newRootFlags(),
+				Action: func(_ context.Context, cmd *cli.Command) error {
+					var err error
+					got, err = loadFindingRules(cmd)
+					return err
+				},
+			}
+			err := cmd.Run(context.Background(), append([]string{"arc"}, tt.args...))
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("loadFindingRules() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("loadFindingRules() unexpected error = %v", err)
+			}
+			if len(got) != tt.wantRules {
+				t.Fatalf("loadFindingRules() returned %d rules, want %d", len(got), tt.wantRules)
+			}
+		})
+	}
+}
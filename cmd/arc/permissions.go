@@ -0,0 +1,146 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/y-miyazaki/arc/internal/aws/resources"
+)
+
+// iamPolicyVersion is the version of the IAM policy language.
+const iamPolicyVersion = "2012-10-17"
+
+// IAM actions called by arc itself, outside of the collectors.
+var (
+	// runActions are called by every run: the credentials check and the tag loader.
+	runActions = []string{"sts:GetCallerIdentity", "tag:GetResources"}
+	// allRegionsActions discover the regions enabled for the account (--all-regions).
+	allRegionsActions = []string{"ec2:DescribeRegions"}
+	// htmlActions resolve the account name shown by the HTML viewer (--html).
+	htmlActions = []string{"account:GetAccountInformation"}
+	// orgActions discover the accounts of the organization (--org).
+	orgActions = []string{
+		"organizations:ListAccountsForParent",
+		"organizations:ListOrganizationalUnitsForParent",
+		"organizations:ListRoots",
+	}
+	// orgTagsActions filter the accounts of the organization by tag (--org-tags).
+	orgTagsActions = []string{"organizations:ListTagsForResource"}
+)
+
+// IAMPolicy is an IAM identity-based policy document.
+//
+//nolint:tagliatelle // IAM policy grammar
+type IAMPolicy struct {
+	Version   string               `json:"Version"`
+	Statement []IAMPolicyStatement `json:"Statement"`
+}
+
+// IAMPolicyStatement is a statement of an IAMPolicy.
+//
+//nolint:tagliatelle // IAM policy grammar
+type IAMPolicyStatement struct {
+	Sid      string   `json:"Sid"`
+	Effect   string   `json:"Effect"`
+	Action   []string `json:"Action"`
+	Resource string   `json:"Resource"`
+}
+
+// permissionsOptions selects the IAM actions of a run.
+type permissionsOptions struct {
+	Categories   string
+	OrgTags      string
+	SecretValues string
+	AllRegions   bool
+	HTML         bool
+	Org          bool
+}
+
+// newPermissionsCommand returns the "arc permissions" subcommand printing the
+// least-privilege IAM policy of a collection.
+func newPermissionsCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "permissions",
+		Usage: "Print the least-privilege IAM policy of a collection with the given options",
+		Description: "Takes the collection options that change the AWS APIs called: --categories (all categories by default), " +
+			"--all-regions, --html, --org, --org-tags and --secret-values. The policy is for the credentials that collect each account; " +
+			"with --accounts or --assume-role-arn the caller only needs sts:AssumeRole on the target roles.",
+		Action: func(_ context.Context, cmd *cli.Command) error {
+			return runPermissions(os.Stdout, &permissionsOptions{
+				Categories:   cmd.String("categories"),
+				OrgTags:      cmd.String("org-tags"),
+				SecretValues: cmd.String("secret-values"),
+				AllRegions:   cmd.Bool("all-regions"),
+				HTML:         cmd.Bool("html"),
+				Org:          cmd.Bool("org"),
+			})
+		},
+	}
+}
+
+// runPermissions writes the IAM policy of the options to stdout as indented JSON.
+func runPermissions(stdout io.Writer, opts *permissionsOptions) error {
+	actions, err := requiredActions(opts)
+	if err != nil {
+		return err
+	}
+	policy := IAMPolicy{
+		Version: iamPolicyVersion,
+		Statement: []IAMPolicyStatement{{
+			Sid:      "ArcReadOnly",
+			Effect:   "Allow",
+			Action:   actions,
+			Resource: "*",
+		}},
+	}
+	encoder := json.NewEncoder(stdout)
+	encoder.SetIndent("", "  ")
+	if encodeErr := encoder.Encode(policy); encodeErr != nil {
+		return fmt.Errorf("failed to write policy: %w", encodeErr)
+	}
+	return nil
+}
+
+// requiredActions returns the sorted IAM actions called by a run with the
+// options: those of arc itself and those declared by the selected collectors.
+func requiredActions(opts *permissionsOptions) ([]string, error) {
+	categories := parseCommaList(opts.Categories)
+	if len(categories) == 0 {
+		categories = resources.CollectorNames()
+	}
+	secretValues, err := parseSecretValueMode(opts.SecretValues)
+	if err != nil {
+		return nil, configError(err)
+	}
+	actions := slices.Clone(runActions)
+	for _, category := range categories {
+		collectorActions, actionsErr := resources.RequiredActionsFor(category)
+		if actionsErr != nil {
+			return nil, configError(actionsErr)
+		}
+		actions = append(actions, collectorActions...)
+		if category == "secretsmanager" && secretValues != resources.SecretValuesNone {
+			actions = append(actions, resources.SecretValueActions...)
+		}
+	}
+	if opts.AllRegions {
+		actions = append(actions, allRegionsActions...)
+	}
+	if opts.HTML {
+		actions = append(actions, htmlActions...)
+	}
+	if opts.Org {
+		actions = append(actions, orgActions...)
+		if opts.OrgTags != "" {
+			actions = append(actions, orgTagsActions...)
+		}
+	}
+	slices.Sort(actions)
+	return slices.Compact(actions), nil
+}
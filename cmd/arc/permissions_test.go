@@ -0,0 +1,137 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/y-miyazaki/arc/internal/aws/resources"
+)
+
+func TestRequiredActions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		opts    permissionsOptions
+		want    []string
+		without []string
+	}{
+		{
+			name: "selected categories",
+			opts: permissionsOptions{Categories: "sqs, sns"},
+			want: []string{"sns:GetTopicAttributes", "sns:ListTopics", "sqs:GetQueueAttributes", "sqs:ListQueues", "sts:GetCallerIdentity", "tag:GetResources"},
+		},
+		{
+			name: "all regions, html and organization with tags",
+			opts: permissionsOptions{Categories: "sqs", AllRegions: true, HTML: true, Org: true, OrgTags: "env=prod"},
+			want: []string{
+				"account:GetAccountInformation", "ec2:DescribeRegions",
+				"organizations:ListAccountsForParent", "organizations:ListOrganizationalUnitsForParent", "organizations:ListRoots", "organizations:ListTagsForResource",
+				"sqs:GetQueueAttributes", "sqs:ListQueues", "sts:GetCallerIdentity", "tag:GetResources",
+			},
+		},
+		{
+			name:    "secret values are not read by default",
+			opts:    permissionsOptions{Categories: "secretsmanager"},
+			want:    []string{"kms:ListAliases", "kms:ListKeys", "secretsmanager:ListSecrets", "sts:GetCallerIdentity", "tag:GetResources"},
+			without: []string{"secretsmanager:GetSecretValue"},
+		},
+		{
+			name: "redacted secret values",
+			opts: permissionsOptions{Categories: "secretsmanager", SecretValues: "redacted"},
+			want: []string{"kms:ListAliases", "kms:ListKeys", "secretsmanager:GetSecretValue", "secretsmanager:ListSecrets", "sts:GetCallerIdentity", "tag:GetResources"},
+		},
+		{
+			name:    "secret values without the secretsmanager category",
+			opts:    permissionsOptions{Categories: "sqs", SecretValues: "plaintext"},
+			without: []string{"secretsmanager:GetSecretValue"},
+		},
+		{
+			name:    "org tags without org",
+			opts:    permissionsOptions{Categories: "sqs", OrgTags: "env=prod"},
+			without: []string{"organizations:ListTagsForResource"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := requiredActions(&tt.opts)
+			if err != nil {
+				t.Fatalf("requiredActions(...) error = %v", err)
+			}
+			if tt.want != nil && !slices.Equal(got, tt.want) {
+				t.Fatalf("requiredActions(...) = %v, want %v", got, tt.want)
+			}
+			for _, action := range tt.without {
+				if slices.Contains(got, action) {
+					t.Fatalf("requiredActions(...) = %v, want without %s", got, action)
+				}
+			}
+		})
+	}
+}
+
+func TestRequiredActions_AllCategories(t *testing.T) {
+	t.Parallel()
+
+	got, err := requiredActions(&permissionsOptions{})
+	if err != nil {
+		t.Fatalf("requiredActions(...) error = %v", err)
+	}
+	if !slices.IsSorted(got) || len(slices.Compact(slices.Clone(got))) != len(got) {
+		t.Fatalf("requiredActions(...) = %v, want sorted unique actions", got)
+	}
+	for _, name := range resources.CollectorNames() {
+		actions, actionsErr := resources.RequiredActionsFor(name)
+		if actionsErr != nil {
+			t.Fatalf("RequiredActionsFor(%q) error = %v", name, actionsErr)
+		}
+		for _, action := range actions {
+			if !slices.Contains(got, action) {
+				t.Fatalf("requiredActions(...) misses %s of %s", action, name)
+			}
+		}
+	}
+}
+
+func TestRequiredActions_UnknownCategory(t *testing.T) {
+	t.Parallel()
+
+	_, err := requiredActions(&permissionsOptions{Categories: "sqs,nope"})
+	if !errors.Is(err, resources.ErrUnknownCollector) || !errors.Is(err, ErrConfiguration) {
+		t.Fatalf("requiredActions(...) error = %v, want an unknown collector configuration error", err)
+	}
+}
+
+func TestRequiredActions_UnknownSecretValueMode(t *testing.T) {
+	t.Parallel()
+
+	_, err := requiredActions(&permissionsOptions{Categories: "secretsmanager", SecretValues: "all"})
+	if !errors.Is(err, resources.ErrUnknownSecretValueMode) || !errors.Is(err, ErrConfiguration) {
+		t.Fatalf("requiredActions(...) error = %v, want an unknown secret value mode configuration error", err)
+	}
+}
+
+func TestRunPermissions(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	if err := runPermissions(&buf, &permissionsOptions{Categories: "sqs"}); err != nil {
+		t.Fatalf("runPermissions(...) error = %v", err)
+	}
+	var policy IAMPolicy
+	if err := json.Unmarshal(buf.Bytes(), &policy); err != nil {
+		t.Fatalf("runPermissions(...) wrote invalid JSON: %v\n%s", err, buf.String())
+	}
+	if policy.Version != iamPolicyVersion || len(policy.Statement) != 1 {
+		t.Fatalf("runPermissions(...) policy = %+v, want one statement", policy)
+	}
+	statement := policy.Statement[0]
+	want := []string{"sqs:GetQueueAttributes", "sqs:ListQueues", "sts:GetCallerIdentity", "tag:GetResources"}
+	if statement.Effect != "Allow" || statement.Resource != "*" || !slices.Equal(statement.Action, want) {
+		t.Fatalf("runPermissions(...) statement = %+v, want Allow %v on *", statement, want)
+	}
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli/v3"
+	"github.com/y-miyazaki/go-common/pkg/logger"
+
+	"github.com/y-miyazaki/arc/internal/server"
+)
+
+// Timeouts of the HTTP server of the serve command.
+const (
+	serveReadHeaderTimeout = 10 * time.Second
+	serveShutdownTimeout   = 10 * time.Second
+)
+
+// ErrInvalidBasicAuth is returned when --basic-auth is not "user:password".
+var ErrInvalidBasicAuth = errors.New(`--basic-auth must be "user:password"`)
+
+// newServeCommand returns the "arc serve" subcommand serving an output directory over HTTP.
+func newServeCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "serve",
+		Usage: "Serve the HTML viewer and a read-only JSON API over the collected accounts",
+		Description: "Serves every account of --output-dir: an account picker at /, the HTML viewer at /{account-id}/ " +
+			"and the JSON API at /api/accounts. Run the collection with --html first.",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "addr",
+				Usage: "Address to listen on",
+				Value: "127.0.0.1:8080",
+			},
+			&cli.StringFlag{
+				Name:    "basic-auth",
+				Usage:   "Require HTTP basic authentication with user:password",
+				Sources: cli.EnvVars("ARC_SERVE_BASIC_AUTH"),
+			},
+		},
+		Action: func(c context.Context, cmd *cli.Command) error {
+			username, password, err := parseBasicAuth(cmd.String("basic-auth"))
+			if err != nil {
+				return err
+			}
+			handler, err := server.NewHandler(server.Options{
+				Dir:      cmd.String("output-dir"),
+				Username: username,
+				Password: password,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to serve %s: %w", cmd.String("output-dir"), err)
+			}
+			ctx, stop := createRunContext(c, 0)
+			defer stop()
+			l := logger.NewSlogLogger(&logger.SlogConfig{
+				Level:  slog.LevelInfo,
+				Format: "text",
+			})
+			return runServe(ctx, l, cmd.String("addr"), handler, username != "")
+		},
+	}
+}
+
+// parseBasicAuth splits the --basic-auth value "user:password". An empty value disables authentication.
+func parseBasicAuth(s string) (username, password string, err error) {
+	if s == "" {
+		return "", "", nil
+	}
+	username, password, ok := strings.Cut(s, ":")
+	if !ok || username == "" || password == "" {
+		return "", "", ErrInvalidBasicAuth
+	}
+	return username, password, nil
+}
+
+// runServe serves handler on addr until ctx is canceled, then shuts the server
+// down, waiting up to serveShutdownTimeout for the pending requests.
+func runServe(ctx context.Context, l *logger.SlogLogger, addr string, handler http.Handler, auth bool) error {
+	listener, err := (&net.ListenConfig{}).Listen(ctx, "tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", addr, err)
+	}
+	srv := &http.Server{
+		Handler:           handler,
+		ReadHeaderTimeout: serveReadHeaderTimeout,
+	}
+	l.Info("Serving output directory", "url", "http://"+listener.Addr().String()+"/", "basicAuth", auth)
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- srv.Serve(listener)
+	}()
+
+	select {
+	case err := <-errCh:
+		return fmt.Errorf("failed to serve: %w", err)
+	case <-ctx.Done():
+	}
+	l.Info("Shutting down")
+	shutdownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), serveShutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("failed to shut down: %w", err)
+	}
+	if err := <-errCh; !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("failed to serve: %w", err)
+	}
+	return nil
+}
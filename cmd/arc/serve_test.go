@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/y-miyazaki/go-common/pkg/logger"
+)
+
+func TestParseBasicAuth(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		input        string
+		wantUsername string
+		wantPassword string
+		wantErr      bool
+	}{
+		{name: "empty disables authentication", input: ""},
+		{name: "user and password", input: "admin:secret", wantUsername: "admin", wantPassword: "secret"},
+		{name: "password with colon", input: "admin:se:cret", wantUsername: "admin", wantPassword: "se:cret"},
+		{name: "missing colon", input: "admin", wantErr: true},
+		{name: "missing username", input: ":secret", wantErr: true},
+		{name: "missing password", input: "admin:", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			username, password, err := parseBasicAuth(tt.input)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidBasicAuth) {
+					t.Fatalf("parseBasicAuth(%q) error = %v, want ErrInvalidBasicAuth", tt.input, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseBasicAuth(%q) unexpected error = %v", tt.input, err)
+			}
+			if username != tt.wantUsername || password != tt.wantPassword {
+				t.Fatalf("parseBasicAuth(%q) = %q, %q, want %q, %q", tt.input, username, password, tt.wantUsername, tt.wantPassword)
+			}
+		})
+	}
+}
+
+func TestRunServe_ShutsDownOnCancel(t *testing.T) {
+	t.Parallel()
+
+	l := logger.NewSlogLogger(&logger.SlogConfig{
+		Output: io.Discard,
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- runServe(ctx, l, "127.0.0.1:0", http.NotFoundHandler(), false)
+	}()
+	cancel()
+
+	select {
+	case err := <-errCh:
+		if err != nil {
+			t.Fatalf("runServe() unexpected error = %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("runServe() did not return after cancel")
+	}
+}
+
+func TestRunServe_ListenError(t *testing.T) {
+	t.Parallel()
+
+	l := logger.NewSlogLogger(&logger.SlogConfig{
+		Output: io.Discard,
+	})
+	if err := runServe(context.Background(), l, "invalid-address", http.NotFoundHandler(), false); err == nil {
+		t.Fatal("runServe() error = nil, want listen error")
+	}
+}
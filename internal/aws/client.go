@@ -7,10 +7,27 @@
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/aws/smithy-go/middleware"
 )
 
-// NewConfig loads the AWS configuration
-func NewConfig(ctx context.Context, region, profile string) (aws.Config, error) {
+// DefaultRoleSessionName is the session name used when assuming a role without an explicit name.
+const DefaultRoleSessionName = "arc"
+
+// AssumeRoleOptions holds the parameters for assuming an IAM role.
+type AssumeRoleOptions struct {
+	// ExternalID is passed to sts:AssumeRole when set.
+	ExternalID string
+	// RoleARN is the ARN of the role to assume.
+	RoleARN string
+	// SessionName is the role session name. DefaultRoleSessionName is used when empty.
+	SessionName string
+}
+
+// NewConfig loads the AWS configuration.
+// retryOpts configures retries, rate limiting and throttle counting; nil keeps the SDK defaults.
+func NewConfig(ctx context.Context, region, profile string, retryOpts *RetryOptions) (aws.Config, error) {
 	opts := []func(*config.LoadOptions) error{
 		config.WithRegion(region),
 	}
@@ -19,6 +36,16 @@ func NewConfig(ctx context.Context, region, profile string) (aws.Config, error)
 		opts = append(opts, config.WithSharedConfigProfile(profile))
 	}
 
+	if retryOpts != nil {
+		if err := retryOpts.Validate(); err != nil {
+			return aws.Config{}, err
+		}
+		opts = append(opts,
+			config.WithRetryer(retryOpts.newRetryer),
+			config.WithAPIOptions([]func(*middleware.Stack) error{retryOpts.apiOption}),
+		)
+	}
+
 	cfg, err := config.LoadDefaultConfig(ctx, opts...)
 	if err != nil {
 		return aws.Config{}, fmt.Errorf("unable to load SDK config: %w", err)
@@ -26,3 +53,24 @@ func NewConfig(ctx context.Context, region, profile string) (aws.Config, error)
 
 	return cfg, nil
 }
+
+// NewAssumeRoleConfig returns a copy of base whose credentials are obtained by
+// assuming the given role with the base credentials. Credentials are cached and
+// refreshed automatically; no AWS API call is made until they are first used.
+func NewAssumeRoleConfig(base aws.Config, opts *AssumeRoleOptions) aws.Config {
+	sessionName := opts.SessionName
+	if sessionName == "" {
+		sessionName = DefaultRoleSessionName
+	}
+
+	provider := stscreds.NewAssumeRoleProvider(sts.NewFromConfig(base), opts.RoleARN, func(o *stscreds.AssumeRoleOptions) {
+		o.RoleSessionName = sessionName
+		if opts.ExternalID != "" {
+			o.ExternalID = aws.String(opts.ExternalID)
+		}
+	})
+
+	cfg := base.Copy()
+	cfg.Credentials = aws.NewCredentialsCache(provider)
+	return cfg
+}
@@ -2,7 +2,11 @@
 
 import (
 	"context"
+	"errors"
 	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
 )
 
 func TestNewConfig(t *testing.T) {
@@ -36,7 +40,7 @@ func TestNewConfig(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			cfg, err := NewConfig(ctx, tt.region, tt.profile)
+			cfg, err := NewConfig(ctx, tt.region, tt.profile, nil)
 			if (err != nil) != tt.wantErr {
 				t.Fatalf("NewConfig(%q, %q) error = %v, wantErr %v", tt.region, tt.profile, err, tt.wantErr)
 			}
@@ -52,3 +56,68 @@ func TestNewConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestNewConfig_RetryOptions(t *testing.T) {
+	ctx := context.Background()
+
+	cfg, err := NewConfig(ctx, "us-east-1", "", &RetryOptions{Mode: RetryModeAdaptive, MaxAttempts: 7})
+	if err != nil {
+		t.Fatalf("NewConfig() unexpected error = %v", err)
+	}
+	if cfg.Retryer == nil || cfg.Retryer().MaxAttempts() != 7 {
+		t.Fatal("NewConfig() did not apply the retry options")
+	}
+	if len(cfg.APIOptions) == 0 {
+		t.Fatal("NewConfig() did not add the attempt middleware option")
+	}
+
+	if _, err := NewConfig(ctx, "us-east-1", "", &RetryOptions{Mode: "legacy"}); !errors.Is(err, ErrInvalidRetryMode) {
+		t.Fatalf("NewConfig() error = %v, want %v", err, ErrInvalidRetryMode)
+	}
+}
+
+func TestNewAssumeRoleConfig(t *testing.T) {
+	t.Parallel()
+
+	base := aws.Config{
+		Region:      "us-east-1",
+		Credentials: credentials.NewStaticCredentialsProvider("AKID", "SECRET", ""),
+	}
+
+	tests := []struct {
+		name string
+		opts *AssumeRoleOptions
+	}{
+		{
+			name: "role arn only",
+			opts: &AssumeRoleOptions{RoleARN: "arn:aws:iam::123456789012:role/ReadOnly"},
+		},
+		{
+			name: "with external id and session name",
+			opts: &AssumeRoleOptions{
+				RoleARN:     "arn:aws:iam::123456789012:role/ReadOnly",
+				ExternalID:  "external",
+				SessionName: "custom",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cfg := NewAssumeRoleConfig(base, tt.opts)
+			if cfg.Region != base.Region {
+				t.Fatalf("NewAssumeRoleConfig(...) region = %v, want %v", cfg.Region, base.Region)
+			}
+			if cfg.Credentials == nil {
+				t.Fatal("NewAssumeRoleConfig(...) credentials = nil, want non-nil")
+			}
+			if _, ok := cfg.Credentials.(*aws.CredentialsCache); !ok {
+				t.Fatalf("NewAssumeRoleConfig(...) credentials = %T, want *aws.CredentialsCache", cfg.Credentials)
+			}
+			if _, ok := base.Credentials.(credentials.StaticCredentialsProvider); !ok {
+				t.Fatalf("NewAssumeRoleConfig(...) mutated base credentials to %T", base.Credentials)
+			}
+		})
+	}
+}
@@ -0,0 +1,27 @@
+package aws
+
+import (
+	"errors"
+	"slices"
+
+	"github.com/aws/smithy-go"
+)
+
+// accessDeniedErrorCodes are API error codes returned when the credentials are
+// not allowed to call an API, for example a read-only role missing one action.
+var accessDeniedErrorCodes = []string{
+	"AccessDenied",
+	"AccessDeniedException",
+	"AuthorizationError",
+	"UnauthorizedOperation",
+}
+
+// IsAccessDeniedError reports whether err was caused by calling an API the
+// credentials are not allowed to call.
+func IsAccessDeniedError(err error) bool {
+	var apiErr smithy.APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	return slices.Contains(accessDeniedErrorCodes, apiErr.ErrorCode())
+}
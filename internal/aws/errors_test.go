@@ -0,0 +1,36 @@
+package aws
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/aws/smithy-go"
+)
+
+func TestIsAccessDeniedError(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "access denied", err: &smithy.GenericAPIError{Code: "AccessDenied"}, want: true},
+		{name: "access denied exception", err: &smithy.GenericAPIError{Code: "AccessDeniedException"}, want: true},
+		{name: "wrapped unauthorized operation", err: fmt.Errorf("failed: %w", &smithy.GenericAPIError{Code: "UnauthorizedOperation"}), want: true},
+		{name: "sns authorization error", err: &smithy.GenericAPIError{Code: "AuthorizationError"}, want: true},
+		{name: "region not enabled", err: &smithy.GenericAPIError{Code: "OptInRequired"}, want: false},
+		{name: "plain error", err: errors.New("AccessDenied"), want: false},
+		{name: "nil", err: nil, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := IsAccessDeniedError(tt.err); got != tt.want {
+				t.Fatalf("IsAccessDeniedError(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
@@ -43,6 +43,12 @@ func ExtractAccountID(arn string) (string, error) {
 	return parts[ARNPartsAccountIndex], nil
 }
 
+// IAMRoleARN builds the ARN of an IAM role from its partition, account ID and name.
+// roleName may include a path (e.g. "path/to/Role").
+func IAMRoleARN(partition, accountID, roleName string) string {
+	return fmt.Sprintf("arn:%s:iam::%s:role/%s", partition, accountID, strings.TrimPrefix(roleName, "/"))
+}
+
 // FormatJSONIndent converts a value to an indented JSON string with 2-space indentation.
 // If val is a string, it treats it as JSON and formats it.
 // If val is any other type, it marshals the value directly.
@@ -169,6 +175,77 @@ func NormalizeRawData(data map[string]any) map[string]any {
 	return data
 }
 
+// TypedRawData returns a copy of the raw data map whose values are converted with TypedValue.
+// Unlike NormalizeRawData it keeps the structure of the values and does not modify data.
+func TypedRawData(data map[string]any) map[string]any {
+	if data == nil {
+		return nil
+	}
+	out := make(map[string]any, len(data))
+	for k, v := range data {
+		out[k] = TypedValue(v)
+	}
+	return out
+}
+
+// TypedValue converts a raw value into a JSON-friendly value while keeping its type.
+// Pointers are dereferenced, nil pointers and empty strings become nil, times are
+// formatted as RFC3339 in UTC, and slices and string-keyed maps are converted element by element.
+// Other values (numbers, booleans, structs) are returned unchanged.
+func TypedValue(v any) any {
+	if v == nil {
+		return nil
+	}
+
+	switch val := v.(type) {
+	case string:
+		if val == "" {
+			return nil
+		}
+		return val
+	case time.Time:
+		if val.IsZero() {
+			return nil
+		}
+		return val.UTC().Format(time.RFC3339)
+	case *time.Time:
+		if val == nil || val.IsZero() {
+			return nil
+		}
+		return val.UTC().Format(time.RFC3339)
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Pointer:
+		if rv.IsNil() {
+			return nil
+		}
+		return TypedValue(rv.Elem().Interface())
+	case reflect.Slice, reflect.Array:
+		if rv.Kind() == reflect.Slice && rv.IsNil() {
+			return nil
+		}
+		out := make([]any, 0, rv.Len())
+		for i := 0; i < rv.Len(); i++ {
+			out = append(out, TypedValue(rv.Index(i).Interface()))
+		}
+		return out
+	case reflect.Map:
+		if rv.IsNil() || rv.Type().Key().Kind() != reflect.String {
+			return v
+		}
+		out := make(map[string]any, rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			out[iter.Key().String()] = TypedValue(iter.Value().Interface())
+		}
+		return out
+	default:
+		return v
+	}
+}
+
 // ParseTimestamp tries to convert a timestamp string into either *time.Time or the original string.
 // Supported inputs:
 // - epoch seconds (e.g. "1695601655")
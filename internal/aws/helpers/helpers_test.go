@@ -3,6 +3,7 @@
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/cloudfront"
@@ -13,6 +14,7 @@
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
+
 func TestStructToKeyValue(t *testing.T) {
 	t.Parallel()
 
@@ -144,6 +146,79 @@ func TestParseARN(t *testing.T) {
 	}
 }
 
+func TestIAMRoleARN(t *testing.T) {
+	tests := []struct {
+		name      string
+		partition string
+		accountID string
+		roleName  string
+		expected  string
+	}{
+		{
+			name:      "commercial partition",
+			partition: "aws",
+			accountID: "123456789012",
+			roleName:  "ReadOnly",
+			expected:  "arn:aws:iam::123456789012:role/ReadOnly",
+		},
+		{
+			name:      "role with path",
+			partition: "aws-us-gov",
+			accountID: "123456789012",
+			roleName:  "/audit/ReadOnly",
+			expected:  "arn:aws-us-gov:iam::123456789012:role/audit/ReadOnly",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, IAMRoleARN(tt.partition, tt.accountID, tt.roleName))
+		})
+	}
+}
+
+func TestTypedValue(t *testing.T) {
+	t.Parallel()
+
+	created := time.Date(2024, 1, 2, 3, 4, 5, 0, time.FixedZone("JST", 9*60*60))
+	tests := []struct {
+		name string
+		in   any
+		want any
+	}{
+		{name: "nil", in: nil, want: nil},
+		{name: "empty string", in: "", want: nil},
+		{name: "string pointer", in: aws.String("value"), want: "value"},
+		{name: "nil pointer", in: (*string)(nil), want: nil},
+		{name: "int32 pointer", in: aws.Int32(3), want: int32(3)},
+		{name: "bool", in: false, want: false},
+		{name: "time in utc", in: created, want: "2024-01-01T18:04:05Z"},
+		{name: "time pointer", in: &created, want: "2024-01-01T18:04:05Z"},
+		{name: "zero time", in: time.Time{}, want: nil},
+		{name: "string slice keeps order", in: []string{"b", "a"}, want: []any{"b", "a"}},
+		{name: "nil slice", in: []string(nil), want: nil},
+		{name: "nested map", in: map[string]any{"k": aws.Int64(1)}, want: map[string]any{"k": int64(1)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, TypedValue(tt.in))
+		})
+	}
+}
+
+func TestTypedRawData_DoesNotModifyInput(t *testing.T) {
+	t.Parallel()
+
+	in := map[string]any{"Name": aws.String("web"), "Ports": []int32{80, 443}}
+	got := TypedRawData(in)
+
+	assert.Equal(t, map[string]any{"Name": "web", "Ports": []any{int32(80), int32(443)}}, got)
+	assert.IsType(t, (*string)(nil), in["Name"])
+	assert.Nil(t, TypedRawData(nil))
+}
+
 func TestGetResourceNameFromARN(t *testing.T) {
 	tests := []struct {
 		name     string
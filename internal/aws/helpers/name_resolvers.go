@@ -24,7 +24,8 @@
 	ARNResourceIndex = 5
 	// ARNServiceIndex is the index of the service in ARN parts.
 	ARNServiceIndex = 2
-	// CloudFrontRegion is the AWS region for CloudFront global service.
+	// CloudFrontRegion is the AWS region for CloudFront global service in the aws partition.
+	// Use CloudFrontRegionForPartition for other partitions.
 	CloudFrontRegion = "us-east-1"
 	// Colon is the colon character.
 	Colon = ":"
@@ -73,6 +74,7 @@ type NameResolver struct {
 	cloudfrontClients map[string]*cloudfront.Client
 	cache             map[string]map[string]map[string]string // cache[region][resourceType] = map[id]name
 	cloudfrontCache   map[string]string                       // cloudfrontCache[resourceType:id] = name
+	partition         string
 	mu                sync.RWMutex
 }
 
@@ -105,15 +107,20 @@ func NewNameResolver(cfg *aws.Config, regions []string) (*NameResolver, error) {
 		return nil, fmt.Errorf("failed to create KMS clients: %w", err)
 	}
 
-	// CloudFront is a global service, create clients for all regions (endpoint is us-east-1)
-	//nolint:unused // region parameter unused as CloudFront is global (us-east-1 only)
-	cloudfrontClients, err := CreateRegionalClients(cfg, regions, func(c *aws.Config, _ string) *cloudfront.Client {
-		return cloudfront.NewFromConfig(*c, func(o *cloudfront.Options) {
-			o.Region = CloudFrontRegion // CloudFront endpoints are in us-east-1
+	// CloudFront is a global service, create clients for all regions (endpoint is the
+	// partition's CloudFront region). CloudFront is not available in every partition.
+	partition := PartitionForRegion(cfg.Region)
+	cloudfrontClients := make(map[string]*cloudfront.Client)
+	if cloudfrontRegion, ok := CloudFrontRegionForPartition(partition); ok {
+		//nolint:unused // region parameter unused as CloudFront is global
+		cloudfrontClients, err = CreateRegionalClients(cfg, regions, func(c *aws.Config, _ string) *cloudfront.Client {
+			return cloudfront.NewFromConfig(*c, func(o *cloudfront.Options) {
+				o.Region = cloudfrontRegion
+			})
 		})
-	})
-	if err != nil {
-		return nil, fmt.Errorf("failed to create CloudFront clients: %w", err)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create CloudFront clients: %w", err)
+		}
 	}
 
 	return &NameResolver{
@@ -122,6 +129,7 @@ func NewNameResolver(cfg *aws.Config, regions []string) (*NameResolver, error) {
 		cloudfrontClients: cloudfrontClients,
 		cache:             make(map[string]map[string]map[string]string),
 		cloudfrontCache:   make(map[string]string),
+		partition:         partition,
 	}, nil
 }
 
@@ -688,8 +696,7 @@ func (nr *NameResolver) GetCachePolicyName(ctx context.Context, policyID string)
 		return name
 	}
 
-	// CloudFront is a global service, use us-east-1 client
-	client, ok := nr.cloudfrontClients[CloudFrontRegion]
+	client, ok := nr.cloudfrontClient()
 	if !ok {
 		return ""
 	}
@@ -718,8 +725,7 @@ func (nr *NameResolver) GetOriginAccessControlName(ctx context.Context, oacID st
 		return name
 	}
 
-	// CloudFront is a global service, use us-east-1 client
-	client, ok := nr.cloudfrontClients[CloudFrontRegion]
+	client, ok := nr.cloudfrontClient()
 	if !ok {
 		return ""
 	}
@@ -748,8 +754,7 @@ func (nr *NameResolver) GetOriginRequestPolicyName(ctx context.Context, policyID
 		return name
 	}
 
-	// CloudFront is a global service, use us-east-1 client
-	client, ok := nr.cloudfrontClients[CloudFrontRegion]
+	client, ok := nr.cloudfrontClient()
 	if !ok {
 		return ""
 	}
@@ -778,8 +783,7 @@ func (nr *NameResolver) GetResponseHeadersPolicyName(ctx context.Context, policy
 		return name
 	}
 
-	// CloudFront is a global service, use us-east-1 client
-	client, ok := nr.cloudfrontClients[CloudFrontRegion]
+	client, ok := nr.cloudfrontClient()
 	if !ok {
 		return ""
 	}
@@ -800,6 +804,12 @@ func (nr *NameResolver) GetResponseHeadersPolicyName(ctx context.Context, policy
 	return name
 }
 
+// cloudfrontClient returns the CloudFront client registered for the partition's global region.
+func (nr *NameResolver) cloudfrontClient() (*cloudfront.Client, bool) {
+	client, ok := nr.cloudfrontClients[GlobalRegionForPartition(nr.partition)]
+	return client, ok
+}
+
 func (nr *NameResolver) loadCached(region, resourceType string) (map[string]string, bool) {
 	nr.mu.RLock()
 	defer nr.mu.RUnlock()
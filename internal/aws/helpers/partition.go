@@ -0,0 +1,83 @@
+package helpers
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// PartitionAWS is the commercial AWS partition.
+	PartitionAWS = "aws"
+	// PartitionAWSCN is the AWS China partition.
+	PartitionAWSCN = "aws-cn"
+	// PartitionAWSUSGov is the AWS GovCloud (US) partition.
+	PartitionAWSUSGov = "aws-us-gov"
+	// CloudFrontRegionCN is the AWS region hosting the CloudFront control plane in the aws-cn partition.
+	CloudFrontRegionCN = "cn-northwest-1"
+	// GlobalRegionAWS is the region used for global services (IAM, S3, CloudFront, etc.) in the aws partition.
+	GlobalRegionAWS = "us-east-1"
+	// GlobalRegionAWSCN is the region used for global services in the aws-cn partition.
+	GlobalRegionAWSCN = "cn-north-1"
+	// GlobalRegionAWSUSGov is the region used for global services in the aws-us-gov partition.
+	GlobalRegionAWSUSGov = "us-gov-west-1"
+)
+
+// PartitionForRegion returns the partition a region belongs to.
+// Unknown or empty regions are treated as commercial (aws) regions.
+func PartitionForRegion(region string) string {
+	switch {
+	case strings.HasPrefix(region, "cn-"):
+		return PartitionAWSCN
+	case strings.HasPrefix(region, "us-gov-"):
+		return PartitionAWSUSGov
+	default:
+		return PartitionAWS
+	}
+}
+
+// GlobalRegionForPartition returns the region used for global services such as IAM,
+// Route 53 and S3 ListBuckets in the given partition.
+// An empty or unknown partition is treated as the commercial (aws) partition.
+func GlobalRegionForPartition(partition string) string {
+	switch partition {
+	case PartitionAWSCN:
+		return GlobalRegionAWSCN
+	case PartitionAWSUSGov:
+		return GlobalRegionAWSUSGov
+	default:
+		return GlobalRegionAWS
+	}
+}
+
+// CloudFrontRegionForPartition returns the region hosting the CloudFront control plane
+// in the given partition. ok is false when CloudFront is not available in the partition.
+// An empty or unknown partition is treated as the commercial (aws) partition.
+func CloudFrontRegionForPartition(partition string) (region string, ok bool) {
+	switch partition {
+	case PartitionAWSCN:
+		return CloudFrontRegionCN, true
+	case PartitionAWSUSGov:
+		return "", false
+	default:
+		return CloudFrontRegion, true
+	}
+}
+
+// S3BucketARN builds the ARN of an S3 bucket in the given partition.
+// An empty partition is treated as the commercial (aws) partition.
+func S3BucketARN(partition, bucket string) string {
+	if partition == "" {
+		partition = PartitionAWS
+	}
+	return fmt.Sprintf("arn:%s:s3:::%s", partition, bucket)
+}
+
+// Route53HostedZoneARN builds the ARN of a Route 53 hosted zone in the given partition.
+// zoneID may include the "/hostedzone/" prefix returned by the API.
+// An empty partition is treated as the commercial (aws) partition.
+func Route53HostedZoneARN(partition, zoneID string) string {
+	if partition == "" {
+		partition = PartitionAWS
+	}
+	return fmt.Sprintf("arn:%s:route53:::hostedzone/%s", partition, strings.TrimPrefix(zoneID, "/hostedzone/"))
+}
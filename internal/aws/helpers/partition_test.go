@@ -0,0 +1,121 @@
+package helpers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPartitionForRegion(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		region string
+		want   string
+	}{
+		{name: "commercial", region: "ap-northeast-1", want: PartitionAWS},
+		{name: "china", region: "cn-northwest-1", want: PartitionAWSCN},
+		{name: "govcloud", region: "us-gov-west-1", want: PartitionAWSUSGov},
+		{name: "empty", region: "", want: PartitionAWS},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, PartitionForRegion(tt.region))
+		})
+	}
+}
+
+func TestGlobalRegionForPartition(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		partition string
+		want      string
+	}{
+		{name: "commercial", partition: PartitionAWS, want: "us-east-1"},
+		{name: "china", partition: PartitionAWSCN, want: "cn-north-1"},
+		{name: "govcloud", partition: PartitionAWSUSGov, want: "us-gov-west-1"},
+		{name: "empty defaults to commercial", partition: "", want: "us-east-1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, GlobalRegionForPartition(tt.partition))
+		})
+	}
+}
+
+func TestCloudFrontRegionForPartition(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		partition string
+		want      string
+		wantOK    bool
+	}{
+		{name: "commercial", partition: PartitionAWS, want: CloudFrontRegion, wantOK: true},
+		{name: "china", partition: PartitionAWSCN, want: "cn-northwest-1", wantOK: true},
+		{name: "govcloud has no cloudfront", partition: PartitionAWSUSGov, want: "", wantOK: false},
+		{name: "empty defaults to commercial", partition: "", want: CloudFrontRegion, wantOK: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, ok := CloudFrontRegionForPartition(tt.partition)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantOK, ok)
+		})
+	}
+}
+
+func TestS3BucketARN(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		partition string
+		bucket    string
+		want      string
+	}{
+		{name: "commercial", partition: PartitionAWS, bucket: "logs", want: "arn:aws:s3:::logs"},
+		{name: "china", partition: PartitionAWSCN, bucket: "logs", want: "arn:aws-cn:s3:::logs"},
+		{name: "govcloud", partition: PartitionAWSUSGov, bucket: "logs", want: "arn:aws-us-gov:s3:::logs"},
+		{name: "empty defaults to commercial", partition: "", bucket: "logs", want: "arn:aws:s3:::logs"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, S3BucketARN(tt.partition, tt.bucket))
+		})
+	}
+}
+
+func TestRoute53HostedZoneARN(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		partition string
+		zoneID    string
+		want      string
+	}{
+		{name: "commercial", partition: PartitionAWS, zoneID: "Z123", want: "arn:aws:route53:::hostedzone/Z123"},
+		{name: "strips api prefix", partition: PartitionAWSCN, zoneID: "/hostedzone/Z123", want: "arn:aws-cn:route53:::hostedzone/Z123"},
+		{name: "empty defaults to commercial", partition: "", zoneID: "Z123", want: "arn:aws:route53:::hostedzone/Z123"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, Route53HostedZoneARN(tt.partition, tt.zoneID))
+		})
+	}
+}
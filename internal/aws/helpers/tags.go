@@ -0,0 +1,76 @@
+package helpers
+
+import (
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// TagsToMap converts AWS tags to a map keyed by tag key.
+// tags may be a slice (or pointer to a slice) of SDK tag structs with Key/Value
+// or TagKey/TagValue fields of type string or *string, or a map[string]string as
+// returned by List*Tags APIs such as Lambda ListTags. It returns nil when there
+// are no tags so that untagged resources stay distinguishable from unknown tags.
+func TagsToMap(tags any) map[string]string {
+	if m, ok := tags.(map[string]string); ok {
+		if len(m) == 0 {
+			return nil
+		}
+		return maps.Clone(m)
+	}
+
+	val := reflect.ValueOf(tags)
+	if val.Kind() == reflect.Pointer {
+		if val.IsNil() {
+			return nil
+		}
+		val = val.Elem()
+	}
+	if val.Kind() != reflect.Slice || val.Len() == 0 {
+		return nil
+	}
+
+	out := make(map[string]string, val.Len())
+	for i := range val.Len() {
+		item := val.Index(i)
+		if item.Kind() == reflect.Pointer {
+			if item.IsNil() {
+				continue
+			}
+			item = item.Elem()
+		}
+		if item.Kind() != reflect.Struct {
+			continue
+		}
+		key := tagField(item, "Key", "TagKey")
+		if key == "" {
+			continue
+		}
+		out[key] = tagField(item, "Value", "TagValue")
+	}
+	if len(out) == 0 {
+		return nil
+	}
+	return out
+}
+
+// FormatTags returns tags as "key=value" lines sorted by key, the format used in CSV output.
+func FormatTags(tags map[string]string) string {
+	lines := make([]string, 0, len(tags))
+	for _, key := range slices.Sorted(maps.Keys(tags)) {
+		lines = append(lines, key+"="+tags[key])
+	}
+	return strings.Join(lines, "\n")
+}
+
+// tagField returns the string value of the first existing field in names.
+func tagField(item reflect.Value, names ...string) string {
+	for _, name := range names {
+		field := item.FieldByName(name)
+		if field.IsValid() {
+			return StringValue(field.Interface(), "")
+		}
+	}
+	return ""
+}
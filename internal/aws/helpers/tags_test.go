@@ -0,0 +1,64 @@
+package helpers
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	kmstypes "github.com/aws/aws-sdk-go-v2/service/kms/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTagsToMap(t *testing.T) {
+	t.Parallel()
+
+	ec2Tags := []ec2types.Tag{
+		{Key: aws.String("Name"), Value: aws.String("web")},
+		{Key: aws.String("Env"), Value: aws.String("")},
+		{Key: nil, Value: aws.String("ignored")},
+	}
+
+	tests := []struct {
+		name string
+		tags any
+		want map[string]string
+	}{
+		{name: "key value pointers", tags: ec2Tags, want: map[string]string{"Name": "web", "Env": ""}},
+		{name: "pointer to slice", tags: &ec2Tags, want: map[string]string{"Name": "web", "Env": ""}},
+		{name: "tag key and tag value", tags: []kmstypes.Tag{{TagKey: aws.String("Owner"), TagValue: aws.String("team-a")}}, want: map[string]string{"Owner": "team-a"}},
+		{name: "plain strings", tags: []struct{ Key, Value string }{{Key: "Owner", Value: "team-b"}}, want: map[string]string{"Owner": "team-b"}},
+		{name: "map", tags: map[string]string{"Owner": "team-c"}, want: map[string]string{"Owner": "team-c"}},
+		{name: "empty slice", tags: []ec2types.Tag{}, want: nil},
+		{name: "empty map", tags: map[string]string{}, want: nil},
+		{name: "nil", tags: nil, want: nil},
+		{name: "unsupported type", tags: "Owner=team", want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, TagsToMap(tt.tags))
+		})
+	}
+}
+
+func TestFormatTags(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		tags map[string]string
+		want string
+	}{
+		{name: "sorted by key", tags: map[string]string{"Owner": "team-a", "Env": "prod"}, want: "Env=prod\nOwner=team-a"},
+		{name: "empty value", tags: map[string]string{"Backup": ""}, want: "Backup="},
+		{name: "nil", tags: nil, want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, FormatTags(tt.tags))
+		})
+	}
+}
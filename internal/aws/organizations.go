@@ -0,0 +1,216 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/organizations"
+)
+
+// OrganizationsAPI is the subset of the AWS Organizations client used for account discovery.
+type OrganizationsAPI interface {
+	organizations.ListRootsAPIClient
+	organizations.ListOrganizationalUnitsForParentAPIClient
+	organizations.ListAccountsForParentAPIClient
+	organizations.ListTagsForResourceAPIClient
+}
+
+// OrganizationAccount is an account discovered through AWS Organizations.
+type OrganizationAccount struct {
+	// ID is the 12-digit account ID.
+	ID string
+	// Name is the account name registered in Organizations.
+	Name string
+	// OUPath is the slash-separated path of organizational unit names from the root, e.g. "/Root/Workloads/Prod".
+	OUPath string
+	// State is the account state, e.g. ACTIVE or SUSPENDED.
+	State string
+	// Tags holds the account tags. It is only populated when the filter contains tags.
+	Tags map[string]string
+}
+
+// OrganizationAccountFilter selects accounts returned by DiscoverOrganizationAccounts.
+// Empty fields match every account.
+type OrganizationAccountFilter struct {
+	// OUPaths keeps accounts located in one of these OU paths or below them.
+	OUPaths []string
+	// States keeps accounts whose state is one of these values (case-insensitive).
+	States []string
+	// Tags keeps accounts that have every key with the given value.
+	Tags map[string]string
+}
+
+// DiscoverOrganizationAccounts walks the organization tree from its roots and returns
+// the accounts matching filter, ordered by OU path and account ID.
+// It must be called with credentials of the management account or a delegated administrator.
+func DiscoverOrganizationAccounts(ctx context.Context, client OrganizationsAPI, filter *OrganizationAccountFilter) ([]OrganizationAccount, error) {
+	if filter == nil {
+		filter = &OrganizationAccountFilter{}
+	}
+
+	var accounts []OrganizationAccount
+	rootsPaginator := organizations.NewListRootsPaginator(client, &organizations.ListRootsInput{})
+	for rootsPaginator.HasMorePages() {
+		page, err := rootsPaginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list organization roots: %w", err)
+		}
+		for i := range page.Roots {
+			root := &page.Roots[i]
+			found, walkErr := walkOrganizationalUnit(ctx, client, aws.ToString(root.Id), "/"+aws.ToString(root.Name), filter)
+			if walkErr != nil {
+				return nil, walkErr
+			}
+			accounts = append(accounts, found...)
+		}
+	}
+
+	if len(filter.Tags) > 0 {
+		filtered := accounts[:0]
+		for i := range accounts {
+			tags, err := listAccountTags(ctx, client, accounts[i].ID)
+			if err != nil {
+				return nil, err
+			}
+			accounts[i].Tags = tags
+			if matchesTags(tags, filter.Tags) {
+				filtered = append(filtered, accounts[i])
+			}
+		}
+		accounts = filtered
+	}
+
+	slices.SortFunc(accounts, func(a, b OrganizationAccount) int {
+		if order := strings.Compare(a.OUPath, b.OUPath); order != 0 {
+			return order
+		}
+		return strings.Compare(a.ID, b.ID)
+	})
+	return accounts, nil
+}
+
+// walkOrganizationalUnit returns the accounts under parentID and its child OUs
+// that match the OU path and state filters.
+func walkOrganizationalUnit(ctx context.Context, client OrganizationsAPI, parentID, ouPath string, filter *OrganizationAccountFilter) ([]OrganizationAccount, error) {
+	var accounts []OrganizationAccount
+
+	if matchesOUPath(ouPath, filter.OUPaths) {
+		accountsPaginator := organizations.NewListAccountsForParentPaginator(client, &organizations.ListAccountsForParentInput{
+			ParentId: aws.String(parentID),
+		})
+		for accountsPaginator.HasMorePages() {
+			page, err := accountsPaginator.NextPage(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list accounts for %s: %w", ouPath, err)
+			}
+			for i := range page.Accounts {
+				account := &page.Accounts[i]
+				state := string(account.State)
+				if !matchesState(state, filter.States) {
+					continue
+				}
+				accounts = append(accounts, OrganizationAccount{
+					ID:     aws.ToString(account.Id),
+					Name:   aws.ToString(account.Name),
+					OUPath: ouPath,
+					State:  state,
+				})
+			}
+		}
+	}
+
+	ouPaginator := organizations.NewListOrganizationalUnitsForParentPaginator(client, &organizations.ListOrganizationalUnitsForParentInput{
+		ParentId: aws.String(parentID),
+	})
+	for ouPaginator.HasMorePages() {
+		page, err := ouPaginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list organizational units for %s: %w", ouPath, err)
+		}
+		for i := range page.OrganizationalUnits {
+			ou := &page.OrganizationalUnits[i]
+			childPath := ouPath + "/" + aws.ToString(ou.Name)
+			if !couldMatchOUPath(childPath, filter.OUPaths) {
+				continue
+			}
+			found, walkErr := walkOrganizationalUnit(ctx, client, aws.ToString(ou.Id), childPath, filter)
+			if walkErr != nil {
+				return nil, walkErr
+			}
+			accounts = append(accounts, found...)
+		}
+	}
+
+	return accounts, nil
+}
+
+// listAccountTags returns the tags attached to the given account.
+func listAccountTags(ctx context.Context, client OrganizationsAPI, accountID string) (map[string]string, error) {
+	tags := make(map[string]string)
+	paginator := organizations.NewListTagsForResourcePaginator(client, &organizations.ListTagsForResourceInput{
+		ResourceId: aws.String(accountID),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list tags for account %s: %w", accountID, err)
+		}
+		for _, tag := range page.Tags {
+			tags[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+		}
+	}
+	return tags, nil
+}
+
+// matchesOUPath reports whether ouPath equals or is nested below one of prefixes.
+func matchesOUPath(ouPath string, prefixes []string) bool {
+	if len(prefixes) == 0 {
+		return true
+	}
+	for _, prefix := range prefixes {
+		prefix = strings.TrimSuffix(prefix, "/")
+		if strings.EqualFold(ouPath, prefix) || hasPathPrefixFold(ouPath, prefix+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// couldMatchOUPath reports whether ouPath or one of its descendants can match prefixes,
+// so that subtrees which cannot contain matching accounts are not walked.
+func couldMatchOUPath(ouPath string, prefixes []string) bool {
+	if matchesOUPath(ouPath, prefixes) {
+		return true
+	}
+	for _, prefix := range prefixes {
+		if hasPathPrefixFold(strings.TrimSuffix(prefix, "/")+"/", ouPath+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+func hasPathPrefixFold(s, prefix string) bool {
+	return len(s) >= len(prefix) && strings.EqualFold(s[:len(prefix)], prefix)
+}
+
+func matchesState(state string, states []string) bool {
+	if len(states) == 0 {
+		return true
+	}
+	return slices.ContainsFunc(states, func(s string) bool {
+		return strings.EqualFold(s, state)
+	})
+}
+
+func matchesTags(tags, want map[string]string) bool {
+	for key, value := range want {
+		if got, ok := tags[key]; !ok || got != value {
+			return false
+		}
+	}
+	return true
+}
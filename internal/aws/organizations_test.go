@@ -0,0 +1,129 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/organizations"
+	"github.com/aws/aws-sdk-go-v2/service/organizations/types"
+)
+
+// fakeOrganizations is an in-memory organization tree implementing OrganizationsAPI.
+type fakeOrganizations struct {
+	accounts map[string][]types.Account
+	err      error
+	ous      map[string][]types.OrganizationalUnit
+	tags     map[string][]types.Tag
+}
+
+func (f *fakeOrganizations) ListRoots(_ context.Context, _ *organizations.ListRootsInput, _ ...func(*organizations.Options)) (*organizations.ListRootsOutput, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &organizations.ListRootsOutput{Roots: []types.Root{{Id: aws.String("r-root"), Name: aws.String("Root")}}}, nil
+}
+
+func (f *fakeOrganizations) ListOrganizationalUnitsForParent(_ context.Context, in *organizations.ListOrganizationalUnitsForParentInput, _ ...func(*organizations.Options)) (*organizations.ListOrganizationalUnitsForParentOutput, error) {
+	return &organizations.ListOrganizationalUnitsForParentOutput{OrganizationalUnits: f.ous[aws.ToString(in.ParentId)]}, nil
+}
+
+func (f *fakeOrganizations) ListAccountsForParent(_ context.Context, in *organizations.ListAccountsForParentInput, _ ...func(*organizations.Options)) (*organizations.ListAccountsForParentOutput, error) {
+	return &organizations.ListAccountsForParentOutput{Accounts: f.accounts[aws.ToString(in.ParentId)]}, nil
+}
+
+func (f *fakeOrganizations) ListTagsForResource(_ context.Context, in *organizations.ListTagsForResourceInput, _ ...func(*organizations.Options)) (*organizations.ListTagsForResourceOutput, error) {
+	return &organizations.ListTagsForResourceOutput{Tags: f.tags[aws.ToString(in.ResourceId)]}, nil
+}
+
+func newFakeOrganizations() *fakeOrganizations {
+	account := func(id, name string, state types.AccountState) types.Account {
+		return types.Account{Id: aws.String(id), Name: aws.String(name), State: state}
+	}
+	return &fakeOrganizations{
+		accounts: map[string][]types.Account{
+			"r-root":      {account("111111111111", "management", types.AccountStateActive)},
+			"ou-workload": {account("222222222222", "shared", types.AccountStateActive)},
+			"ou-prod":     {account("333333333333", "prod", types.AccountStateActive), account("444444444444", "old-prod", types.AccountStateSuspended)},
+			"ou-sandbox":  {account("555555555555", "sandbox", types.AccountStateActive)},
+		},
+		ous: map[string][]types.OrganizationalUnit{
+			"r-root":      {{Id: aws.String("ou-workload"), Name: aws.String("Workloads")}, {Id: aws.String("ou-sandbox"), Name: aws.String("Sandbox")}},
+			"ou-workload": {{Id: aws.String("ou-prod"), Name: aws.String("Prod")}},
+		},
+		tags: map[string][]types.Tag{
+			"333333333333": {{Key: aws.String("env"), Value: aws.String("prod")}},
+			"555555555555": {{Key: aws.String("env"), Value: aws.String("dev")}},
+		},
+	}
+}
+
+func TestDiscoverOrganizationAccounts(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		filter    *OrganizationAccountFilter
+		wantIDs   []string
+		wantPaths []string
+	}{
+		{
+			name:      "nil filter returns every account",
+			filter:    nil,
+			wantIDs:   []string{"111111111111", "555555555555", "222222222222", "333333333333", "444444444444"},
+			wantPaths: []string{"/Root", "/Root/Sandbox", "/Root/Workloads", "/Root/Workloads/Prod", "/Root/Workloads/Prod"},
+		},
+		{
+			name:      "ou path includes nested units",
+			filter:    &OrganizationAccountFilter{OUPaths: []string{"/root/workloads/"}},
+			wantIDs:   []string{"222222222222", "333333333333", "444444444444"},
+			wantPaths: []string{"/Root/Workloads", "/Root/Workloads/Prod", "/Root/Workloads/Prod"},
+		},
+		{
+			name:      "state filter",
+			filter:    &OrganizationAccountFilter{OUPaths: []string{"/Root/Workloads/Prod"}, States: []string{"active"}},
+			wantIDs:   []string{"333333333333"},
+			wantPaths: []string{"/Root/Workloads/Prod"},
+		},
+		{
+			name:      "tag filter",
+			filter:    &OrganizationAccountFilter{Tags: map[string]string{"env": "prod"}},
+			wantIDs:   []string{"333333333333"},
+			wantPaths: []string{"/Root/Workloads/Prod"},
+		},
+		{
+			name:    "no match",
+			filter:  &OrganizationAccountFilter{OUPaths: []string{"/Root/Missing"}},
+			wantIDs: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := DiscoverOrganizationAccounts(context.Background(), newFakeOrganizations(), tt.filter)
+			if err != nil {
+				t.Fatalf("DiscoverOrganizationAccounts() unexpected error = %v", err)
+			}
+			if len(got) != len(tt.wantIDs) {
+				t.Fatalf("DiscoverOrganizationAccounts() = %+v, want IDs %v", got, tt.wantIDs)
+			}
+			for i := range got {
+				if got[i].ID != tt.wantIDs[i] || got[i].OUPath != tt.wantPaths[i] {
+					t.Fatalf("DiscoverOrganizationAccounts()[%d] = %s %s, want %s %s", i, got[i].ID, got[i].OUPath, tt.wantIDs[i], tt.wantPaths[i])
+				}
+			}
+		})
+	}
+}
+
+func TestDiscoverOrganizationAccounts_Error(t *testing.T) {
+	t.Parallel()
+
+	client := newFakeOrganizations()
+	client.err = errors.New("access denied")
+	if _, err := DiscoverOrganizationAccounts(context.Background(), client, nil); err == nil {
+		t.Fatal("DiscoverOrganizationAccounts() error = nil, want error")
+	}
+}
@@ -0,0 +1,64 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/smithy-go"
+)
+
+// Opt-in statuses returned by EC2 DescribeRegions for regions that can be used.
+const (
+	optInStatusNotRequired = "opt-in-not-required"
+	optInStatusOptedIn     = "opted-in"
+)
+
+// regionNotEnabledErrorCodes are API error codes returned when calling a region
+// that is not enabled for the account (opt-in regions). Credential errors such as
+// AuthFailure and InvalidClientTokenId are not listed: they fail the collection
+// instead of being reported as skipped regions.
+var regionNotEnabledErrorCodes = []string{
+	"OptInRequired",
+	"UnrecognizedClientException",
+}
+
+// EC2RegionsAPI is the subset of the EC2 client used for region discovery.
+type EC2RegionsAPI interface {
+	DescribeRegions(ctx context.Context, params *ec2.DescribeRegionsInput, optFns ...func(*ec2.Options)) (*ec2.DescribeRegionsOutput, error)
+}
+
+// DiscoverEnabledRegions returns the sorted names of the regions enabled for the
+// account: regions that do not require opt-in and opt-in regions that were opted in.
+func DiscoverEnabledRegions(ctx context.Context, client EC2RegionsAPI) ([]string, error) {
+	out, err := client.DescribeRegions(ctx, &ec2.DescribeRegionsInput{
+		AllRegions: aws.Bool(true),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe regions: %w", err)
+	}
+
+	regions := make([]string, 0, len(out.Regions))
+	for i := range out.Regions {
+		region := &out.Regions[i]
+		switch aws.ToString(region.OptInStatus) {
+		case optInStatusNotRequired, optInStatusOptedIn:
+			regions = append(regions, aws.ToString(region.RegionName))
+		}
+	}
+	slices.Sort(regions)
+	return regions, nil
+}
+
+// IsRegionNotEnabledError reports whether err was caused by calling a region that is
+// not enabled for the account, such as an opt-in region that was never opted in.
+func IsRegionNotEnabledError(err error) bool {
+	var apiErr smithy.APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	return slices.Contains(regionNotEnabledErrorCodes, apiErr.ErrorCode())
+}
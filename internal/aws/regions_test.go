@@ -0,0 +1,89 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/smithy-go"
+)
+
+type fakeEC2Regions struct {
+	err     error
+	input   *ec2.DescribeRegionsInput
+	regions []types.Region
+}
+
+func (f *fakeEC2Regions) DescribeRegions(_ context.Context, in *ec2.DescribeRegionsInput, _ ...func(*ec2.Options)) (*ec2.DescribeRegionsOutput, error) {
+	f.input = in
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &ec2.DescribeRegionsOutput{Regions: f.regions}, nil
+}
+
+func TestDiscoverEnabledRegions(t *testing.T) {
+	t.Parallel()
+
+	region := func(name, status string) types.Region {
+		return types.Region{RegionName: aws.String(name), OptInStatus: aws.String(status)}
+	}
+	client := &fakeEC2Regions{regions: []types.Region{
+		region("us-east-1", "opt-in-not-required"),
+		region("ap-east-1", "not-opted-in"),
+		region("me-south-1", "opted-in"),
+		region("ap-northeast-1", "opt-in-not-required"),
+	}}
+
+	got, err := DiscoverEnabledRegions(context.Background(), client)
+	if err != nil {
+		t.Fatalf("DiscoverEnabledRegions() unexpected error = %v", err)
+	}
+	want := []string{"ap-northeast-1", "me-south-1", "us-east-1"}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Fatalf("DiscoverEnabledRegions() = %v, want %v", got, want)
+	}
+	if !aws.ToBool(client.input.AllRegions) {
+		t.Fatal("DiscoverEnabledRegions() did not request all regions")
+	}
+}
+
+func TestDiscoverEnabledRegions_Error(t *testing.T) {
+	t.Parallel()
+
+	client := &fakeEC2Regions{err: errors.New("boom")}
+	if _, err := DiscoverEnabledRegions(context.Background(), client); err == nil {
+		t.Fatal("DiscoverEnabledRegions() error = nil, want error")
+	}
+}
+
+func TestIsRegionNotEnabledError(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "unrecognized client", err: &smithy.GenericAPIError{Code: "UnrecognizedClientException"}, want: true},
+		{name: "wrapped opt-in required", err: fmt.Errorf("failed: %w", &smithy.GenericAPIError{Code: "OptInRequired"}), want: true},
+		{name: "invalid token", err: &smithy.GenericAPIError{Code: "InvalidClientTokenId"}, want: false},
+		{name: "auth failure", err: &smithy.GenericAPIError{Code: "AuthFailure"}, want: false},
+		{name: "access denied", err: &smithy.GenericAPIError{Code: "AccessDenied"}, want: false},
+		{name: "plain error", err: errors.New("boom"), want: false},
+		{name: "nil", err: nil, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := IsRegionNotEnabledError(tt.err); got != tt.want {
+				t.Fatalf("IsRegionNotEnabledError(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
@@ -127,3 +127,11 @@ func (*ACMCollector) Name() string {
 func (*ACMCollector) ShouldSort() bool {
 	return true
 }
+
+// RequiredActions returns the IAM actions called by Collect.
+func (*ACMCollector) RequiredActions() []string {
+	return []string{
+		"acm:DescribeCertificate",
+		"acm:ListCertificates",
+	}
+}
@@ -259,3 +259,10 @@ func (*APIGatewayCollector) Name() string {
 func (*APIGatewayCollector) ShouldSort() bool {
 	return false
 }
+
+// RequiredActions returns the IAM actions called by Collect.
+func (*APIGatewayCollector) RequiredActions() []string {
+	return []string{
+		"apigateway:GET",
+	}
+}
@@ -230,3 +230,12 @@ func (*BatchCollector) Name() string {
 func (*BatchCollector) ShouldSort() bool {
 	return true
 }
+
+// RequiredActions returns the IAM actions called by Collect.
+func (*BatchCollector) RequiredActions() []string {
+	return []string{
+		"batch:DescribeComputeEnvironments",
+		"batch:DescribeJobDefinitions",
+		"batch:DescribeJobQueues",
+	}
+}
@@ -215,3 +215,14 @@ func (*CloudFormationCollector) Name() string {
 func (*CloudFormationCollector) ShouldSort() bool {
 	return true
 }
+
+// RequiredActions returns the IAM actions called by Collect.
+func (*CloudFormationCollector) RequiredActions() []string {
+	return []string{
+		"cloudformation:DescribeStackSet",
+		"cloudformation:DescribeStacks",
+		"cloudformation:ListStackResources",
+		"cloudformation:ListStackSets",
+		"cloudformation:ListStacks",
+	}
+}
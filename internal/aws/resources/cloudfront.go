@@ -21,6 +21,7 @@
 type CloudFrontCollector struct {
 	clients      map[string]*cloudfront.Client
 	nameResolver *helpers.NameResolver
+	partition    string
 }
 
 // NewCloudFrontCollector creates a new CloudFront collector with clients for the specified regions.
@@ -37,11 +38,13 @@ type CloudFrontCollector struct {
 //   - error: Error if client creation fails
 func NewCloudFrontCollector(cfg *aws.Config, regions []string, nameResolver *helpers.NameResolver) (*CloudFrontCollector, error) {
 	// CloudFront is a global service whose control plane endpoints are hosted
-	// in the `us-east-1` region. Ensure the clients map always contains an
-	// entry for the CloudFront control-plane region (`helpers.CloudFrontRegion`),
-	// even if caller did not request it. This guarantees Collect() can always
-	// look up a client for the global CloudFront operations.
-	// Build a de-duplicated regions list and append CloudFrontRegion if missing.
+	// in the `us-east-1` region (`cn-northwest-1` in aws-cn). Ensure the clients
+	// map always contains an entry for the partition's CloudFront control-plane
+	// region (`helpers.CloudFrontRegionForPartition`), even if caller did not
+	// request it. This guarantees Collect() can always look up a client for the
+	// global CloudFront operations.
+	// Build a de-duplicated regions list and append the CloudFront region if missing.
+	partition := helpers.PartitionForRegion(cfg.Region)
 	regionsForClients := make([]string, 0, len(regions)+1)
 	seen := make(map[string]bool, len(regions)+1)
 	for _, r := range regions {
@@ -53,8 +56,8 @@ func NewCloudFrontCollector(cfg *aws.Config, regions []string, nameResolver *hel
 			regionsForClients = append(regionsForClients, r)
 		}
 	}
-	if !seen[helpers.CloudFrontRegion] {
-		regionsForClients = append(regionsForClients, helpers.CloudFrontRegion)
+	if cloudfrontRegion, ok := helpers.CloudFrontRegionForPartition(partition); ok && !seen[cloudfrontRegion] {
+		regionsForClients = append(regionsForClients, cloudfrontRegion)
 	}
 
 	clients, err := helpers.CreateRegionalClients(cfg, regionsForClients, func(c *aws.Config, region string) *cloudfront.Client {
@@ -69,23 +72,29 @@ func NewCloudFrontCollector(cfg *aws.Config, regions []string, nameResolver *hel
 	return &CloudFrontCollector{
 		clients:      clients,
 		nameResolver: nameResolver,
+		partition:    partition,
 	}, nil
 }
 
 // Collect collects CloudFront resources for the specified region.
-// CloudFront is a global service, only process from us-east-1.
-// The collector must have been initialized with a client for this region.
+// CloudFront is a global service, only process from the partition's global region.
+// Partitions without CloudFront (aws-us-gov) return no resources.
+// The collector must have been initialized with a client for the partition's CloudFront region.
 //
 //nolint:revive // cyclomatic complexity is high due to many branches while collecting CloudFront resources
 func (c *CloudFrontCollector) Collect(ctx context.Context, region string) ([]Resource, error) {
-	// CloudFront is a global service, only process from us-east-1
-	if region != "us-east-1" {
+	// CloudFront is a global service, only process from the partition's global region
+	if region != helpers.GlobalRegionForPartition(c.partition) {
+		return nil, nil
+	}
+	cloudfrontRegion, available := helpers.CloudFrontRegionForPartition(c.partition)
+	if !available {
 		return nil, nil
 	}
 
-	svc, ok := c.clients[region]
+	svc, ok := c.clients[cloudfrontRegion]
 	if !ok {
-		return nil, fmt.Errorf("%w: %s", ErrNoClientForRegion, region)
+		return nil, fmt.Errorf("%w: %s", ErrNoClientForRegion, cloudfrontRegion)
 	}
 
 	var resources []Resource
@@ -184,6 +193,7 @@ func (c *CloudFrontCollector) Collect(ctx context.Context, region string) ([]Res
 					SubCategory2: "",
 					Name:         dist.DomainName,
 					Region:       "Global",
+					ARN:          dist.ARN,
 					RawData: map[string]any{
 						"ID":                    dist.Id,
 						"Description":           config.Comment,
@@ -580,3 +590,15 @@ func (*CloudFrontCollector) Name() string {
 func (*CloudFrontCollector) ShouldSort() bool {
 	return false
 }
+
+// RequiredActions returns the IAM actions called by Collect.
+func (*CloudFrontCollector) RequiredActions() []string {
+	return []string{
+		"cloudfront:GetCachePolicy",
+		"cloudfront:GetDistribution",
+		"cloudfront:GetOriginAccessControl",
+		"cloudfront:GetOriginRequestPolicy",
+		"cloudfront:GetResponseHeadersPolicy",
+		"cloudfront:ListDistributions",
+	}
+}
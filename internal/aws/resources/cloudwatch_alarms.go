@@ -131,3 +131,10 @@ func (*CloudWatchAlarmsCollector) Name() string {
 func (*CloudWatchAlarmsCollector) ShouldSort() bool {
 	return true
 }
+
+// RequiredActions returns the IAM actions called by Collect.
+func (*CloudWatchAlarmsCollector) RequiredActions() []string {
+	return []string{
+		"cloudwatch:DescribeAlarms",
+	}
+}
@@ -155,3 +155,14 @@ func (*CloudWatchLogsCollector) Name() string {
 func (*CloudWatchLogsCollector) ShouldSort() bool {
 	return true
 }
+
+// RequiredActions returns the IAM actions called by Collect.
+func (*CloudWatchLogsCollector) RequiredActions() []string {
+	return []string{
+		"kms:ListAliases",
+		"kms:ListKeys",
+		"logs:DescribeLogGroups",
+		"logs:DescribeMetricFilters",
+		"logs:DescribeSubscriptionFilters",
+	}
+}
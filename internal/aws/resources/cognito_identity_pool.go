@@ -87,6 +87,14 @@ func (*CognitoIdentityPoolCollector) ShouldSort() bool {
 	return true
 }
 
+// RequiredActions returns the IAM actions called by Collect.
+func (*CognitoIdentityPoolCollector) RequiredActions() []string {
+	return []string{
+		"cognito-identity:DescribeIdentityPool",
+		"cognito-identity:ListIdentityPools",
+	}
+}
+
 // collectIdentityPools lists identity pools and returns resources.
 func collectIdentityPools(ctx context.Context, region string, identitySvc *cognitoidentity.Client) ([]Resource, error) {
 	var resources []Resource
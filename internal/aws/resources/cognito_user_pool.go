@@ -94,6 +94,17 @@ func (*CognitoUserPoolCollector) ShouldSort() bool {
 	return false
 }
 
+// RequiredActions returns the IAM actions called by Collect.
+func (*CognitoUserPoolCollector) RequiredActions() []string {
+	return []string{
+		"cognito-idp:DescribeUserPool",
+		"cognito-idp:ListGroups",
+		"cognito-idp:ListUserPools",
+		"cognito-idp:ListUsers",
+		"cognito-idp:ListUsersInGroup",
+	}
+}
+
 // collectUserPools lists user pools, groups, and users and returns resources.
 func collectUserPools(ctx context.Context, region string, idpSvc *cognitoidentityprovider.Client) ([]Resource, error) {
 	var resources []Resource
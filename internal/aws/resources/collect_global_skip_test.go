@@ -4,8 +4,11 @@
 	"context"
 	"testing"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/y-miyazaki/arc/internal/aws/helpers"
 )
 
 func TestCollectors_Collect_SkipsNonGlobalRegion(t *testing.T) {
@@ -34,3 +37,48 @@ func TestCollectors_Collect_SkipsNonGlobalRegion(t *testing.T) {
 		})
 	}
 }
+
+func TestCollectors_Collect_SkipsOtherPartitionGlobalRegion(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		call   func(context.Context, string) ([]Resource, error)
+		region string
+	}{
+		{name: "iam policy in aws-cn skips us-east-1", call: (&IAMPolicyCollector{partition: helpers.PartitionAWSCN}).Collect, region: "us-east-1"},
+		{name: "iam role in aws-us-gov skips us-east-1", call: (&IAMRoleCollector{partition: helpers.PartitionAWSUSGov}).Collect, region: "us-east-1"},
+		{name: "iam user group in aws-cn skips us-east-1", call: (&IAMUserGroupCollector{partition: helpers.PartitionAWSCN}).Collect, region: "us-east-1"},
+		{name: "route53 in aws-us-gov skips us-east-1", call: (&Route53Collector{partition: helpers.PartitionAWSUSGov}).Collect, region: "us-east-1"},
+		{name: "s3 bucket in aws-cn skips us-east-1", call: (&S3BucketCollector{partition: helpers.PartitionAWSCN}).Collect, region: "us-east-1"},
+		{name: "cloudfront unavailable in aws-us-gov", call: (&CloudFrontCollector{partition: helpers.PartitionAWSUSGov}).Collect, region: "us-gov-west-1"},
+	}
+
+	for i := range tests {
+		tt := tests[i]
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := tt.call(context.Background(), tt.region)
+			require.NoError(t, err)
+			assert.Nil(t, got)
+		})
+	}
+}
+
+func TestNewGlobalCollectors_PartitionFromConfigRegion(t *testing.T) {
+	t.Parallel()
+
+	cfg := &aws.Config{Region: "cn-north-1"}
+	regions := []string{"cn-north-1"}
+
+	s3Collector, err := NewS3BucketCollector(cfg, regions, nil)
+	require.NoError(t, err)
+	assert.Equal(t, helpers.PartitionAWSCN, s3Collector.partition)
+	assert.Equal(t, "cn-north-1", s3Collector.client.Options().Region)
+
+	cloudfrontCollector, err := NewCloudFrontCollector(cfg, regions, nil)
+	require.NoError(t, err)
+	assert.Equal(t, helpers.PartitionAWSCN, cloudfrontCollector.partition)
+	assert.Contains(t, cloudfrontCollector.clients, helpers.CloudFrontRegionCN)
+}
@@ -208,3 +208,15 @@ func (*DynamoDBCollector) Name() string {
 func (*DynamoDBCollector) ShouldSort() bool {
 	return true
 }
+
+// RequiredActions returns the IAM actions called by Collect.
+func (*DynamoDBCollector) RequiredActions() []string {
+	return []string{
+		"dynamodb:DescribeContinuousBackups",
+		"dynamodb:DescribeTable",
+		"dynamodb:DescribeTimeToLive",
+		"dynamodb:ListTables",
+		"kms:ListAliases",
+		"kms:ListKeys",
+	}
+}
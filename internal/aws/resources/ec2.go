@@ -148,6 +148,7 @@ func (c *EC2Collector) Collect(ctx context.Context, region string) ([]Resource,
 			SubCategory1: "Instance",
 			Name:         helpers.GetTagValue(instance.Tags, tagNameKey),
 			Region:       region,
+			Tags:         helpers.TagsToMap(instance.Tags),
 			RawData: map[string]any{
 				"InstanceID":    instance.InstanceId,
 				"InstanceType":  instance.InstanceType,
@@ -189,3 +190,12 @@ func (*EC2Collector) Name() string {
 func (*EC2Collector) ShouldSort() bool {
 	return true
 }
+
+// RequiredActions returns the IAM actions called by Collect.
+func (*EC2Collector) RequiredActions() []string {
+	return []string{
+		"ec2:DescribeInstances",
+		"ec2:DescribeSubnets",
+		"ec2:DescribeVpcs",
+	}
+}
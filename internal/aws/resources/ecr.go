@@ -167,3 +167,12 @@ func (*ECRCollector) Name() string {
 func (*ECRCollector) ShouldSort() bool {
 	return true
 }
+
+// RequiredActions returns the IAM actions called by Collect.
+func (*ECRCollector) RequiredActions() []string {
+	return []string{
+		"ecr:DescribeRepositories",
+		"ecr:GetLifecyclePolicy",
+		"ecr:ListImages",
+	}
+}
@@ -156,6 +156,20 @@ func (*ECSCollector) ShouldSort() bool {
 	return false
 }
 
+// RequiredActions returns the IAM actions called by Collect.
+func (*ECSCollector) RequiredActions() []string {
+	return []string{
+		"ecs:DescribeClusters",
+		"ecs:DescribeServices",
+		"ecs:DescribeTaskDefinition",
+		"ecs:ListClusters",
+		"ecs:ListServices",
+		"ecs:ListTaskDefinitions",
+		"events:ListRules",
+		"events:ListTargetsByRule",
+	}
+}
+
 // getTaskDef gets task definition with caching.
 // This is a package-level helper function that takes cache as a parameter,
 // making the collector safe for concurrent use across multiple goroutines.
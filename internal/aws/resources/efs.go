@@ -215,3 +215,17 @@ func (*EFSCollector) Name() string {
 func (*EFSCollector) ShouldSort() bool {
 	return true
 }
+
+// RequiredActions returns the IAM actions called by Collect.
+func (*EFSCollector) RequiredActions() []string {
+	return []string{
+		"ec2:DescribeSecurityGroups",
+		"ec2:DescribeSubnets",
+		"elasticfilesystem:DescribeAccessPoints",
+		"elasticfilesystem:DescribeFileSystems",
+		"elasticfilesystem:DescribeMountTargetSecurityGroups",
+		"elasticfilesystem:DescribeMountTargets",
+		"kms:ListAliases",
+		"kms:ListKeys",
+	}
+}
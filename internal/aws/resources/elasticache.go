@@ -293,3 +293,12 @@ func (*ElastiCacheCollector) Name() string {
 func (*ElastiCacheCollector) ShouldSort() bool {
 	return false
 }
+
+// RequiredActions returns the IAM actions called by Collect.
+func (*ElastiCacheCollector) RequiredActions() []string {
+	return []string{
+		"ec2:DescribeSecurityGroups",
+		"elasticache:DescribeCacheClusters",
+		"elasticache:DescribeReplicationGroups",
+	}
+}
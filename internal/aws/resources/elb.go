@@ -211,10 +211,11 @@ func (c *ELBCollector) Collect(ctx context.Context, region string) ([]Resource,
 						Region:       region,
 						ARN:          tg.TargetGroupArn,
 						RawData: map[string]any{
-							"Type":        tg.TargetType,
-							"Protocol":    tg.Protocol,
-							"Port":        tg.Port,
-							"HealthCheck": tg.HealthCheckPath,
+							"Type":         tg.TargetType,
+							"Protocol":     tg.Protocol,
+							"Port":         tg.Port,
+							"HealthCheck":  tg.HealthCheckPath,
+							"LoadBalancer": lb.LoadBalancerArn,
 						},
 					}))
 				}
@@ -242,9 +243,10 @@ func (c *ELBCollector) Collect(ctx context.Context, region string) ([]Resource,
 						Region:       region,
 						ARN:          ls.ListenerArn,
 						RawData: map[string]any{
-							"Protocol":  ls.Protocol,
-							"Port":      ls.Port,
-							"SSLPolicy": ls.SslPolicy,
+							"Protocol":     ls.Protocol,
+							"Port":         ls.Port,
+							"SSLPolicy":    ls.SslPolicy,
+							"LoadBalancer": lb.LoadBalancerArn,
 						},
 					}))
 				}
@@ -290,3 +292,15 @@ func (*ELBCollector) Name() string {
 func (*ELBCollector) ShouldSort() bool {
 	return false
 }
+
+// RequiredActions returns the IAM actions called by Collect.
+func (*ELBCollector) RequiredActions() []string {
+	return []string{
+		"ec2:DescribeSecurityGroups",
+		"ec2:DescribeVpcs",
+		"elasticloadbalancing:DescribeListeners",
+		"elasticloadbalancing:DescribeLoadBalancers",
+		"elasticloadbalancing:DescribeTargetGroups",
+		"wafv2:GetWebACLForResource",
+	}
+}
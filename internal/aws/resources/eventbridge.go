@@ -217,3 +217,13 @@ func (*EventBridgeCollector) Name() string {
 func (*EventBridgeCollector) ShouldSort() bool {
 	return true
 }
+
+// RequiredActions returns the IAM actions called by Collect.
+func (*EventBridgeCollector) RequiredActions() []string {
+	return []string{
+		"events:ListRules",
+		"events:ListTargetsByRule",
+		"scheduler:GetSchedule",
+		"scheduler:ListSchedules",
+	}
+}
@@ -163,3 +163,11 @@ func (*GlueCollector) Name() string {
 func (*GlueCollector) ShouldSort() bool {
 	return true
 }
+
+// RequiredActions returns the IAM actions called by Collect.
+func (*GlueCollector) RequiredActions() []string {
+	return []string{
+		"glue:GetDatabases",
+		"glue:GetJobs",
+	}
+}
@@ -15,6 +15,7 @@
 type IAMPolicyCollector struct {
 	client       *iam.Client
 	nameResolver *helpers.NameResolver //nolint:unused // Reserved for future resource name resolution
+	partition    string
 }
 
 // NewIAMPolicyCollector creates a new IAM Policy collector with clients for the specified regions.
@@ -23,7 +24,7 @@ type IAMPolicyCollector struct {
 //
 // Parameters:
 //   - cfg: AWS configuration with credentials
-//   - regions: List of AWS regions (IAM is global, only processes in us-east-1)
+//   - regions: List of AWS regions (IAM is global, only processes in the partition's global region)
 //   - nameResolver: Shared NameResolver instance for resource name resolution
 //
 // Returns:
@@ -37,14 +38,15 @@ func NewIAMPolicyCollector(cfg *aws.Config, regions []string, nameResolver *help
 	return &IAMPolicyCollector{
 		client:       client,
 		nameResolver: nameResolver,
+		partition:    helpers.PartitionForRegion(cfg.Region),
 	}, nil
 }
 
 // Collect collects IAM Policies for the specified region.
-// IAM is a global service, so this only runs in us-east-1 region.
+// IAM is a global service, so this only runs in the partition's global region (us-east-1 for aws).
 func (c *IAMPolicyCollector) Collect(ctx context.Context, region string) ([]Resource, error) {
-	// IAM is a global service, only process from us-east-1
-	if region != "us-east-1" {
+	// IAM is a global service, only process from the partition's global region
+	if region != helpers.GlobalRegionForPartition(c.partition) {
 		return nil, nil
 	}
 
@@ -62,15 +64,19 @@ func (c *IAMPolicyCollector) Collect(ctx context.Context, region string) ([]Reso
 		for i := range page.Policies {
 			policy := &page.Policies[i]
 
-			// Get detailed policy information including description
+			// Get detailed policy information including description and tags
 			var description string
+			var policyTags map[string]string
 			if policy.Arn != nil {
 				getPolicyInput := &iam.GetPolicyInput{
 					PolicyArn: policy.Arn,
 				}
 				getPolicyOutput, getErr := c.client.GetPolicy(ctx, getPolicyInput)
-				if getErr == nil && getPolicyOutput.Policy != nil && getPolicyOutput.Policy.Description != nil {
-					description = *getPolicyOutput.Policy.Description
+				if getErr == nil && getPolicyOutput.Policy != nil {
+					if getPolicyOutput.Policy.Description != nil {
+						description = *getPolicyOutput.Policy.Description
+					}
+					policyTags = helpers.TagsToMap(getPolicyOutput.Policy.Tags)
 				}
 			}
 
@@ -80,6 +86,7 @@ func (c *IAMPolicyCollector) Collect(ctx context.Context, region string) ([]Reso
 				Name:         policy.PolicyName,
 				Region:       "Global",
 				ARN:          policy.Arn,
+				Tags:         policyTags,
 				RawData: map[string]any{
 					"Description": description,
 					"Scope":       types.PolicyScopeTypeLocal,
@@ -117,3 +124,11 @@ func (*IAMPolicyCollector) Name() string {
 func (*IAMPolicyCollector) ShouldSort() bool {
 	return true
 }
+
+// RequiredActions returns the IAM actions called by Collect.
+func (*IAMPolicyCollector) RequiredActions() []string {
+	return []string{
+		"iam:GetPolicy",
+		"iam:ListPolicies",
+	}
+}
@@ -15,6 +15,7 @@
 type IAMRoleCollector struct {
 	client       *iam.Client
 	nameResolver *helpers.NameResolver //nolint:unused // Reserved for future resource name resolution
+	partition    string
 }
 
 // NewIAMRoleCollector creates a new IAM Role collector with clients for the specified regions.
@@ -23,7 +24,7 @@ type IAMRoleCollector struct {
 //
 // Parameters:
 //   - cfg: AWS configuration with credentials
-//   - regions: List of AWS regions (IAM is global, only processes in us-east-1)
+//   - regions: List of AWS regions (IAM is global, only processes in the partition's global region)
 //   - nameResolver: Shared NameResolver instance for resource name resolution
 //
 // Returns:
@@ -37,14 +38,15 @@ func NewIAMRoleCollector(cfg *aws.Config, regions []string, nameResolver *helper
 	return &IAMRoleCollector{
 		client:       client,
 		nameResolver: nameResolver,
+		partition:    helpers.PartitionForRegion(cfg.Region),
 	}, nil
 }
 
 // Collect collects IAM Roles for the specified region.
-// IAM is a global service, so this only runs in us-east-1 region.
+// IAM is a global service, so this only runs in the partition's global region (us-east-1 for aws).
 func (c *IAMRoleCollector) Collect(ctx context.Context, region string) ([]Resource, error) {
-	// IAM is a global service, only process from us-east-1
-	if region != "us-east-1" {
+	// IAM is a global service, only process from the partition's global region
+	if region != helpers.GlobalRegionForPartition(c.partition) {
 		return nil, nil
 	}
 
@@ -82,6 +84,14 @@ func (c *IAMRoleCollector) Collect(ctx context.Context, region string) ([]Resour
 			if role.RoleLastUsed != nil {
 				lastUsedDate = role.RoleLastUsed.LastUsedDate
 			}
+			// ListRoles does not return tags; tags are optional so errors are ignored.
+			var roleTags map[string]string
+			tagsOut, tagsErr := c.client.ListRoleTags(ctx, &iam.ListRoleTagsInput{
+				RoleName: role.RoleName,
+			})
+			if tagsErr == nil {
+				roleTags = helpers.TagsToMap(tagsOut.Tags)
+			}
 
 			resources = append(resources, NewResource(&ResourceInput{
 				Category:     "iam_role_policy",
@@ -89,6 +99,7 @@ func (c *IAMRoleCollector) Collect(ctx context.Context, region string) ([]Resour
 				Name:         role.RoleName,
 				Region:       "Global",
 				ARN:          role.Arn,
+				Tags:         roleTags,
 				RawData: map[string]any{
 					"Path":                role.Path,
 					"AttachedPolicies":    attachedPolicies,
@@ -128,3 +139,12 @@ func (*IAMRoleCollector) Name() string {
 func (*IAMRoleCollector) ShouldSort() bool {
 	return true
 }
+
+// RequiredActions returns the IAM actions called by Collect.
+func (*IAMRoleCollector) RequiredActions() []string {
+	return []string{
+		"iam:ListAttachedRolePolicies",
+		"iam:ListRoleTags",
+		"iam:ListRoles",
+	}
+}
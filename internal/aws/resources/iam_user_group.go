@@ -14,6 +14,7 @@
 type IAMUserGroupCollector struct {
 	client       *iam.Client
 	nameResolver *helpers.NameResolver //nolint:unused // Reserved for future resource name resolution
+	partition    string
 }
 
 // NewIAMUserGroupCollector creates a new IAM User/Group collector with clients for the specified regions.
@@ -22,7 +23,7 @@ type IAMUserGroupCollector struct {
 //
 // Parameters:
 //   - cfg: AWS configuration with credentials
-//   - regions: List of AWS regions (IAM is global, only processes in us-east-1)
+//   - regions: List of AWS regions (IAM is global, only processes in the partition's global region)
 //   - nameResolver: Shared NameResolver instance for resource name resolution
 //
 // Returns:
@@ -36,14 +37,15 @@ func NewIAMUserGroupCollector(cfg *aws.Config, regions []string, nameResolver *h
 	return &IAMUserGroupCollector{
 		client:       client,
 		nameResolver: nameResolver,
+		partition:    helpers.PartitionForRegion(cfg.Region),
 	}, nil
 }
 
 // Collect collects IAM Users and Groups for the specified region.
-// IAM is a global service, so this only runs in us-east-1 region.
+// IAM is a global service, so this only runs in the partition's global region (us-east-1 for aws).
 func (c *IAMUserGroupCollector) Collect(ctx context.Context, region string) ([]Resource, error) {
-	// IAM is a global service, only process from us-east-1
-	if region != "us-east-1" {
+	// IAM is a global service, only process from the partition's global region
+	if region != helpers.GlobalRegionForPartition(c.partition) {
 		return nil, nil
 	}
 
@@ -58,12 +60,21 @@ func (c *IAMUserGroupCollector) Collect(ctx context.Context, region string) ([]R
 		}
 		for i := range page.Users {
 			user := &page.Users[i]
+			// ListUsers does not return tags; tags are optional so errors are ignored.
+			var userTags map[string]string
+			tagsOut, tagsErr := c.client.ListUserTags(ctx, &iam.ListUserTagsInput{
+				UserName: user.UserName,
+			})
+			if tagsErr == nil {
+				userTags = helpers.TagsToMap(tagsOut.Tags)
+			}
 			resources = append(resources, NewResource(&ResourceInput{
 				Category:     "iam_user_group",
 				SubCategory1: "User",
 				Name:         user.UserName,
 				Region:       "Global",
 				ARN:          user.Arn,
+				Tags:         userTags,
 				RawData: map[string]any{
 					"Path":             user.Path,
 					"PasswordLastUsed": user.PasswordLastUsed,
@@ -157,3 +168,14 @@ func (*IAMUserGroupCollector) Name() string {
 func (*IAMUserGroupCollector) ShouldSort() bool {
 	return true
 }
+
+// RequiredActions returns the IAM actions called by Collect.
+func (*IAMUserGroupCollector) RequiredActions() []string {
+	return []string{
+		"iam:GetGroup",
+		"iam:ListAttachedGroupPolicies",
+		"iam:ListGroups",
+		"iam:ListUserTags",
+		"iam:ListUsers",
+	}
+}
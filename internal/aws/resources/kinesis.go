@@ -190,3 +190,13 @@ func (*KinesisCollector) Name() string {
 func (*KinesisCollector) ShouldSort() bool {
 	return true
 }
+
+// RequiredActions returns the IAM actions called by Collect.
+func (*KinesisCollector) RequiredActions() []string {
+	return []string{
+		"firehose:DescribeDeliveryStream",
+		"firehose:ListDeliveryStreams",
+		"kinesis:DescribeStream",
+		"kinesis:ListStreams",
+	}
+}
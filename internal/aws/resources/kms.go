@@ -132,3 +132,12 @@ func (*KMSCollector) Name() string {
 func (*KMSCollector) ShouldSort() bool {
 	return true
 }
+
+// RequiredActions returns the IAM actions called by Collect.
+func (*KMSCollector) RequiredActions() []string {
+	return []string{
+		"kms:DescribeKey",
+		"kms:ListAliases",
+		"kms:ListKeys",
+	}
+}
@@ -71,11 +71,12 @@ function := &page.Functions[i]
 				architecture = string(function.Architectures[0])
 			}
 
-			// Process environment variables
+			// Process environment variables. Other sensitive values are redacted
+			// before the outputs are written (see the redact package).
 			var envEntries []string
 			if function.Environment != nil && function.Environment.Variables != nil {
 				for k, v := range function.Environment.Variables {
-					// Mask private keys
+					// Mask private keys, even with --redact-mode none
 					if strings.Contains(k, "PRIVATE_KEY") {
 						v = "*****"
 					}
@@ -135,3 +136,10 @@ func (*LambdaCollector) Name() string {
 func (*LambdaCollector) ShouldSort() bool {
 	return true
 }
+
+// RequiredActions returns the IAM actions called by Collect.
+func (*LambdaCollector) RequiredActions() []string {
+	return []string{
+		"lambda:ListFunctions",
+	}
+}
@@ -146,3 +146,12 @@ func (*QuickSightCollector) Name() string {
 func (*QuickSightCollector) ShouldSort() bool {
 	return true
 }
+
+// RequiredActions returns the IAM actions called by Collect.
+func (*QuickSightCollector) RequiredActions() []string {
+	return []string{
+		"quicksight:ListAnalyses",
+		"quicksight:ListDataSources",
+		"sts:GetCallerIdentity",
+	}
+}
@@ -111,6 +111,7 @@ func (c *RDSCollector) Collect(ctx context.Context, region string) ([]Resource,
 			SubCategory1: "DBCluster",
 			Name:         cluster.DBClusterIdentifier,
 			Region:       region,
+			Tags:         helpers.TagsToMap(cluster.TagList),
 			RawData: map[string]any{
 				"ID":                               cluster.DBClusterIdentifier,
 				"Type":                             "DBCluster",
@@ -160,6 +161,7 @@ func (c *RDSCollector) Collect(ctx context.Context, region string) ([]Resource,
 					SubCategory2: "DBInstance",
 					Name:         memberID,
 					Region:       region,
+					Tags:         helpers.TagsToMap(inst.TagList),
 					RawData: map[string]any{
 						"ID":                               memberID,
 						"Type":                             fmt.Sprintf("DBInstance (%s)", role),
@@ -204,6 +206,7 @@ func (c *RDSCollector) Collect(ctx context.Context, region string) ([]Resource,
 			SubCategory1: "DBInstance",
 			Name:         inst.DBInstanceIdentifier,
 			Region:       region,
+			Tags:         helpers.TagsToMap(inst.TagList),
 			RawData: map[string]any{
 				"ID":                               inst.DBInstanceIdentifier,
 				"Type":                             "DBInstance",
@@ -260,3 +263,13 @@ func (*RDSCollector) Name() string {
 func (*RDSCollector) ShouldSort() bool {
 	return false
 }
+
+// RequiredActions returns the IAM actions called by Collect.
+func (*RDSCollector) RequiredActions() []string {
+	return []string{
+		"kms:ListAliases",
+		"kms:ListKeys",
+		"rds:DescribeDBClusters",
+		"rds:DescribeDBInstances",
+	}
+}
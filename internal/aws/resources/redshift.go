@@ -156,3 +156,14 @@ func (*RedshiftCollector) Name() string {
 func (*RedshiftCollector) ShouldSort() bool {
 	return true
 }
+
+// RequiredActions returns the IAM actions called by Collect.
+func (*RedshiftCollector) RequiredActions() []string {
+	return []string{
+		"ec2:DescribeSecurityGroups",
+		"ec2:DescribeVpcs",
+		"kms:ListAliases",
+		"kms:ListKeys",
+		"redshift:DescribeClusters",
+	}
+}
@@ -7,6 +7,8 @@
 	"fmt"
 	"maps"
 	"reflect"
+	"slices"
+	"sync"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 
@@ -23,6 +25,10 @@
 	ErrUnknownCollector     = errors.New("unknown collector")
 )
 
+// constructorsMu serializes the registration of constructors by ColumnsFor and
+// CollectorNames, which may run concurrently.
+var constructorsMu sync.Mutex
+
 // Collector is the interface that all resource collectors must implement.
 // Collectors are initialized with AWS clients for specific regions via dependency injection,
 // and the Collect method no longer requires the aws.Config parameter.
@@ -39,13 +45,24 @@ type Collector interface {
 	ShouldSort() bool
 }
 
+// ActionDeclarer is implemented by collectors to declare the IAM actions their
+// Collect method calls, from which the least-privilege policy of a category
+// selection is built. Every registered collector implements it.
+type ActionDeclarer interface {
+	// RequiredActions returns the IAM actions called by Collect, such as "sqs:ListQueues".
+	RequiredActions() []string
+}
+
 // Column defines a CSV column with a header and a value extractor
 type Column struct {
 	Value  func(Resource) string
 	Header string
 }
 
-// Resource represents a single collected AWS resource
+// Resource represents a single collected AWS resource.
+// RawData holds the values normalized to strings for CSV output, while
+// TypedData keeps the original structure (lists, numbers, booleans) for JSON output.
+// Tags is nil when the resource has no tags or its tags could not be read.
 type Resource struct {
 	ARN          string
 	Category     string
@@ -55,6 +72,8 @@ type Resource struct {
 	SubCategory1 string
 	SubCategory2 string
 	SubCategory3 string
+	Tags         map[string]string
+	TypedData    map[string]any
 }
 
 // ResourceInput is the input for creating a new Resource.
@@ -68,11 +87,14 @@ type ResourceInput struct {
 	SubCategory1 any
 	SubCategory2 any
 	SubCategory3 any
+	Tags         map[string]string
 }
 
 // NewResource creates a new Resource and normalizes its RawData.
 // It automatically converts all input fields to strings using helpers.StringValue.
+// A typed copy of RawData is kept in TypedData before normalization.
 func NewResource(input *ResourceInput) Resource {
+	typedData := helpers.TypedRawData(input.RawData)
 	return Resource{
 		Category:     helpers.StringValue(input.Category),
 		SubCategory1: helpers.StringValue(input.SubCategory1, ""),
@@ -82,6 +104,8 @@ func NewResource(input *ResourceInput) Resource {
 		Region:       helpers.StringValue(input.Region),
 		ARN:          helpers.StringValue(input.ARN, ""),
 		RawData:      helpers.NormalizeRawData(input.RawData),
+		Tags:         input.Tags,
+		TypedData:    typedData,
 	}
 }
 
@@ -120,7 +144,80 @@ func InitializeCollectors(cfg *aws.Config, regions []string) error {
 		return fmt.Errorf("failed to create NameResolver: %w", err)
 	}
 
-	// Register all collector constructors
+	registerConstructors()
+
+	for name := range collectorConstructors {
+		collector, collErr := createCollector(name, cfg, regions, nameResolver)
+		if collErr != nil {
+			return fmt.Errorf("failed to initialize %s collector: %w", name, collErr)
+		}
+		Register(name, collector)
+	}
+
+	return nil
+}
+
+// ColumnsFor returns the CSV columns of the named collector, including the Tags
+// column, without initializing it with AWS clients. It is used to interpret
+// previously exported output.
+func ColumnsFor(name string) ([]Column, error) {
+	// GetColumns does not depend on collector state, so a zero value is enough.
+	collector, err := zeroCollector(name)
+	if err != nil {
+		return nil, err
+	}
+	return WithTagColumns(collector, nil).GetColumns(), nil
+}
+
+// RequiredActionsFor returns the IAM actions called by the named collector,
+// without initializing it with AWS clients.
+func RequiredActionsFor(name string) ([]string, error) {
+	collector, err := zeroCollector(name)
+	if err != nil {
+		return nil, err
+	}
+	declarer, ok := collector.(ActionDeclarer)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s does not declare its IAM actions", ErrInvalidCollectorType, name)
+	}
+	return declarer.RequiredActions(), nil
+}
+
+// zeroCollector returns a zero value of the named collector, for the methods
+// that do not depend on collector state.
+func zeroCollector(name string) (Collector, error) {
+	constructor, exists := registeredConstructors()[name]
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownCollector, name)
+	}
+	collectorType := reflect.TypeOf(constructor).Out(0)
+	if collectorType.Kind() != reflect.Pointer {
+		return nil, ErrInvalidCollectorType
+	}
+	collector, ok := reflect.New(collectorType.Elem()).Interface().(Collector)
+	if !ok {
+		return nil, ErrInvalidCollectorType
+	}
+	return collector, nil
+}
+
+// CollectorNames returns the sorted names of all supported collectors, which
+// are the category names accepted by --categories.
+func CollectorNames() []string {
+	return slices.Sorted(maps.Keys(registeredConstructors()))
+}
+
+// registeredConstructors registers the constructors and returns a copy of them.
+// It is safe for concurrent use, unlike InitializeCollectors.
+func registeredConstructors() map[string]any {
+	constructorsMu.Lock()
+	defer constructorsMu.Unlock()
+	registerConstructors()
+	return maps.Clone(collectorConstructors)
+}
+
+// registerConstructors registers the constructors of all supported collectors.
+func registerConstructors() {
 	// Add new collectors here as they are migrated to the DI pattern
 	RegisterConstructor("acm", NewACMCollector)
 	RegisterConstructor("apigateway", NewAPIGatewayCollector)
@@ -159,16 +256,6 @@ func InitializeCollectors(cfg *aws.Config, regions []string) error {
 	RegisterConstructor("transferfamily", NewTransferFamilyCollector)
 	RegisterConstructor("vpc", NewVPCCollector)
 	RegisterConstructor("waf", NewWAFCollector)
-
-	for name := range collectorConstructors {
-		collector, collErr := createCollector(name, cfg, regions, nameResolver)
-		if collErr != nil {
-			return fmt.Errorf("failed to initialize %s collector: %w", name, collErr)
-		}
-		Register(name, collector)
-	}
-
-	return nil
 }
 
 // Register registers a collector to the global registry.
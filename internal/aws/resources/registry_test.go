@@ -4,6 +4,7 @@
 	"context"
 	"errors"
 	"maps"
+	"slices"
 	"testing"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -149,6 +150,41 @@ func TestNewResource(t *testing.T) {
 	}
 }
 
+func TestNewResource_KeepsTypedData(t *testing.T) {
+	t.Parallel()
+
+	got := NewResource(&ResourceInput{
+		Category: "test-category",
+		Name:     "test-name",
+		Region:   "us-east-1",
+		RawData: map[string]any{
+			"Count":   42,
+			"Enabled": true,
+			"Subnets": []string{"subnet-b", "subnet-a"},
+		},
+	})
+
+	assert.Equal(t, map[string]any{
+		"Count":   42,
+		"Enabled": true,
+		"Subnets": []any{"subnet-b", "subnet-a"},
+	}, got.TypedData)
+	assert.Equal(t, "42", got.RawData["Count"])
+}
+
+func TestNewResource_KeepsTags(t *testing.T) {
+	t.Parallel()
+
+	got := NewResource(&ResourceInput{
+		Category: "test-category",
+		Name:     "test-name",
+		Tags:     map[string]string{"Owner": "team-a"},
+	})
+
+	assert.Equal(t, map[string]string{"Owner": "team-a"}, got.Tags)
+	assert.Nil(t, NewResource(&ResourceInput{Category: "test-category"}).Tags)
+}
+
 func TestRegister(t *testing.T) {
 	// Clear the registry before test
 	originalCollectors := make(map[string]Collector)
@@ -293,6 +329,58 @@ func TestRegisterConstructor(t *testing.T) {
 	assert.NotNil(t, collectorConstructors["test"])
 }
 
+func TestColumnsFor(t *testing.T) {
+	// Mutates package constructor map; omit t.Parallel() (TBL-06).
+	originalConstructors := maps.Clone(collectorConstructors)
+	defer func() {
+		collectorConstructors = originalConstructors
+	}()
+
+	cols, err := ColumnsFor("lambda")
+	require.NoError(t, err)
+	headers := make([]string, 0, len(cols))
+	for _, col := range cols {
+		headers = append(headers, col.Header)
+	}
+	assert.Contains(t, headers, "RoleARN")
+	assert.Equal(t, TagsColumn, headers[len(headers)-1])
+
+	_, err = ColumnsFor("unknown")
+	assert.ErrorIs(t, err, ErrUnknownCollector)
+}
+
+func TestRequiredActionsFor(t *testing.T) {
+	// Mutates package constructor map; omit t.Parallel() (TBL-06).
+	originalConstructors := maps.Clone(collectorConstructors)
+	defer func() {
+		collectorConstructors = originalConstructors
+	}()
+
+	actions, err := RequiredActionsFor("sqs")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"sqs:GetQueueAttributes", "sqs:ListQueues"}, actions)
+
+	_, err = RequiredActionsFor("unknown")
+	require.ErrorIs(t, err, ErrUnknownCollector)
+
+	RegisterConstructor("test", NewMockCollector)
+	_, err = RequiredActionsFor("test")
+	assert.ErrorIs(t, err, ErrInvalidCollectorType)
+}
+
+func TestCollectorNames(t *testing.T) {
+	// Mutates package constructor map; omit t.Parallel() (TBL-06).
+	originalConstructors := maps.Clone(collectorConstructors)
+	defer func() {
+		collectorConstructors = originalConstructors
+	}()
+
+	names := CollectorNames()
+	assert.Contains(t, names, "lambda")
+	assert.Contains(t, names, "iam_role")
+	assert.True(t, slices.IsSorted(names))
+}
+
 func TestCreateCollector(t *testing.T) {
 	originalConstructors := make(map[string]any)
 	maps.Copy(originalConstructors, collectorConstructors)
@@ -0,0 +1,165 @@
+package resources
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// sdkServicePath is the import path prefix of the AWS SDK service clients.
+const sdkServicePath = "github.com/aws/aws-sdk-go-v2/service/"
+
+// sdkActionPrefixes maps the AWS SDK service packages to their IAM service prefix.
+var sdkActionPrefixes = map[string]string{
+	"acm":                     "acm",
+	"apigateway":              "apigateway",
+	"apigatewayv2":            "apigateway",
+	"batch":                   "batch",
+	"cloudformation":          "cloudformation",
+	"cloudfront":              "cloudfront",
+	"cloudwatch":              "cloudwatch",
+	"cloudwatchlogs":          "logs",
+	"cognitoidentity":         "cognito-identity",
+	"cognitoidentityprovider": "cognito-idp",
+	"dynamodb":                "dynamodb",
+	"ec2":                     "ec2",
+	"ecr":                     "ecr",
+	"ecs":                     "ecs",
+	"efs":                     "elasticfilesystem",
+	"elasticache":             "elasticache",
+	"elasticloadbalancingv2":  "elasticloadbalancing",
+	"eventbridge":             "events",
+	"firehose":                "firehose",
+	"glue":                    "glue",
+	"iam":                     "iam",
+	"kinesis":                 "kinesis",
+	"kms":                     "kms",
+	"lambda":                  "lambda",
+	"quicksight":              "quicksight",
+	"rds":                     "rds",
+	"redshift":                "redshift",
+	"route53":                 "route53",
+	"s3":                      "s3",
+	"scheduler":               "scheduler",
+	"secretsmanager":          "secretsmanager",
+	"sesv2":                   "ses",
+	"sfn":                     "states",
+	"sns":                     "sns",
+	"sqs":                     "sqs",
+	"sts":                     "sts",
+	"transfer":                "transfer",
+	"wafv2":                   "wafv2",
+}
+
+// sdkActionNames maps the operations whose IAM action is not named after the operation.
+var sdkActionNames = map[string]string{
+	"s3:GetBucketAccelerateConfiguration": "s3:GetAccelerateConfiguration",
+	"s3:GetBucketEncryption":              "s3:GetEncryptionConfiguration",
+	"s3:GetBucketLifecycleConfiguration":  "s3:GetLifecycleConfiguration",
+	"s3:GetObjectLockConfiguration":       "s3:GetBucketObjectLockConfiguration",
+	"s3:GetPublicAccessBlock":             "s3:GetBucketPublicAccessBlock",
+	"s3:ListBuckets":                      "s3:ListAllMyBuckets",
+}
+
+// nameResolverActions are the IAM actions called by the NameResolver methods.
+var nameResolverActions = map[string][]string{
+	"GetAllKMSKeys":                {"kms:ListAliases", "kms:ListKeys"},
+	"GetAllSecurityGroups":         {"ec2:DescribeSecurityGroups"},
+	"GetAllSubnets":                {"ec2:DescribeSubnets"},
+	"GetAllVPCs":                   {"ec2:DescribeVpcs"},
+	"GetCachePolicyName":           {"cloudfront:GetCachePolicy"},
+	"GetOriginAccessControlName":   {"cloudfront:GetOriginAccessControl"},
+	"GetOriginRequestPolicyName":   {"cloudfront:GetOriginRequestPolicy"},
+	"GetResponseHeadersPolicyName": {"cloudfront:GetResponseHeadersPolicy"},
+}
+
+// optionalActions are the IAM actions a collector only calls when an option
+// enables them, which RequiredActions leaves out.
+var optionalActions = map[string][]string{
+	"secretsmanager": SecretValueActions,
+}
+
+// calledActions returns the sorted IAM actions of the AWS SDK operations whose
+// input is built in the Go file at path, and of the NameResolver methods it calls.
+func calledActions(t *testing.T, filename string) []string {
+	t.Helper()
+	file, err := parser.ParseFile(token.NewFileSet(), filename, nil, 0)
+	require.NoError(t, err)
+
+	services := make(map[string]string)
+	for _, spec := range file.Imports {
+		importPath, unquoteErr := strconv.Unquote(spec.Path.Value)
+		require.NoError(t, unquoteErr)
+		service, ok := strings.CutPrefix(importPath, sdkServicePath)
+		if !ok || strings.Contains(service, "/") {
+			continue
+		}
+		name := path.Base(importPath)
+		if spec.Name != nil {
+			name = spec.Name.Name
+		}
+		services[name] = service
+	}
+
+	var actions []string
+	ast.Inspect(file, func(n ast.Node) bool {
+		sel, ok := n.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		if resolver, isSel := sel.X.(*ast.SelectorExpr); isSel && resolver.Sel.Name == "nameResolver" {
+			resolverActions, known := nameResolverActions[sel.Sel.Name]
+			require.Truef(t, known, "%s: NameResolver.%s is missing from nameResolverActions", filename, sel.Sel.Name)
+			actions = append(actions, resolverActions...)
+			return true
+		}
+		pkg, ok := sel.X.(*ast.Ident)
+		if !ok {
+			return true
+		}
+		service, ok := services[pkg.Name]
+		operation, isInput := strings.CutSuffix(sel.Sel.Name, "Input")
+		if !ok || !isInput {
+			return true
+		}
+		prefix, known := sdkActionPrefixes[service]
+		require.Truef(t, known, "%s: service %s is missing from sdkActionPrefixes", filename, service)
+		action := prefix + ":" + operation
+		if prefix == "apigateway" {
+			// API Gateway authorizes the HTTP method of its management API.
+			action = "apigateway:GET"
+		}
+		if name, renamed := sdkActionNames[action]; renamed {
+			action = name
+		}
+		actions = append(actions, action)
+		return true
+	})
+	slices.Sort(actions)
+	return slices.Compact(actions)
+}
+
+func TestRequiredActions_MatchClientCalls(t *testing.T) {
+	// Reads the package constructor map; omit t.Parallel() (TBL-06).
+	for _, name := range CollectorNames() {
+		t.Run(name, func(t *testing.T) {
+			want := calledActions(t, name+".go")
+			require.NotEmpty(t, want, "the collector calls no AWS API")
+			for _, action := range optionalActions[name] {
+				require.Contains(t, want, action, "optional action not called by %s.go", name)
+				want = slices.DeleteFunc(want, func(a string) bool { return a == action })
+			}
+			got, err := RequiredActionsFor(name)
+			require.NoError(t, err)
+			assert.Equal(t, want, got, "RequiredActions must list, sorted, the actions of the operations called by %s.go", name)
+		})
+	}
+}
@@ -12,10 +12,11 @@
 
 // Route53Collector collects Route53 resources.
 // It uses dependency injection to manage Route53 clients.
-// Route53 is a global service - only processes from us-east-1 to avoid duplicates.
+// Route53 is a global service - only processes from the partition's global region to avoid duplicates.
 type Route53Collector struct {
 	client       *route53.Client
 	nameResolver *helpers.NameResolver //nolint:unused // Reserved for future resource name resolution
+	partition    string
 }
 
 // NewRoute53Collector creates a new Route53 collector with a global client.
@@ -24,7 +25,7 @@ type Route53Collector struct {
 //
 // Parameters:
 //   - cfg: AWS configuration with credentials
-//   - regions: List of AWS regions (only us-east-1 will be used for global service)
+//   - regions: List of AWS regions (only the partition's global region will be used for global service)
 //   - nameResolver: Shared NameResolver instance for resource name resolution
 //
 // Returns:
@@ -38,14 +39,15 @@ func NewRoute53Collector(cfg *aws.Config, regions []string, nameResolver *helper
 	return &Route53Collector{
 		client:       client,
 		nameResolver: nameResolver,
+		partition:    helpers.PartitionForRegion(cfg.Region),
 	}, nil
 }
 
 // Collect collects Route53 resources for the specified region.
-// Route53 is a global service - only processes from us-east-1 to avoid duplicates.
+// Route53 is a global service - only processes from the partition's global region to avoid duplicates.
 func (c *Route53Collector) Collect(ctx context.Context, region string) ([]Resource, error) {
-	// Route53 is a global service, only process from us-east-1 to avoid duplicates.
-	if region != "us-east-1" {
+	// Route53 is a global service, only process from the partition's global region to avoid duplicates.
+	if region != helpers.GlobalRegionForPartition(c.partition) {
 		return nil, nil
 	}
 
@@ -79,6 +81,7 @@ func (c *Route53Collector) Collect(ctx context.Context, region string) ([]Resour
 				SubCategory1: "HostedZone",
 				Name:         zoneName,
 				Region:       "Global",
+				ARN:          helpers.Route53HostedZoneARN(c.partition, zoneID),
 				RawData: map[string]any{
 					"ID":          zoneID,
 					"Type":        zoneType,
@@ -163,3 +166,11 @@ func (*Route53Collector) Name() string {
 func (*Route53Collector) ShouldSort() bool {
 	return false
 }
+
+// RequiredActions returns the IAM actions called by Collect.
+func (*Route53Collector) RequiredActions() []string {
+	return []string{
+		"route53:ListHostedZones",
+		"route53:ListResourceRecordSets",
+	}
+}
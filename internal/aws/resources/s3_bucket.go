@@ -18,10 +18,11 @@
 
 // S3BucketCollector collects S3 buckets.
 // It uses dependency injection to manage S3 clients.
-// S3 is a global service - only processes from us-east-1 to avoid duplicates.
+// S3 is a global service - only processes from the partition's global region to avoid duplicates.
 type S3BucketCollector struct {
 	client       *s3.Client
 	nameResolver *helpers.NameResolver //nolint:unused // Reserved for future resource name resolution
+	partition    string
 }
 
 // NewS3BucketCollector creates a new S3 bucket collector with a global client.
@@ -30,30 +31,33 @@ type S3BucketCollector struct {
 //
 // Parameters:
 //   - cfg: AWS configuration with credentials
-//   - regions: List of AWS regions (only us-east-1 will be used for global service)
+//   - regions: List of AWS regions (only the partition's global region will be used for global service)
 //   - nameResolver: Shared NameResolver instance for resource name resolution
 //
 // Returns:
 //   - *S3BucketCollector: Initialized collector with global client and name resolver
 //   - error: Error if client creation fails
 func NewS3BucketCollector(cfg *aws.Config, regions []string, nameResolver *helpers.NameResolver) (*S3BucketCollector, error) {
-	// S3 is a global service, create a single client for us-east-1
+	// S3 is a global service, create a single client for the partition's global region
 	_ = regions // unused parameter
+	partition := helpers.PartitionForRegion(cfg.Region)
 	client := s3.NewFromConfig(*cfg, func(o *s3.Options) {
-		o.Region = "us-east-1"
+		o.Region = helpers.GlobalRegionForPartition(partition)
 	})
 
 	return &S3BucketCollector{
 		client:       client,
 		nameResolver: nameResolver,
+		partition:    partition,
 	}, nil
 }
 
 // Collect collects S3 resources for the specified region.
-// S3 is a global service - only processes from us-east-1 to avoid duplicates.
+// S3 is a global service - only processes from the partition's global region to avoid duplicates.
 func (c *S3BucketCollector) Collect(ctx context.Context, region string) ([]Resource, error) {
-	// S3 bucket listing only works from us-east-1 (global service).
-	if region != "us-east-1" {
+	// S3 bucket listing is global, only process from the partition's global region.
+	globalRegion := helpers.GlobalRegionForPartition(c.partition)
+	if region != globalRegion {
 		return nil, nil
 	}
 
@@ -67,7 +71,7 @@ func (c *S3BucketCollector) Collect(ctx context.Context, region string) ([]Resou
 
 	// Cache for region-specific clients.
 	regionClients := make(map[string]*s3.Client)
-	regionClients["us-east-1"] = c.client
+	regionClients[globalRegion] = c.client
 
 	// Helper to get or create client for a region.
 	getClient := func(r string) *s3.Client {
@@ -86,7 +90,7 @@ func (c *S3BucketCollector) Collect(ctx context.Context, region string) ([]Resou
 		bucket := &listBucketsOut.Buckets[i]
 
 		// Get bucket location using global client.
-		bucketRegion := "us-east-1" // default
+		bucketRegion := globalRegion // default
 		locationOut, locErr := c.client.GetBucketLocation(ctx, &s3.GetBucketLocationInput{
 			Bucket: bucket.Name,
 		})
@@ -127,8 +131,9 @@ func (c *S3BucketCollector) Collect(ctx context.Context, region string) ([]Resou
 			versioning = string(versioningOut.Status)
 		}
 
-		// Get bucket tagging for ABAC.
+		// Get bucket tagging for ABAC and the resource tags.
 		var bucketABAC []string
+		var bucketTags map[string]string
 		taggingOut, taggingErr := svc.GetBucketTagging(ctx, &s3.GetBucketTaggingInput{
 			Bucket: bucket.Name,
 		})
@@ -136,6 +141,7 @@ func (c *S3BucketCollector) Collect(ctx context.Context, region string) ([]Resou
 			for _, tag := range taggingOut.TagSet {
 				bucketABAC = append(bucketABAC, fmt.Sprintf("%s=%s", helpers.StringValue(tag.Key), helpers.StringValue(tag.Value)))
 			}
+			bucketTags = helpers.TagsToMap(taggingOut.TagSet)
 		}
 
 		// Public Access Block
@@ -185,7 +191,7 @@ func (c *S3BucketCollector) Collect(ctx context.Context, region string) ([]Resou
 		if logErr == nil && loggingOut.LoggingEnabled != nil && loggingOut.LoggingEnabled.TargetBucket != nil {
 			targetBucket := helpers.StringValue(loggingOut.LoggingEnabled.TargetBucket)
 			if targetBucket != "" {
-				accessLogARN = fmt.Sprintf("arn:aws:s3:::%s", targetBucket)
+				accessLogARN = helpers.S3BucketARN(c.partition, targetBucket)
 			}
 		}
 
@@ -246,7 +252,8 @@ func (c *S3BucketCollector) Collect(ctx context.Context, region string) ([]Resou
 			SubCategory1: "Bucket",
 			Name:         bucket.Name,
 			Region:       bucketRegion,
-			ARN:          fmt.Sprintf("arn:aws:s3:::%s", helpers.StringValue(bucket.Name)),
+			ARN:          helpers.S3BucketARN(c.partition, helpers.StringValue(bucket.Name)),
+			Tags:         bucketTags,
 			RawData: map[string]any{
 				"Versioning":               versioning,
 				"BucketABAC":               bucketABAC,
@@ -308,3 +315,22 @@ func (*S3BucketCollector) Name() string {
 func (*S3BucketCollector) ShouldSort() bool {
 	return true
 }
+
+// RequiredActions returns the IAM actions called by Collect.
+func (*S3BucketCollector) RequiredActions() []string {
+	return []string{
+		"s3:GetAccelerateConfiguration",
+		"s3:GetBucketAcl",
+		"s3:GetBucketLocation",
+		"s3:GetBucketLogging",
+		"s3:GetBucketObjectLockConfiguration",
+		"s3:GetBucketPublicAccessBlock",
+		"s3:GetBucketRequestPayment",
+		"s3:GetBucketTagging",
+		"s3:GetBucketVersioning",
+		"s3:GetBucketWebsite",
+		"s3:GetEncryptionConfiguration",
+		"s3:GetLifecycleConfiguration",
+		"s3:ListAllMyBuckets",
+	}
+}
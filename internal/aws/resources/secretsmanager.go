@@ -2,18 +2,60 @@
 
 import (
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"maps"
+	"slices"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
 	"github.com/y-miyazaki/arc/internal/aws/helpers"
 )
 
+// Secret value modes of the Secrets Manager collector.
+const (
+	// SecretValuesNone does not read secret values: GetSecretValue is not called.
+	SecretValuesNone = "none"
+	// SecretValuesRedacted reads secret values but only records their JSON key
+	// names, length and salted hash, so that a changed value can be detected
+	// without exposing it.
+	SecretValuesRedacted = "redacted"
+	// SecretValuesPlaintext reads secret values and records them in SecretString.
+	SecretValuesPlaintext = "plaintext"
+)
+
+// SecretValueModes lists the supported secret value modes.
+var SecretValueModes = []string{SecretValuesNone, SecretValuesRedacted, SecretValuesPlaintext}
+
+// SecretValueActions are the IAM actions called by the Secrets Manager collector
+// only when it reads secret values.
+var SecretValueActions = []string{"secretsmanager:GetSecretValue"}
+
+// Sentinel errors for Secrets Manager operations.
+var (
+	ErrUnknownSecretValueMode = errors.New("unknown secret value mode")
+)
+
+// SecretValueOptions selects whether and how the Secrets Manager collector
+// records secret values. The zero value does not read them.
+type SecretValueOptions struct {
+	// Mode is one of SecretValueModes. Empty is SecretValuesNone.
+	Mode string
+	// Salt keys the HMAC-SHA256 hash of SecretValuesRedacted. The same salt must
+	// be used across runs for their hashes to be comparable.
+	Salt string
+}
+
 // SecretsManagerCollector collects Secrets Manager secrets.
 // It uses dependency injection to manage Secrets Manager clients for multiple regions.
 type SecretsManagerCollector struct {
 	clients      map[string]*secretsmanager.Client
 	nameResolver *helpers.NameResolver
+	secretValues SecretValueOptions
 }
 
 // NewSecretsManagerCollector creates a new Secrets Manager collector with clients for the specified regions.
@@ -44,8 +86,15 @@ func NewSecretsManagerCollector(cfg *aws.Config, regions []string, nameResolver
 	}, nil
 }
 
+// SetSecretValueOptions sets how the following Collect calls record secret values.
+// It must not be called while Collect runs.
+func (c *SecretsManagerCollector) SetSecretValueOptions(opts SecretValueOptions) {
+	c.secretValues = opts
+}
+
 // Collect collects Secrets Manager resources for the specified region.
 // The collector must have been initialized with a client for this region.
+// Secret values are only read when enabled by SetSecretValueOptions.
 func (c *SecretsManagerCollector) Collect(ctx context.Context, region string) ([]Resource, error) {
 	svc, ok := c.clients[region]
 	if !ok {
@@ -70,16 +119,17 @@ func (c *SecretsManagerCollector) Collect(ctx context.Context, region string) ([
 		for i := range page.SecretList {
 			secret := &page.SecretList[i]
 
-			// Get secret value to retrieve SecretString
-			var secretStringValue string
+			rawData := map[string]any{
+				"Description":       secret.Description,
+				"KmsKey":            helpers.ResolveNameFromMap(secret.KmsKeyId, kmsMap),
+				"RotationEnabled":   secret.RotationEnabled,
+				"RotationLambdaARN": secret.RotationLambdaARN,
+				"LastAccessedDate":  secret.LastAccessedDate,
+				"LastRotatedDate":   secret.LastRotatedDate,
+				"LastChangedDate":   secret.LastChangedDate,
+			}
 			if secret.ARN != nil {
-				getValueOutput, getValueErr := svc.GetSecretValue(ctx, &secretsmanager.GetSecretValueInput{
-					SecretId: secret.ARN,
-				})
-				if getValueErr == nil && getValueOutput.SecretString != nil { // pragma: allowlist secret
-					// Format as indented JSON if valid, otherwise return raw string
-					secretStringValue = helpers.FormatJSONIndentOrRaw(*getValueOutput.SecretString)
-				}
+				maps.Copy(rawData, c.secretValueData(ctx, svc, secret.ARN))
 			}
 
 			r := NewResource(&ResourceInput{
@@ -88,16 +138,7 @@ func (c *SecretsManagerCollector) Collect(ctx context.Context, region string) ([
 				Name:         secret.Name,
 				Region:       region,
 				ARN:          secret.ARN,
-				RawData: map[string]any{
-					"Description":       secret.Description,
-					"KmsKey":            helpers.ResolveNameFromMap(secret.KmsKeyId, kmsMap),
-					"RotationEnabled":   secret.RotationEnabled,
-					"RotationLambdaARN": secret.RotationLambdaARN,
-					"SecretString":      secretStringValue,
-					"LastAccessedDate":  secret.LastAccessedDate,
-					"LastRotatedDate":   secret.LastRotatedDate,
-					"LastChangedDate":   secret.LastChangedDate,
-				},
+				RawData:      rawData,
 			})
 			resources = append(resources, r)
 		}
@@ -106,6 +147,55 @@ func (c *SecretsManagerCollector) Collect(ctx context.Context, region string) ([
 	return resources, nil
 }
 
+// secretValueData returns the raw data recording the value of the secret arn
+// under the secret value mode: nothing with SecretValuesNone or when the value
+// cannot be read, SecretString with SecretValuesPlaintext, and SecretKeys,
+// SecretLength and SecretHash with SecretValuesRedacted.
+func (c *SecretsManagerCollector) secretValueData(ctx context.Context, svc *secretsmanager.Client, arn *string) map[string]any {
+	mode := c.secretValues.Mode
+	if mode != SecretValuesRedacted && mode != SecretValuesPlaintext {
+		return nil
+	}
+	output, err := svc.GetSecretValue(ctx, &secretsmanager.GetSecretValueInput{
+		SecretId: arn,
+	})
+	if err != nil {
+		return nil
+	}
+	if mode == SecretValuesPlaintext {
+		if output.SecretString == nil { // pragma: allowlist secret
+			return nil
+		}
+		// Format as indented JSON if valid, otherwise return raw string
+		return map[string]any{"SecretString": helpers.FormatJSONIndentOrRaw(*output.SecretString)}
+	}
+	switch {
+	case output.SecretString != nil: // pragma: allowlist secret
+		return redactSecretValue([]byte(*output.SecretString), c.secretValues.Salt)
+	case output.SecretBinary != nil:
+		return redactSecretValue(output.SecretBinary, c.secretValues.Salt)
+	default:
+		return nil
+	}
+}
+
+// redactSecretValue returns the raw data of SecretValuesRedacted for a secret
+// value: the sorted top-level key names when the value is a JSON object, the
+// length of the value in bytes and its HMAC-SHA256 hash keyed with salt.
+func redactSecretValue(value []byte, salt string) map[string]any {
+	mac := hmac.New(sha256.New, []byte(salt))
+	mac.Write(value)
+	data := map[string]any{
+		"SecretLength": len(value),
+		"SecretHash":   hex.EncodeToString(mac.Sum(nil)),
+	}
+	var object map[string]json.RawMessage
+	if json.Unmarshal(value, &object) == nil && len(object) > 0 {
+		data["SecretKeys"] = slices.Sorted(maps.Keys(object))
+	}
+	return data
+}
+
 // GetColumns returns the CSV columns for the collector.
 func (*SecretsManagerCollector) GetColumns() []Column {
 	return []Column{
@@ -119,6 +209,9 @@ func (*SecretsManagerCollector) GetColumns() []Column {
 		{Header: "RotationEnabled", Value: func(r Resource) string { return helpers.GetMapValue(r.RawData, "RotationEnabled") }},
 		{Header: "RotationLambdaARN", Value: func(r Resource) string { return helpers.GetMapValue(r.RawData, "RotationLambdaARN") }},
 		{Header: "SecretString", Value: func(r Resource) string { return helpers.GetMapValue(r.RawData, "SecretString") }},
+		{Header: "SecretKeys", Value: func(r Resource) string { return helpers.GetMapValue(r.RawData, "SecretKeys") }},
+		{Header: "SecretLength", Value: func(r Resource) string { return helpers.GetMapValue(r.RawData, "SecretLength") }},
+		{Header: "SecretHash", Value: func(r Resource) string { return helpers.GetMapValue(r.RawData, "SecretHash") }},
 		{Header: "LastAccessedDate", Value: func(r Resource) string { return helpers.GetMapValue(r.RawData, "LastAccessedDate") }},
 		{Header: "LastRotatedDate", Value: func(r Resource) string { return helpers.GetMapValue(r.RawData, "LastRotatedDate") }},
 		{Header: "LastChangedDate", Value: func(r Resource) string { return helpers.GetMapValue(r.RawData, "LastChangedDate") }},
@@ -134,3 +227,13 @@ func (*SecretsManagerCollector) Name() string {
 func (*SecretsManagerCollector) ShouldSort() bool {
 	return true
 }
+
+// RequiredActions returns the IAM actions called by Collect, except the
+// SecretValueActions only called when secret values are read.
+func (*SecretsManagerCollector) RequiredActions() []string {
+	return []string{
+		"kms:ListAliases",
+		"kms:ListKeys",
+		"secretsmanager:ListSecrets",
+	}
+}
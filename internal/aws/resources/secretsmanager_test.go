@@ -1,6 +1,7 @@
 package resources
 
 import (
+	"context"
 	"testing"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -91,7 +92,9 @@ func TestSecretsManagerCollector_GetColumns(t *testing.T) {
 					"KmsKey":            "arn:aws:kms:us-east-1:123456789012:key/12345678-1234-1234-1234-123456789012",
 					"RotationEnabled":   "true",
 					"RotationLambdaARN": "arn:aws:lambda:us-east-1:123456789012:function:rotation-function",
-					"SecretString":      "{\n  \"username\": \"admin\"\n}",
+					"SecretKeys":        "password\nusername",
+					"SecretLength":      "42",
+					"SecretHash":        "0a1b2c",
 					"LastAccessedDate":  "2023-09-24T01:07:55Z",
 					"LastRotatedDate":   "2023-09-25T01:07:55Z",
 					"LastChangedDate":   "2023-09-26T01:07:55Z",
@@ -99,11 +102,11 @@ func TestSecretsManagerCollector_GetColumns(t *testing.T) {
 			},
 			wantHeaders: []string{
 				"Category", "SubCategory1", "Name", "Region", "ARN",
-				"Description", "KmsKey", "RotationEnabled", "RotationLambdaARN", "SecretString", "LastAccessedDate", "LastRotatedDate", "LastChangedDate",
+				"Description", "KmsKey", "RotationEnabled", "RotationLambdaARN", "SecretString", "SecretKeys", "SecretLength", "SecretHash", "LastAccessedDate", "LastRotatedDate", "LastChangedDate",
 			},
 			wantValues: []string{
 				"Security", "SecretsManager", "test-secret", "us-east-1", "arn:aws:secretsmanager:us-east-1:123456789012:secret:test-secret-AbCdEf",
-				"Test secret", "arn:aws:kms:us-east-1:123456789012:key/12345678-1234-1234-1234-123456789012", "true", "arn:aws:lambda:us-east-1:123456789012:function:rotation-function", "{\n  \"username\": \"admin\"\n}", "2023-09-24T01:07:55Z", "2023-09-25T01:07:55Z", "2023-09-26T01:07:55Z",
+				"Test secret", "arn:aws:kms:us-east-1:123456789012:key/12345678-1234-1234-1234-123456789012", "true", "arn:aws:lambda:us-east-1:123456789012:function:rotation-function", "", "password\nusername", "42", "0a1b2c", "2023-09-24T01:07:55Z", "2023-09-25T01:07:55Z", "2023-09-26T01:07:55Z",
 			},
 		},
 	}
@@ -121,3 +124,97 @@ func TestSecretsManagerCollector_GetColumns(t *testing.T) {
 		})
 	}
 }
+
+func TestSecretsManagerCollector_SecretValueData(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		opts SecretValueOptions
+	}{
+		{name: "zero options do not read values", opts: SecretValueOptions{}},
+		{name: "none does not read values", opts: SecretValueOptions{Mode: SecretValuesNone, Salt: "salt"}},
+		{name: "unknown mode does not read values", opts: SecretValueOptions{Mode: "all"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			collector := &SecretsManagerCollector{}
+			collector.SetSecretValueOptions(tt.opts)
+			// A nil client fails the test if GetSecretValue is called.
+			assert.Nil(t, collector.secretValueData(context.Background(), nil, aws.String("arn:aws:secretsmanager:us-east-1:123456789012:secret:test")))
+		})
+	}
+}
+
+func TestRedactSecretValue(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		value    string
+		salt     string
+		wantKeys any
+		wantLen  int
+		wantHash string
+	}{
+		{
+			name:     "json object records sorted key names",
+			value:    `{"username":"admin","password":"p"}`,
+			salt:     "salt",
+			wantKeys: []string{"password", "username"},
+			wantLen:  35,
+			wantHash: "a9619b1184a75b39a58b41e5e93e32c026e836985a6cd4f9ec29d45ec7196c9d",
+		},
+		{
+			name:     "plain string records no key names",
+			value:    "p",
+			salt:     "salt",
+			wantLen:  1,
+			wantHash: "d611f60228c1e0dcfd330b2e9d48d345b3eec089f3bd7a55d351efeb842d4f7c",
+		},
+		{
+			name:     "empty json object records no key names",
+			value:    "{}",
+			salt:     "salt",
+			wantLen:  2,
+			wantHash: "2a1e7d8006270f6e2b733e05d4840d126e14a012212274b8eb2ca152c1f505aa",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			data := redactSecretValue([]byte(tt.value), tt.salt)
+			assert.Equal(t, tt.wantKeys, data["SecretKeys"])
+			assert.Equal(t, tt.wantLen, data["SecretLength"])
+			assert.Equal(t, tt.wantHash, data["SecretHash"])
+			assert.NotContains(t, data, "SecretString")
+		})
+	}
+}
+
+func TestRedactSecretValue_Salt(t *testing.T) {
+	t.Parallel()
+
+	value := []byte(`{"password":"p"}`)
+	tests := []struct {
+		name     string
+		saltA    string
+		saltB    string
+		wantSame bool
+	}{
+		{name: "same salt gives the same hash", saltA: "salt", saltB: "salt", wantSame: true},
+		{name: "different salts give different hashes", saltA: "salt", saltB: "other", wantSame: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			a := redactSecretValue(value, tt.saltA)["SecretHash"]
+			b := redactSecretValue(value, tt.saltB)["SecretHash"]
+			assert.Equal(t, tt.wantSame, a == b)
+		})
+	}
+}
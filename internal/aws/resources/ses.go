@@ -231,3 +231,14 @@ func (*SESCollector) Name() string { return "ses" }
 
 // ShouldSort returns whether the collected resources should be sorted.
 func (*SESCollector) ShouldSort() bool { return false }
+
+// RequiredActions returns the IAM actions called by Collect.
+func (*SESCollector) RequiredActions() []string {
+	return []string{
+		"ses:GetConfigurationSet",
+		"ses:GetConfigurationSetEventDestinations",
+		"ses:GetEmailIdentity",
+		"ses:ListConfigurationSets",
+		"ses:ListEmailIdentities",
+	}
+}
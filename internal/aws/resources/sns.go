@@ -127,3 +127,11 @@ func (*SNSCollector) Name() string {
 func (*SNSCollector) ShouldSort() bool {
 	return true
 }
+
+// RequiredActions returns the IAM actions called by Collect.
+func (*SNSCollector) RequiredActions() []string {
+	return []string{
+		"sns:GetTopicAttributes",
+		"sns:ListTopics",
+	}
+}
@@ -132,3 +132,11 @@ func (*SQSCollector) Name() string {
 func (*SQSCollector) ShouldSort() bool {
 	return true
 }
+
+// RequiredActions returns the IAM actions called by Collect.
+func (*SQSCollector) RequiredActions() []string {
+	return []string{
+		"sqs:GetQueueAttributes",
+		"sqs:ListQueues",
+	}
+}
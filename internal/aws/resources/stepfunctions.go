@@ -169,6 +169,16 @@ func (*StepFunctionsCollector) ShouldSort() bool {
 	return true
 }
 
+// RequiredActions returns the IAM actions called by Collect.
+func (*StepFunctionsCollector) RequiredActions() []string {
+	return []string{
+		"states:DescribeActivity",
+		"states:DescribeStateMachine",
+		"states:ListActivities",
+		"states:ListStateMachines",
+	}
+}
+
 func getDefinitionComment(definition *string) string {
 	if definition == nil {
 		return ""
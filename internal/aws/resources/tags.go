@@ -0,0 +1,121 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/resourcegroupstaggingapi"
+
+	"github.com/y-miyazaki/arc/internal/aws/helpers"
+)
+
+const (
+	// TagColumnPrefix prefixes the header of a tag key promoted to its own column (e.g. "Tag:Owner").
+	TagColumnPrefix = "Tag:"
+	// TagsColumn is the header of the column listing all tags of a resource.
+	TagsColumn = "Tags"
+	// tagsPerPage is the maximum page size of the Resource Groups Tagging API GetResources.
+	tagsPerPage = 100
+)
+
+// taggedCollector decorates a collector so that its columns end with the Tags
+// column followed by one column per promoted tag key.
+type taggedCollector struct {
+	Collector
+
+	tagKeys []string
+}
+
+// WithTagColumns returns c with the Tags column and one "Tag:<key>" column per
+// entry of tagKeys appended to its columns.
+func WithTagColumns(c Collector, tagKeys []string) Collector {
+	return &taggedCollector{Collector: c, tagKeys: tagKeys}
+}
+
+// GetColumns returns the collector columns followed by the tag columns.
+func (c *taggedCollector) GetColumns() []Column {
+	return append(c.Collector.GetColumns(), TagColumns(c.tagKeys)...)
+}
+
+// TagColumns returns the Tags column and one column per tag key.
+// A promoted tag column is empty when the resource does not have the tag.
+func TagColumns(tagKeys []string) []Column {
+	columns := []Column{
+		{Header: TagsColumn, Value: func(r Resource) string { return helpers.FormatTags(r.Tags) }},
+	}
+	for _, key := range tagKeys {
+		columns = append(columns, Column{
+			Header: TagColumnPrefix + key,
+			Value:  func(r Resource) string { return r.Tags[key] },
+		})
+	}
+	return columns
+}
+
+// TagLoader reads the tags of all taggable resources of a region in bulk with the
+// Resource Groups Tagging API. It complements collectors that do not read tags
+// through their service API.
+type TagLoader struct {
+	clients map[string]*resourcegroupstaggingapi.Client
+}
+
+// NewTagLoader creates a TagLoader with Resource Groups Tagging API clients for the specified regions.
+func NewTagLoader(cfg *aws.Config, regions []string) (*TagLoader, error) {
+	clients, err := helpers.CreateRegionalClients(cfg, regions, func(c *aws.Config, region string) *resourcegroupstaggingapi.Client {
+		return resourcegroupstaggingapi.NewFromConfig(*c, func(o *resourcegroupstaggingapi.Options) {
+			o.Region = region
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create resource groups tagging clients: %w", err)
+	}
+	return &TagLoader{clients: clients}, nil
+}
+
+// Load returns the tags of every tagged resource of region keyed by resource ARN.
+func (l *TagLoader) Load(ctx context.Context, region string) (map[string]map[string]string, error) {
+	client, ok := l.clients[region]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrNoClientForRegion, region)
+	}
+	return loadTags(ctx, client)
+}
+
+// ApplyTags sets the tags of resources whose ARN is in tagsByARN and that have
+// no tags yet, so tags read by collectors take precedence. It returns the number
+// of updated resources.
+func ApplyTags(res []Resource, tagsByARN map[string]map[string]string) int {
+	updated := 0
+	for i := range res {
+		if res[i].Tags != nil || res[i].ARN == "" {
+			continue
+		}
+		if tags, ok := tagsByARN[res[i].ARN]; ok {
+			res[i].Tags = tags
+			updated++
+		}
+	}
+	return updated
+}
+
+// loadTags pages through GetResources and returns the tags keyed by resource ARN.
+func loadTags(ctx context.Context, client resourcegroupstaggingapi.GetResourcesAPIClient) (map[string]map[string]string, error) {
+	tagsByARN := make(map[string]map[string]string)
+	paginator := resourcegroupstaggingapi.NewGetResourcesPaginator(client, &resourcegroupstaggingapi.GetResourcesInput{
+		ResourcesPerPage: aws.Int32(tagsPerPage),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get tagged resources: %w", err)
+		}
+		for i := range page.ResourceTagMappingList {
+			mapping := &page.ResourceTagMappingList[i]
+			if tags := helpers.TagsToMap(mapping.Tags); tags != nil && mapping.ResourceARN != nil {
+				tagsByARN[*mapping.ResourceARN] = tags
+			}
+		}
+	}
+	return tagsByARN, nil
+}
@@ -0,0 +1,178 @@
+package resources
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/resourcegroupstaggingapi"
+	taggingtypes "github.com/aws/aws-sdk-go-v2/service/resourcegroupstaggingapi/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errTestGetResources = errors.New("get resources failed")
+
+// stubGetResourcesClient returns prepared GetResources pages in sequence.
+type stubGetResourcesClient struct {
+	err   error
+	pages []*resourcegroupstaggingapi.GetResourcesOutput
+	calls int
+}
+
+func (s *stubGetResourcesClient) GetResources(_ context.Context, _ *resourcegroupstaggingapi.GetResourcesInput, _ ...func(*resourcegroupstaggingapi.Options)) (*resourcegroupstaggingapi.GetResourcesOutput, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	if s.calls >= len(s.pages) {
+		return &resourcegroupstaggingapi.GetResourcesOutput{}, nil
+	}
+	out := s.pages[s.calls]
+	s.calls++
+	return out, nil
+}
+
+func TestLoadTags(t *testing.T) {
+	t.Parallel()
+
+	page1 := &resourcegroupstaggingapi.GetResourcesOutput{
+		ResourceTagMappingList: []taggingtypes.ResourceTagMapping{
+			{ResourceARN: aws.String("arn:aws:sqs:us-east-1:123456789012:queue"), Tags: []taggingtypes.Tag{{Key: aws.String("Owner"), Value: aws.String("team-a")}}},
+			{ResourceARN: aws.String("arn:aws:sns:us-east-1:123456789012:untagged")},
+		},
+		PaginationToken: aws.String("next"),
+	}
+	page2 := &resourcegroupstaggingapi.GetResourcesOutput{
+		ResourceTagMappingList: []taggingtypes.ResourceTagMapping{
+			{ResourceARN: aws.String("arn:aws:lambda:us-east-1:123456789012:function:fn"), Tags: []taggingtypes.Tag{{Key: aws.String("Env"), Value: aws.String("prod")}}},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		client  *stubGetResourcesClient
+		want    map[string]map[string]string
+		wantErr error
+	}{
+		{
+			name:   "pages and skips untagged resources",
+			client: &stubGetResourcesClient{pages: []*resourcegroupstaggingapi.GetResourcesOutput{page1, page2}},
+			want: map[string]map[string]string{
+				"arn:aws:sqs:us-east-1:123456789012:queue":          {"Owner": "team-a"},
+				"arn:aws:lambda:us-east-1:123456789012:function:fn": {"Env": "prod"},
+			},
+		},
+		{
+			name:    "api error",
+			client:  &stubGetResourcesClient{err: errTestGetResources},
+			wantErr: errTestGetResources,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := loadTags(context.Background(), tt.client)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestTagLoader_Load_NoClient(t *testing.T) {
+	t.Parallel()
+
+	loader, err := NewTagLoader(&aws.Config{Region: "us-east-1"}, []string{"us-east-1"})
+	require.NoError(t, err)
+
+	_, err = loader.Load(context.Background(), "eu-west-1")
+	assert.ErrorIs(t, err, ErrNoClientForRegion)
+}
+
+func TestApplyTags(t *testing.T) {
+	t.Parallel()
+
+	res := []Resource{
+		{ARN: "arn:aws:sqs:us-east-1:123456789012:queue"},
+		{ARN: "arn:aws:sqs:us-east-1:123456789012:queue", Tags: map[string]string{"Owner": "from-collector"}},
+		{ARN: "arn:aws:sqs:us-east-1:123456789012:other"},
+		{Name: "no-arn"},
+	}
+	tagsByARN := map[string]map[string]string{
+		"arn:aws:sqs:us-east-1:123456789012:queue": {"Owner": "team-a"},
+	}
+
+	assert.Equal(t, 1, ApplyTags(res, tagsByARN))
+	assert.Equal(t, map[string]string{"Owner": "team-a"}, res[0].Tags)
+	assert.Equal(t, map[string]string{"Owner": "from-collector"}, res[1].Tags)
+	assert.Nil(t, res[2].Tags)
+	assert.Nil(t, res[3].Tags)
+}
+
+func TestTagColumns(t *testing.T) {
+	t.Parallel()
+
+	r := Resource{Tags: map[string]string{"Owner": "team-a", "Env": "prod"}}
+
+	tests := []struct {
+		name        string
+		tagKeys     []string
+		resource    Resource
+		wantHeaders []string
+		wantValues  []string
+	}{
+		{
+			name:        "tags column only",
+			resource:    r,
+			wantHeaders: []string{"Tags"},
+			wantValues:  []string{"Env=prod\nOwner=team-a"},
+		},
+		{
+			name:        "promoted tag keys",
+			tagKeys:     []string{"Owner", "CostCenter"},
+			resource:    r,
+			wantHeaders: []string{"Tags", "Tag:Owner", "Tag:CostCenter"},
+			wantValues:  []string{"Env=prod\nOwner=team-a", "team-a", ""},
+		},
+		{
+			name:        "untagged resource",
+			tagKeys:     []string{"Owner"},
+			resource:    Resource{},
+			wantHeaders: []string{"Tags", "Tag:Owner"},
+			wantValues:  []string{"", ""},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cols := TagColumns(tt.tagKeys)
+			require.Len(t, cols, len(tt.wantHeaders))
+			for i, col := range cols {
+				assert.Equal(t, tt.wantHeaders[i], col.Header)
+				assert.Equal(t, tt.wantValues[i], col.Value(tt.resource))
+			}
+		})
+	}
+}
+
+func TestWithTagColumns(t *testing.T) {
+	t.Parallel()
+
+	base := &SQSCollector{}
+	collector := WithTagColumns(base, []string{"Owner"})
+
+	baseCols := base.GetColumns()
+	cols := collector.GetColumns()
+	require.Len(t, cols, len(baseCols)+2)
+	assert.Equal(t, baseCols[0].Header, cols[0].Header)
+	assert.Equal(t, "Tags", cols[len(cols)-2].Header)
+	assert.Equal(t, "Tag:Owner", cols[len(cols)-1].Header)
+	assert.Equal(t, base.Name(), collector.Name())
+	assert.Equal(t, base.ShouldSort(), collector.ShouldSort())
+}
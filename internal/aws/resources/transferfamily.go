@@ -112,3 +112,11 @@ func (*TransferFamilyCollector) Name() string {
 func (*TransferFamilyCollector) ShouldSort() bool {
 	return true
 }
+
+// RequiredActions returns the IAM actions called by Collect.
+func (*TransferFamilyCollector) RequiredActions() []string {
+	return []string{
+		"transfer:DescribeServer",
+		"transfer:ListServers",
+	}
+}
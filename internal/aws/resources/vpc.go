@@ -74,6 +74,7 @@ func (c *VPCCollector) Collect(ctx context.Context, region string) ([]Resource,
 			SubCategory1: "VPC",
 			Name:         helpers.GetTagValue(vpc.Tags, "Name"),
 			Region:       region,
+			Tags:         helpers.TagsToMap(vpc.Tags),
 			RawData: map[string]any{
 				"ID":    vpc.VpcId,
 				"CIDR":  vpc.CidrBlock,
@@ -155,6 +156,7 @@ func (c *VPCCollector) Collect(ctx context.Context, region string) ([]Resource,
 				SubCategory2: subCategory,
 				Name:         helpers.GetTagValue(subnet.Tags, "Name"),
 				Region:       region,
+				Tags:         helpers.TagsToMap(subnet.Tags),
 				RawData: map[string]any{
 					"ID":   subnet.SubnetId,
 					"CIDR": subnet.CidrBlock,
@@ -181,6 +183,7 @@ func (c *VPCCollector) Collect(ctx context.Context, region string) ([]Resource,
 				SubCategory2: "RouteTable",
 				Name:         helpers.GetTagValue(rt.Tags, "Name"),
 				Region:       region,
+				Tags:         helpers.TagsToMap(rt.Tags),
 				RawData: map[string]any{
 					"ID": rt.RouteTableId,
 				},
@@ -203,6 +206,7 @@ func (c *VPCCollector) Collect(ctx context.Context, region string) ([]Resource,
 				SubCategory2: "InternetGateway",
 				Name:         helpers.GetTagValue(igw.Tags, "Name"),
 				Region:       region,
+				Tags:         helpers.TagsToMap(igw.Tags),
 				RawData: map[string]any{
 					"ID":    igw.InternetGatewayId,
 					"State": "attached",
@@ -240,6 +244,7 @@ func (c *VPCCollector) Collect(ctx context.Context, region string) ([]Resource,
 				SubCategory2: "NATGateway",
 				Name:         helpers.GetTagValue(nat.Tags, "Name"),
 				Region:       region,
+				Tags:         helpers.TagsToMap(nat.Tags),
 				RawData: map[string]any{
 					"ID":       nat.NatGatewayId,
 					"PublicIP": publicIPs,
@@ -282,6 +287,7 @@ func (c *VPCCollector) Collect(ctx context.Context, region string) ([]Resource,
 				SubCategory2: "NetworkACL",
 				Name:         helpers.GetTagValue(nacl.Tags, "Name"),
 				Region:       region,
+				Tags:         helpers.TagsToMap(nacl.Tags),
 				RawData: map[string]any{
 					"ID":       nacl.NetworkAclId,
 					"Settings": entries,
@@ -300,37 +306,16 @@ func (c *VPCCollector) Collect(ctx context.Context, region string) ([]Resource,
 		for j := range sgOut.SecurityGroups {
 			sg := &sgOut.SecurityGroups[j]
 
-			// Format inbound rules
-			var inbound []string
-			for k := range sg.IpPermissions {
-				perm := &sg.IpPermissions[k]
-				for l := range perm.IpRanges {
-					inbound = append(inbound, fmt.Sprintf("Protocol: %s | FromPort: %d | ToPort: %d | CIDR: %s",
-						helpers.StringValue(perm.IpProtocol),
-						aws.ToInt32(perm.FromPort),
-						aws.ToInt32(perm.ToPort),
-						helpers.StringValue(perm.IpRanges[l].CidrIp)))
-				}
-			}
-
-			// Format outbound rules
-			var outbound []string
-			for k := range sg.IpPermissionsEgress {
-				perm := &sg.IpPermissionsEgress[k]
-				for l := range perm.IpRanges {
-					outbound = append(outbound, fmt.Sprintf("Protocol: %s | FromPort: %d | ToPort: %d | CIDR: %s",
-						helpers.StringValue(perm.IpProtocol),
-						aws.ToInt32(perm.FromPort),
-						aws.ToInt32(perm.ToPort),
-						helpers.StringValue(perm.IpRanges[l].CidrIp)))
-				}
-			}
+			// Format inbound and outbound rules
+			inbound := formatSecurityGroupRules(sg.IpPermissions)
+			outbound := formatSecurityGroupRules(sg.IpPermissionsEgress)
 
 			resources = append(resources, NewResource(&ResourceInput{
 				Category:     "vpc",
 				SubCategory2: "SecurityGroup",
 				Name:         sg.GroupName,
 				Region:       region,
+				Tags:         helpers.TagsToMap(sg.Tags),
 				RawData: map[string]any{
 					"ID":          sg.GroupId,
 					"Description": sg.Description,
@@ -361,6 +346,7 @@ func (c *VPCCollector) Collect(ctx context.Context, region string) ([]Resource,
 				SubCategory2: "Endpoint",
 				Name:         helpers.GetTagValue(ep.Tags, "Name"),
 				Region:       region,
+				Tags:         helpers.TagsToMap(ep.Tags),
 				RawData: map[string]any{
 					"ID":             ep.VpcEndpointId,
 					"Type":           ep.VpcEndpointType,
@@ -377,6 +363,29 @@ func (c *VPCCollector) Collect(ctx context.Context, region string) ([]Resource,
 	return resources, nil
 }
 
+// formatSecurityGroupRules returns one line per IPv4 and IPv6 CIDR block of perms.
+func formatSecurityGroupRules(perms []types.IpPermission) []string {
+	var rules []string
+	for i := range perms {
+		perm := &perms[i]
+		var cidrs []string
+		for j := range perm.IpRanges {
+			cidrs = append(cidrs, helpers.StringValue(perm.IpRanges[j].CidrIp))
+		}
+		for j := range perm.Ipv6Ranges {
+			cidrs = append(cidrs, helpers.StringValue(perm.Ipv6Ranges[j].CidrIpv6))
+		}
+		for _, cidr := range cidrs {
+			rules = append(rules, fmt.Sprintf("Protocol: %s | FromPort: %d | ToPort: %d | CIDR: %s",
+				helpers.StringValue(perm.IpProtocol),
+				aws.ToInt32(perm.FromPort),
+				aws.ToInt32(perm.ToPort),
+				cidr))
+		}
+	}
+	return rules
+}
+
 // GetColumns returns the CSV columns for the collector.
 func (*VPCCollector) GetColumns() []Column {
 	return []Column{
@@ -416,3 +425,17 @@ func (*VPCCollector) Name() string {
 func (*VPCCollector) ShouldSort() bool {
 	return false
 }
+
+// RequiredActions returns the IAM actions called by Collect.
+func (*VPCCollector) RequiredActions() []string {
+	return []string{
+		"ec2:DescribeInternetGateways",
+		"ec2:DescribeNatGateways",
+		"ec2:DescribeNetworkAcls",
+		"ec2:DescribeRouteTables",
+		"ec2:DescribeSecurityGroups",
+		"ec2:DescribeSubnets",
+		"ec2:DescribeVpcEndpoints",
+		"ec2:DescribeVpcs",
+	}
+}
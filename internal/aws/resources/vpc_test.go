@@ -5,6 +5,7 @@
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -128,3 +129,41 @@ func TestVPCCollector_GetColumns(t *testing.T) {
 		})
 	}
 }
+
+func TestFormatSecurityGroupRules(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		perms []ec2types.IpPermission
+		want  []string
+	}{
+		{
+			name: "ipv4 and ipv6 ranges",
+			perms: []ec2types.IpPermission{{
+				IpProtocol: aws.String("tcp"),
+				FromPort:   aws.Int32(22),
+				ToPort:     aws.Int32(22),
+				IpRanges:   []ec2types.IpRange{{CidrIp: aws.String("10.0.0.0/8")}},
+				Ipv6Ranges: []ec2types.Ipv6Range{{CidrIpv6: aws.String("::/0")}},
+			}},
+			want: []string{
+				"Protocol: tcp | FromPort: 22 | ToPort: 22 | CIDR: 10.0.0.0/8",
+				"Protocol: tcp | FromPort: 22 | ToPort: 22 | CIDR: ::/0",
+			},
+		},
+		{
+			name:  "ipv6 only",
+			perms: []ec2types.IpPermission{{IpProtocol: aws.String("-1"), Ipv6Ranges: []ec2types.Ipv6Range{{CidrIpv6: aws.String("2001:db8::/32")}}}},
+			want:  []string{"Protocol: -1 | FromPort: 0 | ToPort: 0 | CIDR: 2001:db8::/32"},
+		},
+		{name: "no ranges", perms: []ec2types.IpPermission{{IpProtocol: aws.String("tcp")}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, formatSecurityGroupRules(tt.perms))
+		})
+	}
+}
@@ -13,11 +13,15 @@
 
 // WAFCollector collects WAFv2 WebACLs.
 // It uses dependency injection to manage WAFv2 and CloudFront clients.
-// WAF is a global service for CloudFront - only processes from us-east-1 to avoid duplicates.
+// WAF is a global service for CloudFront - CloudFront-scoped WebACLs are only processed
+// from the partition's global region (us-east-1 for aws) to avoid duplicates, using a
+// WAFv2 client of the partition's CloudFront region (cn-northwest-1 for aws-cn).
 type WAFCollector struct {
 	wafClient    map[string]*wafv2.Client
+	cfWAFClient  *wafv2.Client
 	cfClient     *cloudfront.Client
 	nameResolver *helpers.NameResolver //nolint:unused // Reserved for future resource name resolution
+	partition    string
 }
 
 // NewWAFCollector creates a new WAF collector with clients for the specified regions.
@@ -43,12 +47,29 @@ func NewWAFCollector(cfg *aws.Config, regions []string, nameResolver *helpers.Na
 	}
 
 	// CloudFront client for Global WAF associations
-	cfClient := cloudfront.NewFromConfig(*cfg)
+	partition := helpers.PartitionForRegion(cfg.Region)
+	cfClient := cloudfront.NewFromConfig(*cfg, func(o *cloudfront.Options) {
+		if cloudfrontRegion, ok := helpers.CloudFrontRegionForPartition(partition); ok {
+			o.Region = cloudfrontRegion
+		}
+	})
+
+	// WAFv2 client for CloudFront-scoped WebACLs, which are only served from the
+	// partition's CloudFront region. It may not be one of the collected regions
+	// (cn-northwest-1 in aws-cn), so it is created separately.
+	var cfWAFClient *wafv2.Client
+	if cloudfrontRegion, ok := helpers.CloudFrontRegionForPartition(partition); ok {
+		cfWAFClient = wafv2.NewFromConfig(*cfg, func(o *wafv2.Options) {
+			o.Region = cloudfrontRegion
+		})
+	}
 
 	return &WAFCollector{
 		wafClient:    wafClients,
+		cfWAFClient:  cfWAFClient,
 		cfClient:     cfClient,
 		nameResolver: nameResolver,
+		partition:    partition,
 	}, nil
 }
 
@@ -67,9 +88,9 @@ func (c *WAFCollector) Collect(ctx context.Context, region string) ([]Resource,
 		return nil, fmt.Errorf("failed to collect regional WAFs: %w", err)
 	}
 
-	// 2. CloudFront WAFs (Global) - only collect in us-east-1
-	if region == "us-east-1" {
-		if err := c.collectScope(ctx, svc, c.cfClient, "Global", types.ScopeCloudfront, &resources); err != nil {
+	// 2. CloudFront WAFs (Global) - only collect once, in the partition's global region
+	if c.cfWAFClient != nil && region == helpers.GlobalRegionForPartition(c.partition) {
+		if err := c.collectScope(ctx, c.cfWAFClient, c.cfClient, "Global", types.ScopeCloudfront, &resources); err != nil {
 			return nil, fmt.Errorf("failed to collect global WAFs: %w", err)
 		}
 	}
@@ -103,6 +124,17 @@ func (*WAFCollector) ShouldSort() bool {
 	return true
 }
 
+// RequiredActions returns the IAM actions called by Collect.
+func (*WAFCollector) RequiredActions() []string {
+	return []string{
+		"cloudfront:ListDistributionsByWebACLId",
+		"wafv2:GetLoggingConfiguration",
+		"wafv2:GetWebACL",
+		"wafv2:ListResourcesForWebACL",
+		"wafv2:ListWebACLs",
+	}
+}
+
 func (*WAFCollector) collectScope(ctx context.Context, svc *wafv2.Client, cfSvc *cloudfront.Client, regionDesc string, scope types.Scope, resources *[]Resource) error {
 	var nextMarker *string
 	for {
@@ -2,6 +2,10 @@
 
 import (
 	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
 	"testing"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -50,6 +54,95 @@ func TestNewWAFCollector(t *testing.T) {
 	}
 }
 
+func TestNewWAFCollector_CloudFrontScopeClient(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		cfgRegion  string
+		regions    []string
+		wantRegion string
+	}{
+		{name: "aws uses us-east-1", cfgRegion: "us-east-1", regions: []string{"us-east-1"}, wantRegion: "us-east-1"},
+		{name: "aws-cn uses cn-northwest-1 when not collected", cfgRegion: "cn-north-1", regions: []string{"cn-north-1"}, wantRegion: "cn-northwest-1"},
+		{name: "aws-us-gov has no CloudFront scope", cfgRegion: "us-gov-west-1", regions: []string{"us-gov-west-1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			collector, err := NewWAFCollector(&aws.Config{Region: tt.cfgRegion}, tt.regions, nil)
+			require.NoError(t, err)
+			assert.Len(t, collector.wafClient, len(tt.regions))
+			if tt.wantRegion == "" {
+				assert.Nil(t, collector.cfWAFClient)
+				return
+			}
+			require.NotNil(t, collector.cfWAFClient)
+			assert.Equal(t, tt.wantRegion, collector.cfWAFClient.Options().Region)
+		})
+	}
+}
+
+func TestWAFCollector_Collect_CloudFrontScope(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		partition  string
+		region     string
+		wantScopes []string
+	}{
+		{name: "aws-cn collects the CloudFront scope from cn-north-1", partition: helpers.PartitionAWSCN, region: "cn-north-1", wantScopes: []string{"REGIONAL", "CLOUDFRONT"}},
+		{name: "aws-cn skips the CloudFront scope in cn-northwest-1", partition: helpers.PartitionAWSCN, region: "cn-northwest-1", wantScopes: []string{"REGIONAL"}},
+		{name: "aws collects the CloudFront scope from us-east-1", partition: helpers.PartitionAWS, region: "us-east-1", wantScopes: []string{"REGIONAL", "CLOUDFRONT"}},
+		{name: "aws skips the CloudFront scope in other regions", partition: helpers.PartitionAWS, region: "eu-west-1", wantScopes: []string{"REGIONAL"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				mu     sync.Mutex
+				scopes []string
+			)
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var in struct{ Scope string }
+				_ = json.NewDecoder(r.Body).Decode(&in)
+				mu.Lock()
+				scopes = append(scopes, in.Scope)
+				mu.Unlock()
+				w.Header().Set("Content-Type", "application/x-amz-json-1.1")
+				_, _ = w.Write([]byte(`{"WebACLs":[]}`))
+			}))
+			defer server.Close()
+
+			cfg := aws.Config{
+				Region:      tt.region,
+				Credentials: aws.AnonymousCredentials{},
+				HTTPClient:  server.Client(),
+				EndpointResolverWithOptions: aws.EndpointResolverWithOptionsFunc(func(_, _ string, _ ...any) (aws.Endpoint, error) {
+					return aws.Endpoint{URL: server.URL, HostnameImmutable: true}, nil
+				}),
+				RetryMaxAttempts: 1,
+			}
+			collector := &WAFCollector{
+				wafClient:   map[string]*wafv2.Client{tt.region: wafv2.NewFromConfig(cfg)},
+				cfWAFClient: wafv2.NewFromConfig(cfg),
+				cfClient:    cloudfront.NewFromConfig(cfg),
+				partition:   tt.partition,
+			}
+
+			got, err := collector.Collect(context.Background(), tt.region)
+			require.NoError(t, err)
+			assert.Empty(t, got)
+			assert.Equal(t, tt.wantScopes, scopes)
+		})
+	}
+}
+
 func TestWAFCollector_Basic(t *testing.T) {
 	t.Parallel()
 
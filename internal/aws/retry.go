@@ -0,0 +1,211 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"math"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
+	"github.com/aws/smithy-go/middleware"
+	"golang.org/x/time/rate"
+)
+
+// Retry modes accepted by RetryOptions.Mode.
+const (
+	RetryModeAdaptive = "adaptive"
+	RetryModeStandard = "standard"
+)
+
+// attemptMiddlewareID is the ID of the per-attempt middleware that applies the
+// rate limit, counts the attempts and records throttling errors.
+const attemptMiddlewareID = "arc.Attempt"
+
+// ErrInvalidRetryMode is returned for retry modes other than standard and adaptive.
+var ErrInvalidRetryMode = errors.New("invalid retry mode")
+
+// RetryOptions configures how AWS API calls are retried and rate limited.
+// Zero values keep the SDK defaults.
+type RetryOptions struct {
+	// Limiter throttles requests client-side per service. nil disables rate limiting.
+	Limiter *ServiceRateLimiter
+	// Mode is the SDK retry mode: standard (default) or adaptive.
+	Mode string
+	// Stats receives the number of throttling errors per service. nil disables counting.
+	Stats *APIStats
+	// MaxAttempts is the maximum number of attempts per API call, including the first one.
+	MaxAttempts int
+	// MaxBackoff is the maximum delay between two attempts.
+	MaxBackoff time.Duration
+}
+
+// Validate checks that the retry mode is supported.
+func (o *RetryOptions) Validate() error {
+	switch o.Mode {
+	case "", RetryModeStandard, RetryModeAdaptive:
+		return nil
+	default:
+		return fmt.Errorf("%w: %q (supported: standard, adaptive)", ErrInvalidRetryMode, o.Mode)
+	}
+}
+
+// newRetryer returns the retryer configured by the options.
+func (o *RetryOptions) newRetryer() aws.Retryer {
+	var retryer aws.Retryer
+	if o.Mode == RetryModeAdaptive {
+		retryer = retry.NewAdaptiveMode()
+	} else {
+		retryer = retry.NewStandard()
+	}
+	if o.MaxAttempts > 0 {
+		retryer = retry.AddWithMaxAttempts(retryer, o.MaxAttempts)
+	}
+	if o.MaxBackoff > 0 {
+		retryer = retry.AddWithMaxBackoffDelay(retryer, o.MaxBackoff)
+	}
+	return retryer
+}
+
+// apiOption adds the per-attempt middleware right after the SDK retry middleware,
+// so that every attempt, including retries, waits for the rate limiter, is
+// counted by the CallCounter of its context and throttling errors are counted
+// once per attempt.
+func (o *RetryOptions) apiOption(stack *middleware.Stack) error {
+	if o.Limiter == nil && o.Stats == nil {
+		return nil
+	}
+	mw := middleware.FinalizeMiddlewareFunc(attemptMiddlewareID, func(ctx context.Context, in middleware.FinalizeInput, next middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+		service := awsmiddleware.GetServiceID(ctx)
+		if counter, ok := ctx.Value(callCounterKey{}).(*CallCounter); ok {
+			counter.n.Add(1)
+		}
+		if o.Limiter != nil {
+			if err := o.Limiter.Wait(ctx, service); err != nil {
+				return middleware.FinalizeOutput{}, middleware.Metadata{}, err
+			}
+		}
+		out, metadata, err := next.HandleFinalize(ctx, in)
+		if err != nil && o.Stats != nil && IsThrottleError(err) {
+			o.Stats.AddThrottle(service)
+		}
+		return out, metadata, err
+	})
+	if _, ok := stack.Finalize.Get((&retry.Attempt{}).ID()); ok {
+		if err := stack.Finalize.Insert(mw, (&retry.Attempt{}).ID(), middleware.After); err != nil {
+			return fmt.Errorf("failed to add attempt middleware: %w", err)
+		}
+		return nil
+	}
+	if err := stack.Finalize.Add(mw, middleware.After); err != nil {
+		return fmt.Errorf("failed to add attempt middleware: %w", err)
+	}
+	return nil
+}
+
+// IsThrottleError reports whether err is a throttling error as classified by the SDK
+// (ThrottlingException, TooManyRequestsException, RequestLimitExceeded, ...).
+func IsThrottleError(err error) bool {
+	return retry.IsErrorThrottles(retry.DefaultThrottles).IsErrorThrottle(err) == aws.TrueTernary
+}
+
+// ServiceRateLimiter limits the request rate to each AWS service separately.
+// A single limiter is shared by all collectors so that concurrent collectors
+// calling the same service share its budget.
+type ServiceRateLimiter struct {
+	limiters map[string]*rate.Limiter
+	limit    rate.Limit
+	burst    int
+	mu       sync.Mutex
+}
+
+// NewServiceRateLimiter returns a limiter allowing requestsPerSecond requests per
+// service, or nil when requestsPerSecond is not positive (no rate limiting).
+func NewServiceRateLimiter(requestsPerSecond float64) *ServiceRateLimiter {
+	if requestsPerSecond <= 0 {
+		return nil
+	}
+	return &ServiceRateLimiter{
+		limiters: make(map[string]*rate.Limiter),
+		limit:    rate.Limit(requestsPerSecond),
+		burst:    max(1, int(math.Ceil(requestsPerSecond))),
+	}
+}
+
+// Wait blocks until a request to service is allowed or ctx is done.
+func (l *ServiceRateLimiter) Wait(ctx context.Context, service string) error {
+	l.mu.Lock()
+	limiter, ok := l.limiters[service]
+	if !ok {
+		limiter = rate.NewLimiter(l.limit, l.burst)
+		l.limiters[service] = limiter
+	}
+	l.mu.Unlock()
+
+	if err := limiter.Wait(ctx); err != nil {
+		return fmt.Errorf("rate limit wait for %s: %w", service, err)
+	}
+	return nil
+}
+
+// CallCounter counts the AWS API call attempts, including retries, made with
+// the contexts returned by WithCallCounter. Attempts are only counted by
+// configurations with RetryOptions.Limiter or RetryOptions.Stats set.
+// It is safe for concurrent use.
+type CallCounter struct {
+	n atomic.Int64
+}
+
+// callCounterKey is the context key of the CallCounter of WithCallCounter.
+type callCounterKey struct{}
+
+// WithCallCounter returns a copy of ctx whose AWS API call attempts are counted by counter.
+func WithCallCounter(ctx context.Context, counter *CallCounter) context.Context {
+	return context.WithValue(ctx, callCounterKey{}, counter)
+}
+
+// Count returns the number of attempts counted so far.
+func (c *CallCounter) Count() int64 {
+	return c.n.Load()
+}
+
+// APIStats counts AWS API events per service. It is safe for concurrent use.
+type APIStats struct {
+	throttles map[string]int64
+	mu        sync.Mutex
+}
+
+// NewAPIStats returns empty API statistics.
+func NewAPIStats() *APIStats {
+	return &APIStats{throttles: make(map[string]int64)}
+}
+
+// AddThrottle records one throttling error returned by service.
+func (s *APIStats) AddThrottle(service string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.throttles[service]++
+}
+
+// Throttles returns a copy of the throttling error counts per service.
+func (s *APIStats) Throttles() map[string]int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return maps.Clone(s.throttles)
+}
+
+// TotalThrottles returns the number of throttling errors across all services.
+func (s *APIStats) TotalThrottles() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var total int64
+	for _, n := range s.throttles {
+		total += n
+	}
+	return total
+}
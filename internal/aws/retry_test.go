@@ -0,0 +1,151 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/smithy-go"
+	"github.com/aws/smithy-go/middleware"
+)
+
+func TestRetryOptions_Validate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		mode    string
+		wantErr bool
+	}{
+		{mode: ""},
+		{mode: RetryModeStandard},
+		{mode: RetryModeAdaptive},
+		{mode: "legacy", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.mode, func(t *testing.T) {
+			t.Parallel()
+			err := (&RetryOptions{Mode: tt.mode}).Validate()
+			if tt.wantErr != errors.Is(err, ErrInvalidRetryMode) {
+				t.Fatalf("Validate(%q) error = %v, wantErr %v", tt.mode, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRetryOptions_NewRetryer(t *testing.T) {
+	t.Parallel()
+
+	retryer := (&RetryOptions{MaxAttempts: 10, MaxBackoff: time.Second}).newRetryer()
+	if got := retryer.MaxAttempts(); got != 10 {
+		t.Fatalf("MaxAttempts() = %d, want 10", got)
+	}
+	delay, err := retryer.RetryDelay(9, &smithy.GenericAPIError{Code: "ThrottlingException"})
+	if err != nil {
+		t.Fatalf("RetryDelay() unexpected error = %v", err)
+	}
+	if delay > time.Second {
+		t.Fatalf("RetryDelay() = %v, want <= 1s", delay)
+	}
+}
+
+func TestIsThrottleError(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "throttling exception", err: &smithy.GenericAPIError{Code: "ThrottlingException"}, want: true},
+		{name: "request limit exceeded", err: &smithy.GenericAPIError{Code: "RequestLimitExceeded"}, want: true},
+		{name: "access denied", err: &smithy.GenericAPIError{Code: "AccessDenied"}, want: false},
+		{name: "plain error", err: errors.New("boom"), want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := IsThrottleError(tt.err); got != tt.want {
+				t.Fatalf("IsThrottleError(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetryOptions_APIOptionCountsAttempts(t *testing.T) {
+	t.Parallel()
+
+	stats := NewAPIStats()
+	opts := &RetryOptions{Stats: stats, Limiter: NewServiceRateLimiter(1000)}
+	stack := middleware.NewStack("test", func() any { return nil })
+	if err := opts.apiOption(stack); err != nil {
+		t.Fatalf("apiOption() unexpected error = %v", err)
+	}
+	if _, ok := stack.Finalize.Get(attemptMiddlewareID); !ok {
+		t.Fatal("apiOption() did not add the attempt middleware")
+	}
+
+	throttle := middleware.HandlerFunc(func(context.Context, any) (any, middleware.Metadata, error) {
+		return nil, middleware.Metadata{}, &smithy.GenericAPIError{Code: "ThrottlingException"}
+	})
+	handler := middleware.DecorateHandler(throttle, stack)
+	counter := &CallCounter{}
+	ctx := WithCallCounter(awsmiddleware.SetServiceID(context.Background(), "S3"), counter)
+	for range 2 {
+		if _, _, err := handler.Handle(ctx, struct{}{}); err == nil {
+			t.Fatal("Handle() error = nil, want throttling error")
+		}
+	}
+	if _, _, err := handler.Handle(awsmiddleware.SetServiceID(context.Background(), "S3"), struct{}{}); err == nil {
+		t.Fatal("Handle() error = nil, want throttling error")
+	}
+
+	if got := counter.Count(); got != 2 {
+		t.Fatalf("CallCounter.Count() = %d, want 2", got)
+	}
+
+	if got := stats.Throttles()["S3"]; got != 3 {
+		t.Fatalf("Throttles()[S3] = %d, want 3", got)
+	}
+	if got := stats.TotalThrottles(); got != 3 {
+		t.Fatalf("TotalThrottles() = %d, want 3", got)
+	}
+}
+
+func TestRetryOptions_APIOptionNoop(t *testing.T) {
+	t.Parallel()
+
+	stack := middleware.NewStack("test", func() any { return nil })
+	if err := (&RetryOptions{}).apiOption(stack); err != nil {
+		t.Fatalf("apiOption() unexpected error = %v", err)
+	}
+	if _, ok := stack.Finalize.Get(attemptMiddlewareID); ok {
+		t.Fatal("apiOption() added a middleware without limiter or stats")
+	}
+}
+
+func TestServiceRateLimiter(t *testing.T) {
+	t.Parallel()
+
+	if NewServiceRateLimiter(0) != nil {
+		t.Fatal("NewServiceRateLimiter(0) != nil, want nil (disabled)")
+	}
+
+	limiter := NewServiceRateLimiter(1)
+	ctx := context.Background()
+	if err := limiter.Wait(ctx, "IAM"); err != nil {
+		t.Fatalf("Wait() first call unexpected error = %v", err)
+	}
+	if err := limiter.Wait(ctx, "S3"); err != nil {
+		t.Fatalf("Wait() other service unexpected error = %v", err)
+	}
+
+	shortCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	if err := limiter.Wait(shortCtx, "IAM"); err == nil {
+		t.Fatal("Wait() error = nil, want error when the IAM budget is exhausted")
+	}
+}
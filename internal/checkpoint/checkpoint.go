@@ -0,0 +1,154 @@
+// Package checkpoint persists the resources collected for each (category, region)
+// pair of an account, so that an interrupted collection can be resumed without
+// calling the AWS APIs again for the pairs that already completed.
+package checkpoint
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/y-miyazaki/arc/internal/aws/resources"
+	"github.com/y-miyazaki/arc/internal/findings"
+)
+
+// DirName is the name of the checkpoint directory, written next to the resources directory.
+const DirName = "checkpoints"
+
+// File and directory permissions of the checkpoints.
+const (
+	dirPerm  = 0o750
+	filePerm = 0o600
+)
+
+// ErrVersionMismatch is returned by Load for checkpoints written by another version of arc,
+// whose resources may not have the columns of this version.
+var ErrVersionMismatch = errors.New("checkpoint written by another version")
+
+// Checkpoint is the content of a checkpoint file: the resources collected for
+// one category in one region.
+type Checkpoint struct {
+	Category    string               `json:"category"`
+	Region      string               `json:"region"`
+	Version     string               `json:"version"`
+	CollectedAt time.Time            `json:"collectedAt"`
+	Resources   []resources.Resource `json:"resources"`
+	Findings    *Findings            `json:"findings,omitempty"`
+}
+
+// Findings are the findings of a (category, region) pair, evaluated before its
+// resources were redacted and checkpointed, since the redacted values would not
+// give the same findings once resumed.
+type Findings struct {
+	// RuleIDs are the IDs of the evaluated rules.
+	RuleIDs []string `json:"ruleIds"`
+	// List holds the findings, with redacted evidence.
+	List []findings.Finding `json:"list"`
+}
+
+// Store reads and writes the checkpoints of an account in dir, one file per
+// (category, region) pair: {dir}/{category}/{region}.json.
+type Store struct {
+	dir     string
+	version string
+}
+
+// NewStore returns the store of the checkpoints in dir. Checkpoints are written
+// with version and only loaded back by the same version.
+func NewStore(dir, version string) *Store {
+	return &Store{dir: dir, version: version}
+}
+
+// Dir returns the checkpoint directory.
+func (s *Store) Dir() string {
+	return s.dir
+}
+
+// Save writes the resources collected for category in region and, when rules
+// were evaluated, their findings. The file is written to a temporary file first
+// and renamed, so that an interrupted write never leaves a truncated checkpoint
+// behind.
+func (s *Store) Save(category, region string, res []resources.Resource, found *Findings) (err error) {
+	path := s.path(category, region)
+	if mkdirErr := os.MkdirAll(filepath.Dir(path), dirPerm); mkdirErr != nil {
+		return fmt.Errorf("failed to create checkpoint directory: %w", mkdirErr)
+	}
+	if res == nil {
+		res = []resources.Resource{}
+	}
+	b, err := json.Marshal(Checkpoint{
+		Category:    category,
+		Region:      region,
+		Version:     s.version,
+		CollectedAt: time.Now().UTC(),
+		Resources:   res,
+		Findings:    found,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode checkpoint: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create checkpoint: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			_ = os.Remove(tmp.Name())
+		}
+	}()
+	if _, writeErr := tmp.Write(b); writeErr != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to write checkpoint: %w", writeErr)
+	}
+	if closeErr := tmp.Close(); closeErr != nil {
+		return fmt.Errorf("failed to close checkpoint: %w", closeErr)
+	}
+	if chmodErr := os.Chmod(tmp.Name(), filePerm); chmodErr != nil {
+		return fmt.Errorf("failed to write checkpoint: %w", chmodErr)
+	}
+	if renameErr := os.Rename(tmp.Name(), path); renameErr != nil {
+		return fmt.Errorf("failed to write checkpoint: %w", renameErr)
+	}
+	return nil
+}
+
+// Load returns the checkpoint of category in region. ok is false when there is
+// no checkpoint for the pair.
+func (s *Store) Load(category, region string) (cp *Checkpoint, ok bool, err error) {
+	b, err := os.ReadFile(s.path(category, region))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to read checkpoint: %w", err)
+	}
+	// Numbers of the typed raw data are kept as written instead of becoming float64.
+	decoder := json.NewDecoder(bytes.NewReader(b))
+	decoder.UseNumber()
+	cp = &Checkpoint{}
+	if decodeErr := decoder.Decode(cp); decodeErr != nil {
+		return nil, false, fmt.Errorf("failed to parse checkpoint %s/%s: %w", category, region, decodeErr)
+	}
+	if cp.Version != s.version {
+		return nil, false, fmt.Errorf("%w: %s/%s (%s)", ErrVersionMismatch, category, region, cp.Version)
+	}
+	return cp, true, nil
+}
+
+// Clear removes every checkpoint of the store.
+func (s *Store) Clear() error {
+	if err := os.RemoveAll(s.dir); err != nil {
+		return fmt.Errorf("failed to remove checkpoints: %w", err)
+	}
+	return nil
+}
+
+// path returns the checkpoint file of category in region.
+func (s *Store) path(category, region string) string {
+	return filepath.Join(s.dir, filepath.Base(category), filepath.Base(region)+".json")
+}
@@ -0,0 +1,133 @@
+package checkpoint
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/y-miyazaki/arc/internal/aws/resources"
+	"github.com/y-miyazaki/arc/internal/findings"
+)
+
+func testResources() []resources.Resource {
+	return []resources.Resource{
+		{
+			ARN:          "arn:aws:sqs:us-east-1:123456789012:jobs",
+			Category:     "sqs",
+			Name:         "jobs",
+			Region:       "us-east-1",
+			SubCategory1: "Queue",
+			RawData:      map[string]any{"VisibilityTimeout": "30"},
+			Tags:         map[string]string{"Team": "payments"},
+			TypedData:    map[string]any{"VisibilityTimeout": 30, "Encrypted": true},
+		},
+	}
+}
+
+func TestStore_SaveLoad(t *testing.T) {
+	t.Parallel()
+
+	s := NewStore(filepath.Join(t.TempDir(), DirName), "v1.0.0")
+	found := &Findings{
+		RuleIDs: []string{"sqs-unencrypted"},
+		List:    []findings.Finding{{Category: "sqs", Name: "jobs", Region: "us-east-1", RuleID: "sqs-unencrypted", Evidence: "Encrypted=false"}},
+	}
+	require.NoError(t, s.Save("sqs", "us-east-1", testResources(), found))
+	require.NoError(t, s.Save("sqs", "eu-west-1", nil, nil))
+
+	cp, ok, err := s.Load("sqs", "us-east-1")
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, "sqs", cp.Category)
+	assert.Equal(t, "us-east-1", cp.Region)
+	assert.Equal(t, "v1.0.0", cp.Version)
+	assert.False(t, cp.CollectedAt.IsZero())
+	require.Len(t, cp.Resources, 1)
+	got := cp.Resources[0]
+	want := testResources()[0]
+	assert.Equal(t, want.ARN, got.ARN)
+	assert.Equal(t, want.RawData, got.RawData)
+	assert.Equal(t, want.Tags, got.Tags)
+	assert.Equal(t, map[string]any{"VisibilityTimeout": json.Number("30"), "Encrypted": true}, got.TypedData)
+	assert.Equal(t, found, cp.Findings)
+
+	cp, ok, err = s.Load("sqs", "eu-west-1")
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Empty(t, cp.Resources)
+	assert.Nil(t, cp.Findings)
+
+	entries, err := os.ReadDir(filepath.Join(s.Dir(), "sqs"))
+	require.NoError(t, err)
+	assert.Len(t, entries, 2, "no temporary files are left behind")
+}
+
+func TestStore_Load(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		setup     func(t *testing.T, s *Store)
+		wantErr   bool
+		wantErrIs error
+	}{
+		{
+			name:  "missing checkpoint",
+			setup: func(*testing.T, *Store) {},
+		},
+		{
+			name: "other version",
+			setup: func(t *testing.T, s *Store) {
+				t.Helper()
+				require.NoError(t, NewStore(s.Dir(), "v0.9.0").Save("sqs", "us-east-1", testResources(), nil))
+			},
+			wantErr:   true,
+			wantErrIs: ErrVersionMismatch,
+		},
+		{
+			name: "corrupt checkpoint",
+			setup: func(t *testing.T, s *Store) {
+				t.Helper()
+				require.NoError(t, os.MkdirAll(filepath.Join(s.Dir(), "sqs"), 0o750))
+				require.NoError(t, os.WriteFile(filepath.Join(s.Dir(), "sqs", "us-east-1.json"), []byte(`{"category":`), 0o600))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			s := NewStore(t.TempDir(), "v1.0.0")
+			tt.setup(t, s)
+			cp, ok, err := s.Load("sqs", "us-east-1")
+			if tt.wantErr {
+				require.Error(t, err)
+				if tt.wantErrIs != nil {
+					require.ErrorIs(t, err, tt.wantErrIs)
+				}
+			} else {
+				require.NoError(t, err)
+			}
+			assert.False(t, ok)
+			assert.Nil(t, cp)
+		})
+	}
+}
+
+func TestStore_Clear(t *testing.T) {
+	t.Parallel()
+
+	s := NewStore(filepath.Join(t.TempDir(), DirName), "v1.0.0")
+	require.NoError(t, s.Clear(), "clearing a missing directory is not an error")
+	require.NoError(t, s.Save("sqs", "us-east-1", testResources(), nil))
+	require.NoError(t, s.Clear())
+
+	_, ok, err := s.Load("sqs", "us-east-1")
+	require.NoError(t, err)
+	assert.False(t, ok)
+	assert.NoDirExists(t, s.Dir())
+}
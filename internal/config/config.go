@@ -0,0 +1,178 @@
+// Package config loads arc YAML configuration files.
+//
+// A configuration file sets command-line options by their long flag name and
+// may define named presets that override those options:
+//
+//	region: [ap-northeast-1, us-east-1]
+//	format: [csv, json]
+//	timeout: 45m
+//	presets:
+//	  security:
+//	    categories: [iam_policy, iam_role, kms]
+//
+// List values are joined with commas, the separator used by list flags.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// KeyCategories is the option listing the categories to collect.
+const KeyCategories = "categories"
+
+// Sentinel errors for configuration files (alphabetical order).
+var (
+	ErrInvalidValue    = errors.New("invalid value")
+	ErrUnknownCategory = errors.New("unknown category")
+	ErrUnknownOption   = errors.New("unknown option")
+	ErrUnknownPreset   = errors.New("unknown preset")
+)
+
+// File is the content of a configuration file.
+type File struct {
+	// Options are the base options of every run.
+	Options Values `yaml:",inline"`
+	// Presets are named option sets applied on top of Options with --preset.
+	Presets map[string]Values `yaml:"presets"`
+}
+
+// Values maps option names to YAML values (strings, numbers, booleans or lists of them).
+type Values map[string]any
+
+// Load reads and parses the configuration file at path.
+func Load(path string) (*File, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // G304 - path is provided by the user on purpose
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+	file := &File{}
+	if err := yaml.Unmarshal(data, file); err != nil {
+		return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+	if file.Options == nil {
+		file.Options = Values{}
+	}
+	return file, nil
+}
+
+// Resolve returns the base options merged with the options of preset.
+// An empty preset returns the base options only.
+func (f *File) Resolve(preset string) (Values, error) {
+	merged := maps.Clone(f.Options)
+	if merged == nil {
+		merged = Values{}
+	}
+	if preset == "" {
+		return merged, nil
+	}
+	values, ok := f.Presets[preset]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q (defined: %s)", ErrUnknownPreset, preset, strings.Join(f.PresetNames(), ", "))
+	}
+	maps.Copy(merged, values)
+	return merged, nil
+}
+
+// PresetNames returns the sorted names of the presets.
+func (f *File) PresetNames() []string {
+	return slices.Sorted(maps.Keys(f.Presets))
+}
+
+// Validate checks the base options and every preset: option names must be in
+// options, values must be scalars or lists of scalars and categories must be
+// in categories. All problems are returned joined.
+func (f *File) Validate(options, categories []string) error {
+	var errs []error
+	for _, err := range f.Options.problems(options, categories) {
+		errs = append(errs, fmt.Errorf("config: %w", err))
+	}
+	for _, name := range f.PresetNames() {
+		for _, err := range f.Presets[name].problems(options, categories) {
+			errs = append(errs, fmt.Errorf("preset %s: %w", name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Keys returns the sorted option names.
+func (v Values) Keys() []string {
+	return slices.Sorted(maps.Keys(v))
+}
+
+// String returns the value of key formatted as a flag value.
+func (v Values) String(key string) (string, error) {
+	s, err := FormatValue(v[key])
+	if err != nil {
+		return "", fmt.Errorf("option %s: %w", key, err)
+	}
+	return s, nil
+}
+
+// Validate checks the options like File.Validate.
+func (v Values) Validate(options, categories []string) error {
+	return errors.Join(v.problems(options, categories)...)
+}
+
+func (v Values) problems(options, categories []string) []error {
+	var errs []error
+	for _, key := range v.Keys() {
+		if !slices.Contains(options, key) {
+			errs = append(errs, fmt.Errorf("%w: %s", ErrUnknownOption, key))
+			continue
+		}
+		value, err := v.String(key)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if key != KeyCategories {
+			continue
+		}
+		for category := range strings.SplitSeq(value, ",") {
+			category = strings.TrimSpace(category)
+			if category != "" && !slices.Contains(categories, category) {
+				errs = append(errs, fmt.Errorf("%w: %s", ErrUnknownCategory, category))
+			}
+		}
+	}
+	return errs
+}
+
+// FormatValue formats a YAML value as a flag value. Lists are joined with commas.
+func FormatValue(value any) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case int:
+		return strconv.Itoa(v), nil
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64), nil
+	case []any:
+		items := make([]string, 0, len(v))
+		for _, item := range v {
+			if _, nested := item.([]any); nested {
+				return "", fmt.Errorf("%w: nested lists are not supported", ErrInvalidValue)
+			}
+			s, err := FormatValue(item)
+			if err != nil {
+				return "", err
+			}
+			items = append(items, s)
+		}
+		return strings.Join(items, ","), nil
+	case nil:
+		return "", fmt.Errorf("%w: empty value", ErrInvalidValue)
+	default:
+		return "", fmt.Errorf("%w: %T is not a string, number, boolean or list", ErrInvalidValue, value)
+	}
+}
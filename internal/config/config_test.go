@@ -0,0 +1,169 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testConfig = `
+region: [ap-northeast-1, us-east-1]
+format: csv,json
+concurrency: 10
+html: true
+timeout: 45m
+presets:
+  security:
+    categories: [iam_policy, iam_role, kms]
+    concurrency: 3
+  network:
+    categories: vpc,elb
+`
+
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "arc.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+
+	file, err := Load(writeConfig(t, testConfig))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"concurrency", "format", "html", "region", "timeout"}, file.Options.Keys())
+	assert.Equal(t, []string{"network", "security"}, file.PresetNames())
+
+	_, err = Load(writeConfig(t, "region: [unterminated"))
+	require.Error(t, err)
+
+	_, err = Load(filepath.Join(t.TempDir(), "missing.yaml"))
+	require.ErrorIs(t, err, os.ErrNotExist)
+
+	empty, err := Load(writeConfig(t, ""))
+	require.NoError(t, err)
+	assert.Empty(t, empty.Options)
+}
+
+func TestFile_Resolve(t *testing.T) {
+	t.Parallel()
+
+	file, err := Load(writeConfig(t, testConfig))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		preset  string
+		want    map[string]string
+		wantErr error
+	}{
+		{
+			name: "base options",
+			want: map[string]string{"concurrency": "10", "format": "csv,json", "html": "true", "region": "ap-northeast-1,us-east-1", "timeout": "45m"},
+		},
+		{
+			name:   "preset overrides base options",
+			preset: "security",
+			want:   map[string]string{"categories": "iam_policy,iam_role,kms", "concurrency": "3", "format": "csv,json", "html": "true", "region": "ap-northeast-1,us-east-1", "timeout": "45m"},
+		},
+		{name: "unknown preset", preset: "compute", wantErr: ErrUnknownPreset},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			values, err := file.Resolve(tt.preset)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			got := make(map[string]string, len(values))
+			for _, key := range values.Keys() {
+				got[key], err = values.String(key)
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	// Resolving a preset must not modify the base options.
+	assert.NotContains(t, file.Options, "categories")
+}
+
+func TestFile_Validate(t *testing.T) {
+	t.Parallel()
+
+	options := []string{"categories", "concurrency", "format", "html", "region", "timeout"}
+	categories := []string{"elb", "iam_policy", "iam_role", "kms", "vpc"}
+
+	tests := []struct {
+		name     string
+		content  string
+		wantErrs []error
+	}{
+		{name: "valid", content: testConfig},
+		{name: "unknown option", content: "regions: us-east-1\n", wantErrs: []error{ErrUnknownOption}},
+		{name: "unknown category in preset", content: "presets:\n  network:\n    categories: [vpc, vpn]\n", wantErrs: []error{ErrUnknownCategory}},
+		{name: "nested value", content: "format:\n  csv: true\n", wantErrs: []error{ErrInvalidValue}},
+		{name: "empty value", content: "format:\n", wantErrs: []error{ErrInvalidValue}},
+		{
+			name:     "all problems are reported",
+			content:  "regions: us-east-1\ncategories: ec2\n",
+			wantErrs: []error{ErrUnknownOption, ErrUnknownCategory},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			file, err := Load(writeConfig(t, tt.content))
+			require.NoError(t, err)
+			err = file.Validate(options, categories)
+			if len(tt.wantErrs) == 0 {
+				require.NoError(t, err)
+				return
+			}
+			for _, want := range tt.wantErrs {
+				require.ErrorIs(t, err, want)
+			}
+		})
+	}
+}
+
+func TestFormatValue(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		value   any
+		want    string
+		wantErr bool
+	}{
+		{name: "string", value: "us-east-1", want: "us-east-1"},
+		{name: "bool", value: false, want: "false"},
+		{name: "int", value: 5, want: "5"},
+		{name: "float", value: 2.5, want: "2.5"},
+		{name: "list", value: []any{"csv", "json", 3}, want: "csv,json,3"},
+		{name: "nested list", value: []any{[]any{"csv"}}, wantErr: true},
+		{name: "map", value: map[string]any{"a": 1}, wantErr: true},
+		{name: "nil", value: nil, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := FormatValue(tt.value)
+			if tt.wantErr {
+				require.ErrorIs(t, err, ErrInvalidValue)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,201 @@
+// Package diff compares two arc collection runs and reports added, removed and
+// modified resources per category.
+package diff
+
+import (
+	"slices"
+	"strconv"
+)
+
+// Snapshot is a loaded collection run keyed by category.
+type Snapshot map[string]*Table
+
+// Table holds the records of one category in output order.
+type Table struct {
+	keyCounts map[string]int
+	Columns   []string
+	Records   []Record
+}
+
+// Record is one exported resource row with its values by column header.
+type Record struct {
+	Values map[string]string
+	Key    string
+}
+
+// Result is the outcome of comparing two snapshots.
+type Result struct {
+	Before     string         `json:"before"`
+	After      string         `json:"after"`
+	Categories []CategoryDiff `json:"categories"`
+}
+
+// CategoryDiff lists the changes of one category.
+type CategoryDiff struct {
+	Category string             `json:"category"`
+	Added    []ResourceRef      `json:"added"`
+	Removed  []ResourceRef      `json:"removed"`
+	Modified []ModifiedResource `json:"modified"`
+}
+
+// ResourceRef identifies a resource in a diff.
+type ResourceRef struct {
+	Key    string `json:"key"`
+	Name   string `json:"name"`
+	Region string `json:"region"`
+}
+
+// ModifiedResource is a resource present in both snapshots with different column values.
+type ModifiedResource struct {
+	ResourceRef
+
+	Changes []Change `json:"changes"`
+}
+
+// Change is the before and after value of one column.
+type Change struct {
+	Column string `json:"column"`
+	Before string `json:"before"`
+	After  string `json:"after"`
+}
+
+// HasChanges reports whether any category has added, removed or modified resources.
+func (r *Result) HasChanges() bool {
+	return len(r.Categories) > 0
+}
+
+// add appends a record to the table of category, keying it with recordKey.
+// Records sharing a key in the same category get an occurrence suffix (#2, #3, ...).
+func (s Snapshot) add(category string, columns []string, values map[string]string) {
+	table, ok := s[category]
+	if !ok {
+		table = &Table{keyCounts: make(map[string]int), Columns: slices.Clone(columns)}
+		s[category] = table
+	}
+	for _, col := range columns {
+		if !slices.Contains(table.Columns, col) {
+			table.Columns = append(table.Columns, col)
+		}
+	}
+
+	key := recordKey(category, values)
+	table.keyCounts[key]++
+	if n := table.keyCounts[key]; n > 1 {
+		key += "#" + strconv.Itoa(n)
+	}
+	table.Records = append(table.Records, Record{Key: key, Values: values})
+}
+
+// Compare returns the differences between before and after. Resources are
+// matched by key within each category and categories are sorted by name.
+// beforeLabel and afterLabel describe the compared sources in reports.
+func Compare(before, after Snapshot, beforeLabel, afterLabel string) *Result {
+	categories := make([]string, 0, len(before)+len(after))
+	for category := range before {
+		categories = append(categories, category)
+	}
+	for category := range after {
+		if _, ok := before[category]; !ok {
+			categories = append(categories, category)
+		}
+	}
+	slices.Sort(categories)
+
+	result := &Result{Before: beforeLabel, After: afterLabel, Categories: []CategoryDiff{}}
+	for _, category := range categories {
+		diff := compareTables(category, before[category], after[category])
+		if len(diff.Added)+len(diff.Removed)+len(diff.Modified) > 0 {
+			result.Categories = append(result.Categories, diff)
+		}
+	}
+	return result
+}
+
+// compareTables compares the records of one category. Either table may be nil.
+func compareTables(category string, before, after *Table) CategoryDiff {
+	diff := CategoryDiff{
+		Category: category,
+		Added:    []ResourceRef{},
+		Removed:  []ResourceRef{},
+		Modified: []ModifiedResource{},
+	}
+	beforeRecords := indexRecords(before)
+	afterRecords := indexRecords(after)
+	columns := mergeColumns(after, before)
+
+	if after != nil {
+		for i := range after.Records {
+			rec := &after.Records[i]
+			old, ok := beforeRecords[rec.Key]
+			if !ok {
+				diff.Added = append(diff.Added, refOf(rec))
+				continue
+			}
+			var changes []Change
+			for _, col := range columns {
+				if old.Values[col] != rec.Values[col] {
+					changes = append(changes, Change{Column: col, Before: old.Values[col], After: rec.Values[col]})
+				}
+			}
+			if len(changes) > 0 {
+				diff.Modified = append(diff.Modified, ModifiedResource{ResourceRef: refOf(rec), Changes: changes})
+			}
+		}
+	}
+	if before != nil {
+		for i := range before.Records {
+			rec := &before.Records[i]
+			if _, ok := afterRecords[rec.Key]; !ok {
+				diff.Removed = append(diff.Removed, refOf(rec))
+			}
+		}
+	}
+	return diff
+}
+
+// recordKey returns the ARN of a record, falling back to Category/Region/Name
+// for rows without an ARN such as many SubCategory rows.
+func recordKey(category string, values map[string]string) string {
+	if arn := values[ColumnARN]; arn != "" && arn != "N/A" {
+		return arn
+	}
+	if c := values[ColumnCategory]; c != "" {
+		category = c
+	}
+	return category + "/" + values[ColumnRegion] + "/" + values[ColumnName]
+}
+
+func indexRecords(table *Table) map[string]*Record {
+	if table == nil {
+		return map[string]*Record{}
+	}
+	index := make(map[string]*Record, len(table.Records))
+	for i := range table.Records {
+		index[table.Records[i].Key] = &table.Records[i]
+	}
+	return index
+}
+
+// mergeColumns returns the columns of primary followed by the columns only found in secondary.
+func mergeColumns(primary, secondary *Table) []string {
+	var columns []string
+	for _, table := range []*Table{primary, secondary} {
+		if table == nil {
+			continue
+		}
+		for _, col := range table.Columns {
+			if !slices.Contains(columns, col) {
+				columns = append(columns, col)
+			}
+		}
+	}
+	return columns
+}
+
+func refOf(rec *Record) ResourceRef {
+	return ResourceRef{
+		Key:    rec.Key,
+		Name:   rec.Values[ColumnName],
+		Region: rec.Values[ColumnRegion],
+	}
+}
@@ -0,0 +1,80 @@
+package diff
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnapshotAdd_Keys(t *testing.T) {
+	t.Parallel()
+
+	s := make(Snapshot)
+	columns := []string{"Category", "Name", "Region", "ARN"}
+	s.add("vpc", columns, map[string]string{"Category": "vpc", "Name": "main", "Region": "us-east-1", "ARN": "arn:aws:ec2:us-east-1:123456789012:vpc/vpc-1"})
+	s.add("vpc", columns, map[string]string{"Category": "vpc", "Name": "rtb", "Region": "us-east-1", "ARN": "N/A"})
+	s.add("vpc", columns, map[string]string{"Category": "vpc", "Name": "rtb", "Region": "us-east-1", "ARN": ""})
+
+	require.Len(t, s["vpc"].Records, 3)
+	assert.Equal(t, "arn:aws:ec2:us-east-1:123456789012:vpc/vpc-1", s["vpc"].Records[0].Key)
+	assert.Equal(t, "vpc/us-east-1/rtb", s["vpc"].Records[1].Key)
+	assert.Equal(t, "vpc/us-east-1/rtb#2", s["vpc"].Records[2].Key)
+}
+
+func TestCompare(t *testing.T) {
+	t.Parallel()
+
+	columns := []string{"Category", "Name", "Region", "ARN", "Runtime"}
+	lambda := func(name, runtime string) map[string]string {
+		return map[string]string{
+			"Category": "lambda",
+			"Name":     name,
+			"Region":   "us-east-1",
+			"ARN":      "arn:aws:lambda:us-east-1:123456789012:function:" + name,
+			"Runtime":  runtime,
+		}
+	}
+
+	before := make(Snapshot)
+	before.add("lambda", columns, lambda("api", "python3.11"))
+	before.add("lambda", columns, lambda("old", "nodejs18.x"))
+	before.add("lambda", columns, lambda("same", "go1.x"))
+	before.add("sqs", []string{"Category", "Name", "Region", "ARN"}, map[string]string{"Category": "sqs", "Name": "jobs", "Region": "us-east-1", "ARN": "arn:aws:sqs:us-east-1:123456789012:jobs"})
+
+	after := make(Snapshot)
+	after.add("lambda", columns, lambda("api", "python3.12"))
+	after.add("lambda", columns, lambda("new", "nodejs20.x"))
+	after.add("lambda", columns, lambda("same", "go1.x"))
+	after.add("sqs", []string{"Category", "Name", "Region", "ARN"}, map[string]string{"Category": "sqs", "Name": "jobs", "Region": "us-east-1", "ARN": "arn:aws:sqs:us-east-1:123456789012:jobs"})
+
+	result := Compare(before, after, "week1", "week2")
+
+	assert.Equal(t, "week1", result.Before)
+	assert.Equal(t, "week2", result.After)
+	require.Len(t, result.Categories, 1, "unchanged categories are omitted")
+	got := result.Categories[0]
+	assert.Equal(t, "lambda", got.Category)
+	require.Len(t, got.Added, 1)
+	assert.Equal(t, "new", got.Added[0].Name)
+	require.Len(t, got.Removed, 1)
+	assert.Equal(t, "old", got.Removed[0].Name)
+	require.Len(t, got.Modified, 1)
+	assert.Equal(t, "api", got.Modified[0].Name)
+	assert.Equal(t, []Change{{Column: "Runtime", Before: "python3.11", After: "python3.12"}}, got.Modified[0].Changes)
+}
+
+func TestCompare_CategoryOnlyOnOneSide(t *testing.T) {
+	t.Parallel()
+
+	after := make(Snapshot)
+	after.add("kms", []string{"Category", "Name", "Region", "ARN"}, map[string]string{"Category": "kms", "Name": "key", "Region": "us-east-1", "ARN": "arn:aws:kms:us-east-1:123456789012:key/1"})
+
+	result := Compare(Snapshot{}, after, "a", "b")
+	require.Len(t, result.Categories, 1)
+	assert.Len(t, result.Categories[0].Added, 1)
+	assert.Empty(t, result.Categories[0].Removed)
+	assert.True(t, result.HasChanges())
+
+	assert.False(t, Compare(after, after, "a", "b").HasChanges())
+}
@@ -0,0 +1,263 @@
+package diff
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/y-miyazaki/arc/internal/aws/helpers"
+	"github.com/y-miyazaki/arc/internal/aws/resources"
+	"github.com/y-miyazaki/arc/internal/exporter"
+	"github.com/y-miyazaki/arc/internal/runreport"
+)
+
+// Column headers shared by every collector and used to key records.
+const (
+	ColumnARN      = "ARN"
+	ColumnCategory = "Category"
+	ColumnName     = "Name"
+	ColumnRegion   = "Region"
+)
+
+// Sentinel errors for snapshot loading (alphabetical order).
+var (
+	ErrNoOutputFound     = errors.New("no arc output found")
+	ErrUnsupportedSource = errors.New("unsupported snapshot source")
+)
+
+// maxNDJSONLineSize bounds a single NDJSON line (large policy documents).
+const maxNDJSONLineSize = 64 * 1024 * 1024
+
+// Load reads a snapshot from path, which may be an account output directory
+// ({outputDir}/{accountID}), its resources directory, or a single all.csv,
+// all.json, all.ndjson or per-category export file.
+//
+// In a directory, per-category CSV files are preferred, then per-category JSON
+// and NDJSON files, so categories are named after their collector. Records from
+// combined all.* files are grouped by their Category column instead.
+func Load(path string) (Snapshot, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat %s: %w", path, err)
+	}
+	if !info.IsDir() {
+		return loadFile(path)
+	}
+
+	dir := path
+	if sub := filepath.Join(path, "resources"); isDir(sub) {
+		dir = sub
+	}
+	for _, ext := range []string{exporter.FormatCSV, exporter.FormatJSON, exporter.FormatNDJSON} {
+		files, globErr := filepath.Glob(filepath.Join(dir, "*."+ext))
+		if globErr != nil {
+			return nil, fmt.Errorf("failed to list %s files: %w", ext, globErr)
+		}
+		files = slices.DeleteFunc(files, func(f string) bool {
+			return filepath.Base(f) == "all."+ext || filepath.Base(f) == runreport.FileName
+		})
+		if len(files) == 0 {
+			continue
+		}
+		snapshot := make(Snapshot)
+		for _, file := range files {
+			if loadErr := loadInto(snapshot, file, categoryFromFile(file)); loadErr != nil {
+				return nil, loadErr
+			}
+		}
+		return snapshot, nil
+	}
+	return nil, fmt.Errorf("%w in %s", ErrNoOutputFound, path)
+}
+
+// loadFile loads a single export file. Files named all.* are grouped by the
+// Category column; other files are treated as one category named after the file.
+func loadFile(path string) (Snapshot, error) {
+	category := categoryFromFile(path)
+	if category == "all" {
+		category = ""
+	}
+	snapshot := make(Snapshot)
+	if err := loadInto(snapshot, path, category); err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+// loadInto adds the records of path to snapshot. An empty category groups
+// records by their Category column.
+func loadInto(snapshot Snapshot, path, category string) (err error) {
+	f, err := os.Open(path) //nolint:gosec // G304 - path is provided by the user on purpose
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer func() {
+		if cerr := f.Close(); cerr != nil {
+			err = errors.Join(err, fmt.Errorf("failed to close %s: %w", path, cerr))
+		}
+	}()
+
+	switch strings.TrimPrefix(filepath.Ext(path), ".") {
+	case exporter.FormatCSV:
+		err = readCSV(snapshot, f, category)
+	case exporter.FormatJSON:
+		err = readJSON(snapshot, f, category)
+	case exporter.FormatNDJSON:
+		err = readNDJSON(snapshot, f, category)
+	default:
+		return fmt.Errorf("%w: %s", ErrUnsupportedSource, path)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return nil
+}
+
+// readCSV reads CSV output. all.csv repeats the header for every category and
+// separates categories with a blank line, which the CSV reader skips, so a
+// record starting with the Category header starts a new section.
+func readCSV(snapshot Snapshot, r io.Reader, category string) error {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	var header []string
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to parse csv: %w", err)
+		}
+		if header == nil || record[0] == ColumnCategory {
+			header = record
+			continue
+		}
+
+		values := make(map[string]string, len(header))
+		for i, h := range header {
+			if i < len(record) {
+				values[h] = record[i]
+			}
+		}
+		snapshot.add(recordCategory(category, values), header, values)
+	}
+}
+
+// readJSON reads a JSON array written by exporter.WriteJSON.
+func readJSON(snapshot Snapshot, r io.Reader, category string) error {
+	var items []exporter.JSONResource
+	if err := json.NewDecoder(r).Decode(&items); err != nil {
+		return fmt.Errorf("failed to parse json: %w", err)
+	}
+	for i := range items {
+		addJSONResource(snapshot, &items[i], category)
+	}
+	return nil
+}
+
+// readNDJSON reads newline-delimited JSON written by exporter.WriteNDJSON.
+func readNDJSON(snapshot Snapshot, r io.Reader, category string) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, maxNDJSONLineSize)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		var item exporter.JSONResource
+		if err := json.Unmarshal([]byte(line), &item); err != nil {
+			return fmt.Errorf("failed to parse ndjson line: %w", err)
+		}
+		addJSONResource(snapshot, &item, category)
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to scan ndjson: %w", err)
+	}
+	return nil
+}
+
+// addJSONResource converts a JSON resource back to column values. The collector
+// columns are used when category names a collector so that values match the CSV
+// output; otherwise the base fields and the RawData keys are used.
+func addJSONResource(snapshot Snapshot, item *exporter.JSONResource, category string) {
+	r := resources.Resource{
+		ARN:          item.ARN,
+		Category:     item.Category,
+		Name:         item.Name,
+		RawData:      helpers.NormalizeRawData(item.RawData),
+		Region:       item.Region,
+		SubCategory1: item.SubCategory1,
+		SubCategory2: item.SubCategory2,
+		SubCategory3: item.SubCategory3,
+		Tags:         item.Tags,
+	}
+	if category == "" {
+		category = r.Category
+	}
+
+	columns, err := resources.ColumnsFor(category)
+	if err != nil {
+		columns = genericColumns(&r)
+	}
+	header := make([]string, 0, len(columns))
+	values := make(map[string]string, len(columns))
+	for _, col := range columns {
+		header = append(header, col.Header)
+		values[col.Header] = col.Value(r)
+	}
+	snapshot.add(category, header, values)
+}
+
+// genericColumns returns the base resource columns followed by the sorted RawData keys
+// and the Tags column.
+func genericColumns(r *resources.Resource) []resources.Column {
+	columns := []resources.Column{
+		{Header: ColumnCategory, Value: func(r resources.Resource) string { return r.Category }},
+		{Header: "SubCategory1", Value: func(r resources.Resource) string { return r.SubCategory1 }},
+		{Header: "SubCategory2", Value: func(r resources.Resource) string { return r.SubCategory2 }},
+		{Header: "SubCategory3", Value: func(r resources.Resource) string { return r.SubCategory3 }},
+		{Header: ColumnName, Value: func(r resources.Resource) string { return r.Name }},
+		{Header: ColumnRegion, Value: func(r resources.Resource) string { return r.Region }},
+		{Header: ColumnARN, Value: func(r resources.Resource) string { return r.ARN }},
+	}
+	keys := make([]string, 0, len(r.RawData))
+	for k := range r.RawData {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	for _, k := range keys {
+		columns = append(columns, resources.Column{
+			Header: k,
+			Value:  func(r resources.Resource) string { return helpers.GetMapValue(r.RawData, k) },
+		})
+	}
+	return append(columns, resources.TagColumns(nil)...)
+}
+
+// recordCategory returns category, or the Category column when category is empty.
+func recordCategory(category string, values map[string]string) string {
+	if category != "" {
+		return category
+	}
+	return values[ColumnCategory]
+}
+
+// categoryFromFile returns the file name without its extension.
+func categoryFromFile(path string) string {
+	base := filepath.Base(path)
+	return strings.TrimSuffix(base, filepath.Ext(base))
+}
+
+// isDir reports whether path exists and is a directory.
+func isDir(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
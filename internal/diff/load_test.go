@@ -0,0 +1,94 @@
+package diff
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o750))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+}
+
+func TestLoad_AccountDirectoryPrefersCategoryCSV(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "resources", "sqs.csv"), "Category,Name,Region,ARN\nsqs,jobs,us-east-1,arn:aws:sqs:us-east-1:123456789012:jobs\n")
+	writeFile(t, filepath.Join(dir, "resources", "all.csv"), "Category,Name,Region,ARN\nsqs,ignored,us-east-1,\n")
+
+	snapshot, err := Load(dir)
+	require.NoError(t, err)
+	require.Contains(t, snapshot, "sqs")
+	require.Len(t, snapshot["sqs"].Records, 1)
+	assert.Equal(t, "arn:aws:sqs:us-east-1:123456789012:jobs", snapshot["sqs"].Records[0].Key)
+	assert.Equal(t, []string{"Category", "Name", "Region", "ARN"}, snapshot["sqs"].Columns)
+}
+
+func TestLoad_AllCSVSections(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "all.csv")
+	writeFile(t, path, "Category,Name,Region,ARN\n"+
+		"kms,key,us-east-1,arn:aws:kms:us-east-1:123456789012:key/1\n"+
+		"\n"+
+		"Category,SubCategory1,Name,Region,ARN\n"+
+		"vpc,Subnet,private-a,us-east-1,\n")
+
+	snapshot, err := Load(path)
+	require.NoError(t, err)
+	require.Contains(t, snapshot, "kms")
+	require.Contains(t, snapshot, "vpc")
+	assert.Equal(t, "vpc/us-east-1/private-a", snapshot["vpc"].Records[0].Key)
+	assert.Equal(t, "Subnet", snapshot["vpc"].Records[0].Values["SubCategory1"])
+}
+
+func TestLoad_CategoryJSONUsesCollectorColumns(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "run.json"), `{"status":"completed","tasks":[]}`)
+	writeFile(t, filepath.Join(dir, "lambda.json"), `[{"Category":"lambda","SubCategory1":"","SubCategory2":"","SubCategory3":"","Name":"api","Region":"us-east-1","ARN":"arn:aws:lambda:us-east-1:123456789012:function:api","RawData":{"RoleARN":"arn:aws:iam::123456789012:role/api","MemorySize":128}}]`)
+
+	snapshot, err := Load(dir)
+	require.NoError(t, err)
+	require.Contains(t, snapshot, "lambda")
+	values := snapshot["lambda"].Records[0].Values
+	assert.Equal(t, "arn:aws:iam::123456789012:role/api", values["RoleARN"])
+	assert.Equal(t, "128", values["MemorySize"])
+	assert.Contains(t, snapshot["lambda"].Columns, "Runtime")
+	assert.NotContains(t, snapshot, "run", "the run report is not a category")
+}
+
+func TestLoad_AllNDJSONGroupsByCategory(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "all.ndjson")
+	writeFile(t, path, `{"Category":"custom","Name":"a","Region":"us-east-1","ARN":"","RawData":{"Enabled":true}}`+"\n")
+
+	snapshot, err := Load(path)
+	require.NoError(t, err)
+	require.Contains(t, snapshot, "custom")
+	assert.Equal(t, "true", snapshot["custom"].Records[0].Values["Enabled"])
+	assert.Equal(t, "custom/us-east-1/a", snapshot["custom"].Records[0].Key)
+}
+
+func TestLoad_Errors(t *testing.T) {
+	t.Parallel()
+
+	_, err := Load(filepath.Join(t.TempDir(), "missing"))
+	require.Error(t, err)
+
+	_, err = Load(t.TempDir())
+	require.ErrorIs(t, err, ErrNoOutputFound)
+
+	path := filepath.Join(t.TempDir(), "all.txt")
+	writeFile(t, path, "x")
+	_, err = Load(path)
+	require.ErrorIs(t, err, ErrUnsupportedSource)
+}
@@ -0,0 +1,107 @@
+package diff
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html/template"
+	"io"
+	"strings"
+)
+
+// Report formats supported by the diff command.
+const (
+	FormatHTML = "html"
+	FormatJSON = "json"
+	FormatText = "text"
+)
+
+// ErrUnknownFormat is returned for report formats other than text, json and html.
+var ErrUnknownFormat = errors.New("unknown diff format")
+
+//go:embed diff_template.html
+var htmlTemplateSource string
+
+var htmlTemplate = template.Must(template.New("diff").Parse(htmlTemplateSource))
+
+// Write writes result in the given format.
+func Write(w io.Writer, result *Result, format string) error {
+	switch format {
+	case FormatText:
+		return WriteText(w, result)
+	case FormatJSON:
+		return WriteJSON(w, result)
+	case FormatHTML:
+		return WriteHTML(w, result)
+	default:
+		return fmt.Errorf("%w: %q (supported: text, json, html)", ErrUnknownFormat, format)
+	}
+}
+
+// WriteText writes a human-readable report: one section per category with
+// added (+), removed (-) and modified (~) resources and their changed columns.
+func WriteText(w io.Writer, result *Result) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "--- %s\n+++ %s\n", result.Before, result.After)
+	if !result.HasChanges() {
+		b.WriteString("No differences found.\n")
+	}
+	for i := range result.Categories {
+		c := &result.Categories[i]
+		fmt.Fprintf(&b, "\n[%s] added: %d, removed: %d, modified: %d\n", c.Category, len(c.Added), len(c.Removed), len(c.Modified))
+		for _, ref := range c.Added {
+			fmt.Fprintf(&b, "  + %s\n", describe(ref))
+		}
+		for _, ref := range c.Removed {
+			fmt.Fprintf(&b, "  - %s\n", describe(ref))
+		}
+		for j := range c.Modified {
+			m := &c.Modified[j]
+			fmt.Fprintf(&b, "  ~ %s\n", describe(m.ResourceRef))
+			for _, change := range m.Changes {
+				fmt.Fprintf(&b, "      %s: %s -> %s\n", change.Column, quoteValue(change.Before), quoteValue(change.After))
+			}
+		}
+	}
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return fmt.Errorf("failed to write text report: %w", err)
+	}
+	return nil
+}
+
+// WriteJSON writes result as indented JSON.
+func WriteJSON(w io.Writer, result *Result) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(result); err != nil {
+		return fmt.Errorf("failed to encode json report: %w", err)
+	}
+	return nil
+}
+
+// WriteHTML writes a self-contained HTML report styled like the resource viewer.
+func WriteHTML(w io.Writer, result *Result) error {
+	if err := htmlTemplate.Execute(w, result); err != nil {
+		return fmt.Errorf("failed to render html report: %w", err)
+	}
+	return nil
+}
+
+// describe returns "name (region) key", omitting parts equal to the key.
+func describe(ref ResourceRef) string {
+	var parts []string
+	if ref.Name != "" && ref.Name != ref.Key {
+		parts = append(parts, ref.Name)
+	}
+	if ref.Region != "" {
+		parts = append(parts, "("+ref.Region+")")
+	}
+	parts = append(parts, ref.Key)
+	return strings.Join(parts, " ")
+}
+
+// quoteValue quotes a value so that empty and multi-line values stay readable on one line.
+func quoteValue(v string) string {
+	return fmt.Sprintf("%q", v)
+}
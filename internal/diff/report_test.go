@@ -0,0 +1,89 @@
+package diff
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sampleResult() *Result {
+	return &Result{
+		Before: "before",
+		After:  "after",
+		Categories: []CategoryDiff{
+			{
+				Category: "lambda",
+				Added:    []ResourceRef{{Key: "arn:aws:lambda:us-east-1:123456789012:function:new", Name: "new", Region: "us-east-1"}},
+				Removed:  []ResourceRef{{Key: "lambda/us-east-1/old", Name: "old", Region: "us-east-1"}},
+				Modified: []ModifiedResource{
+					{
+						ResourceRef: ResourceRef{Key: "arn:aws:lambda:us-east-1:123456789012:function:api", Name: "api", Region: "us-east-1"},
+						Changes:     []Change{{Column: "EnvVars", Before: "A=1", After: "A=1\nB=<script>"}},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestWriteText(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteText(&buf, sampleResult()))
+	out := buf.String()
+
+	assert.Contains(t, out, "--- before\n+++ after\n")
+	assert.Contains(t, out, "[lambda] added: 1, removed: 1, modified: 1")
+	assert.Contains(t, out, "  + new (us-east-1) arn:aws:lambda:us-east-1:123456789012:function:new\n")
+	assert.Contains(t, out, "  - old (us-east-1) lambda/us-east-1/old\n")
+	assert.Contains(t, out, `      EnvVars: "A=1" -> "A=1\nB=<script>"`)
+}
+
+func TestWriteText_NoChanges(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteText(&buf, &Result{Before: "a", After: "b"}))
+	assert.Contains(t, buf.String(), "No differences found.")
+}
+
+func TestWriteJSON(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteJSON(&buf, sampleResult()))
+
+	var got map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &got))
+	categories := got["categories"].([]any)
+	require.Len(t, categories, 1)
+	modified := categories[0].(map[string]any)["modified"].([]any)
+	first := modified[0].(map[string]any)
+	assert.Equal(t, "api", first["name"])
+	assert.Len(t, first["changes"], 1)
+}
+
+func TestWriteHTML_EscapesValues(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteHTML(&buf, sampleResult()))
+	out := buf.String()
+
+	assert.True(t, strings.HasPrefix(out, "<!doctype html>"))
+	assert.Contains(t, out, "lambda")
+	assert.Contains(t, out, "B=&lt;script&gt;")
+	assert.NotContains(t, out, "B=<script>")
+}
+
+func TestWrite_UnknownFormat(t *testing.T) {
+	t.Parallel()
+
+	err := Write(&bytes.Buffer{}, sampleResult(), "xml")
+	assert.ErrorIs(t, err, ErrUnknownFormat)
+}
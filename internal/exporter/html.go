@@ -10,14 +10,31 @@
 	"fmt"
 	"html"
 	"io"
+	"io/fs"
+	"net/url"
 	"os"
+	"path"
 	"path/filepath"
 	"strings"
+
+	"github.com/y-miyazaki/arc/internal/findings"
+	"github.com/y-miyazaki/arc/internal/graph"
 )
 
 //go:embed html_template.html
 var htmlTemplate string
 
+//go:embed accounts_template.html
+var accountsTemplate string
+
+// AccountIndexEntry represents one account linked from the top-level accounts index
+// and recorded in the accounts.json manifest.
+type AccountIndexEntry struct {
+	AccountID   string `json:"account_id"`        //nolint:tagliatelle // matches files.json naming convention
+	DisplayName string `json:"display_name"`      //nolint:tagliatelle // matches files.json naming convention
+	OUPath      string `json:"ou_path,omitempty"` //nolint:tagliatelle // matches files.json naming convention
+}
+
 // HTMLTemplateData represents the data structure for HTML template substitution
 type HTMLTemplateData struct {
 	Title       string
@@ -25,10 +42,19 @@ type HTMLTemplateData struct {
 	OutputFile  string
 }
 
+// ManifestKindFindings marks the files.json entry of findings.csv, which the
+// viewer shows in its Findings tab instead of the category list.
+const ManifestKindFindings = "findings"
+
+// ManifestKindRelations marks the files.json entry of relations.json, the
+// relationship index the viewer uses to link rows of different categories.
+const ManifestKindRelations = "relations"
+
 // FileManifestEntry represents a single entry in the files.json manifest
 type FileManifestEntry struct {
 	Path        string `json:"path"`
-	DisplayName string `json:"display_name"` //nolint:tagliatelle // matches JavaScript naming convention
+	DisplayName string `json:"display_name"`   //nolint:tagliatelle // matches JavaScript naming convention
+	Kind        string `json:"kind,omitempty"` // empty for category CSV files
 }
 
 func closeAndJoin(err error, c io.Closer, msg string) error {
@@ -42,15 +68,25 @@ func closeAndJoin(err error, c io.Closer, msg string) error {
 }
 
 // GenerateHTML generates HTML index and manifest files for CSV outputs.
-func GenerateHTML(ctx context.Context, outputDir, accountID, accountDisplay, outputFile string, categories []string) error {
+// mode is one of HTMLModes; an empty mode is HTMLModeServer.
+func GenerateHTML(ctx context.Context, outputDir, accountID, accountDisplay, outputFile, mode string, categories []string) error {
 	if err := ctx.Err(); err != nil {
 		return fmt.Errorf("context canceled: %w", err)
 	}
 	// Generate files.json manifest
 	manifestPath := filepath.Join(outputDir, accountID, "files.json")
-	if err := generateManifest(manifestPath, outputDir, accountID, categories); err != nil {
+	entries, err := generateManifest(manifestPath, outputDir, accountID, categories)
+	if err != nil {
 		return fmt.Errorf("failed to generate manifest: %w", err)
 	}
+	assets, err := fs.Sub(vendoredAssets, "assets")
+	if err != nil {
+		return fmt.Errorf("failed to open assets: %w", err)
+	}
+	page, err := newViewerPage(mode, assets, filepath.Join(outputDir, accountID), entries)
+	if err != nil {
+		return fmt.Errorf("failed to generate viewer: %w", err)
+	}
 
 	// Create ZIP file containing all CSV resources
 	resourcesDir := filepath.Join(outputDir, accountID, "resources")
@@ -61,16 +97,92 @@ func GenerateHTML(ctx context.Context, outputDir, accountID, accountDisplay, out
 
 	// Generate index.html
 	indexPath := filepath.Join(outputDir, accountID, "index.html")
-	if err := generateIndexHTML(ctx, indexPath, accountID, accountDisplay, outputFile); err != nil {
+	if err := generateIndexHTML(ctx, indexPath, accountID, accountDisplay, outputFile, page); err != nil {
 		return fmt.Errorf("failed to generate index.html: %w", err)
 	}
 
 	return nil
 }
 
-// generateManifest creates files.json with the list of CSV files
-func generateManifest(manifestPath, outputDir, accountID string, categories []string) (err error) {
-	var entries []FileManifestEntry
+// GenerateAccountsIndex writes {outputDir}/index.html linking the per-account
+// index.html of every given account. Accounts are listed in the given order.
+func GenerateAccountsIndex(ctx context.Context, outputDir string, accounts []AccountIndexEntry) (err error) {
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return fmt.Errorf("context canceled: %w", ctxErr)
+	}
+
+	indexPath := filepath.Join(outputDir, "index.html")
+	var f *os.File
+	f, err = os.Create(indexPath) //nolint:gosec // G304: Path is controlled and sanitized
+	if err != nil {
+		return fmt.Errorf("failed to create accounts index.html: %w", err)
+	}
+	defer func() {
+		err = closeAndJoin(err, f, "failed to close accounts index.html")
+	}()
+
+	return WriteAccountsIndex(f, accounts)
+}
+
+// WriteAccountsIndex writes the accounts index page linking the per-account
+// index.html of every given account, relative to the output directory.
+func WriteAccountsIndex(w io.Writer, accounts []AccountIndexEntry) error {
+	var items strings.Builder
+	for _, account := range accounts {
+		display := account.DisplayName
+		if display == "" {
+			display = account.AccountID
+		}
+		href := html.EscapeString(path.Join(url.PathEscape(account.AccountID), "index.html"))
+		var meta string
+		if account.OUPath != "" {
+			meta = fmt.Sprintf("<span class=\"meta\">%s</span>", html.EscapeString(account.OUPath))
+		}
+		fmt.Fprintf(&items, "                <li><a href=\"%s\">%s%s</a></li>\n", href, html.EscapeString(display), meta)
+	}
+
+	page := accountsTemplate
+	page = strings.ReplaceAll(page, "@@INDEX_TITLE@@", html.EscapeString("AWS Resources (accounts)"))
+	page = strings.ReplaceAll(page, "@@INDEX_DESCRIPTION@@", html.EscapeString(fmt.Sprintf("%d account(s) collected by arc", len(accounts))))
+	page = strings.ReplaceAll(page, "@@ACCOUNT_LIST@@", strings.TrimRight(items.String(), "\n"))
+
+	if _, err := io.WriteString(w, page); err != nil {
+		return fmt.Errorf("failed to write accounts index.html: %w", err)
+	}
+	return nil
+}
+
+// WriteAccountsManifest writes {outputDir}/accounts.json listing every collected
+// account with its display name and, for Organizations discovery, its OU path.
+func WriteAccountsManifest(ctx context.Context, outputDir string, accounts []AccountIndexEntry) (err error) {
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return fmt.Errorf("context canceled: %w", ctxErr)
+	}
+	if accounts == nil {
+		accounts = []AccountIndexEntry{}
+	}
+
+	manifestPath := filepath.Join(outputDir, "accounts.json")
+	var f *os.File
+	f, err = os.Create(manifestPath) //nolint:gosec // G304: Path is controlled and sanitized
+	if err != nil {
+		return fmt.Errorf("failed to create accounts manifest: %w", err)
+	}
+	defer func() {
+		err = closeAndJoin(err, f, "failed to close accounts manifest")
+	}()
+
+	encoder := json.NewEncoder(f)
+	encoder.SetIndent("", "  ")
+	if encErr := encoder.Encode(accounts); encErr != nil {
+		return fmt.Errorf("failed to encode accounts manifest: %w", encErr)
+	}
+
+	return nil
+}
+
+// generateManifest creates files.json with the list of CSV files and returns its entries
+func generateManifest(manifestPath, outputDir, accountID string, categories []string) (entries []FileManifestEntry, err error) {
 
 	resourcesDir := filepath.Join(outputDir, accountID, "resources")
 	for _, category := range categories {
@@ -86,11 +198,27 @@ func generateManifest(manifestPath, outputDir, accountID string, categories []st
 		}
 	}
 
+	// findings.csv is only written with --findings.
+	if _, statErr := os.Stat(filepath.Join(outputDir, accountID, findings.CSVFile)); statErr == nil {
+		entries = append(entries, FileManifestEntry{
+			Path:        findings.CSVFile,
+			DisplayName: "findings",
+			Kind:        ManifestKindFindings,
+		})
+	}
+	if _, statErr := os.Stat(filepath.Join(outputDir, accountID, graph.RowIndexFile)); statErr == nil {
+		entries = append(entries, FileManifestEntry{
+			Path:        graph.RowIndexFile,
+			DisplayName: "relations",
+			Kind:        ManifestKindRelations,
+		})
+	}
+
 	// Write manifest file
 	var f *os.File
 	f, err = os.Create(manifestPath) //nolint:gosec // G304: Path is controlled and sanitized
 	if err != nil {
-		return fmt.Errorf("failed to create manifest file: %w", err)
+		return nil, fmt.Errorf("failed to create manifest file: %w", err)
 	}
 	defer func() {
 		err = closeAndJoin(err, f, "failed to close manifest file")
@@ -99,10 +227,10 @@ func generateManifest(manifestPath, outputDir, accountID string, categories []st
 	encoder := json.NewEncoder(f)
 	encoder.SetIndent("", "  ")
 	if encErr := encoder.Encode(entries); encErr != nil {
-		return fmt.Errorf("failed to encode manifest: %w", encErr)
+		return nil, fmt.Errorf("failed to encode manifest: %w", encErr)
 	}
 
-	return nil
+	return entries, nil
 }
 
 // createResourcesZip creates a ZIP archive of all CSV files in the resources directory
@@ -197,7 +325,7 @@ func createResourcesZip(ctx context.Context, zipPath, resourcesDir string) (err
 }
 
 // generateIndexHTML creates index.html with embedded template.
-func generateIndexHTML(ctx context.Context, indexPath, accountID, accountDisplay, outputFile string) (err error) {
+func generateIndexHTML(ctx context.Context, indexPath, accountID, accountDisplay, outputFile string, viewer *viewerPage) (err error) {
 	if ctxErr := ctx.Err(); ctxErr != nil {
 		return fmt.Errorf("context canceled: %w", ctxErr)
 	}
@@ -217,6 +345,10 @@ func generateIndexHTML(ctx context.Context, indexPath, accountID, accountDisplay
 	page = strings.ReplaceAll(page, "@@INDEX_DESCRIPTION@@", description)
 	page = strings.ReplaceAll(page, "@@OUTPUT_FILE@@", safeOutputFile)
 	page = strings.ReplaceAll(page, "@@ACCOUNT_ID@@", safeAccount)
+	// The viewer fragments are substituted last: inlined data may contain placeholders.
+	page = strings.Replace(page, "@@STYLESHEETS@@", viewer.stylesheets, 1)
+	page = strings.Replace(page, "@@SCRIPTS@@", viewer.scripts, 1)
+	page = strings.Replace(page, "@@DATA@@", viewer.data, 1)
 
 	// Write HTML file
 	var f *os.File
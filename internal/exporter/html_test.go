@@ -23,6 +23,7 @@ func TestGenerateHTML(t *testing.T) {
 		accountID      string
 		accountDisplay string
 		outputFile     string
+		mode           string
 		categories     []string
 		setup          func(t *testing.T, base, accountID string)
 		wantErr        bool
@@ -46,7 +47,45 @@ func TestGenerateHTML(t *testing.T) {
 				{Path: "resources/ec2.csv", DisplayName: "ec2"},
 				{Path: "resources/s3.csv", DisplayName: "s3"},
 			},
-			wantIndex: []string{"AWS Resources (123456789012)", "files.json"},
+			wantIndex: []string{"AWS Resources (123456789012)", "files.json", "https://cdn.datatables.net/"},
+		},
+		{
+			name:           "findings csv is listed with its kind",
+			accountID:      "123456789012",
+			accountDisplay: "123456789012",
+			outputFile:     "all.csv",
+			categories:     []string{"s3_bucket"},
+			setup: func(t *testing.T, base, accountID string) {
+				t.Helper()
+				resourcesDir := filepath.Join(base, accountID, "resources")
+				require.NoError(t, os.MkdirAll(resourcesDir, 0o755))
+				require.NoError(t, os.WriteFile(filepath.Join(resourcesDir, "s3_bucket.csv"), []byte("a\n"), 0o644))
+				require.NoError(t, os.WriteFile(filepath.Join(base, accountID, "findings.csv"), []byte("Severity\n"), 0o644))
+			},
+			wantEntries: []FileManifestEntry{
+				{Path: "resources/s3_bucket.csv", DisplayName: "s3_bucket"},
+				{Path: "findings.csv", DisplayName: "findings", Kind: ManifestKindFindings},
+			},
+			wantIndex: []string{`id="tabFindings"`},
+		},
+		{
+			name:           "relations json is listed with its kind",
+			accountID:      "123456789012",
+			accountDisplay: "123456789012",
+			outputFile:     "all.csv",
+			categories:     []string{"lambda"},
+			setup: func(t *testing.T, base, accountID string) {
+				t.Helper()
+				resourcesDir := filepath.Join(base, accountID, "resources")
+				require.NoError(t, os.MkdirAll(resourcesDir, 0o755))
+				require.NoError(t, os.WriteFile(filepath.Join(resourcesDir, "lambda.csv"), []byte("a\n"), 0o644))
+				require.NoError(t, os.WriteFile(filepath.Join(base, accountID, "relations.json"), []byte("{}\n"), 0o644))
+			},
+			wantEntries: []FileManifestEntry{
+				{Path: "resources/lambda.csv", DisplayName: "lambda"},
+				{Path: "relations.json", DisplayName: "relations", Kind: ManifestKindRelations},
+			},
+			wantIndex: []string{`id="detailPane"`},
 		},
 		{
 			name:           "account path as file returns error",
@@ -60,6 +99,19 @@ func TestGenerateHTML(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name:           "unknown html mode returns error",
+			accountID:      "123456789012",
+			accountDisplay: "123456789012",
+			outputFile:     "all.csv",
+			mode:           "inline",
+			categories:     []string{},
+			setup: func(t *testing.T, base, accountID string) {
+				t.Helper()
+				require.NoError(t, os.MkdirAll(filepath.Join(base, accountID), 0o755))
+			},
+			wantErr: true,
+		},
 		{
 			name:           "empty categories still writes manifest and index",
 			accountID:      "no-cats",
@@ -109,7 +161,7 @@ func TestGenerateHTML(t *testing.T) {
 			base := t.TempDir()
 			tt.setup(t, base, tt.accountID)
 
-			err := GenerateHTML(context.Background(), base, tt.accountID, tt.accountDisplay, tt.outputFile, tt.categories)
+			err := GenerateHTML(context.Background(), base, tt.accountID, tt.accountDisplay, tt.outputFile, tt.mode, tt.categories)
 			if tt.wantErr {
 				assert.Error(t, err)
 				return
@@ -200,7 +252,7 @@ func TestGenerateIndexHTML_FallbackToAccountIDWhenDisplayEmpty(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 			indexPath := filepath.Join(t.TempDir(), "index.html")
-			require.NoError(t, generateIndexHTML(context.Background(), indexPath, tt.accountID, tt.accountDisplay, "all.csv"))
+			require.NoError(t, generateIndexHTML(context.Background(), indexPath, tt.accountID, tt.accountDisplay, "all.csv", &viewerPage{}))
 			b, err := os.ReadFile(indexPath)
 			require.NoError(t, err)
 			assert.Contains(t, string(b), tt.wantContains)
@@ -232,10 +284,98 @@ func TestGenerateHTML_CanceledContext(t *testing.T) {
 	t.Parallel()
 	ctx, cancel := context.WithCancel(context.Background())
 	cancel()
-	err := GenerateHTML(ctx, t.TempDir(), "123456789012", "123456789012", "all.csv", nil)
+	err := GenerateHTML(ctx, t.TempDir(), "123456789012", "123456789012", "all.csv", HTMLModeServer, nil)
+	assert.Error(t, err)
+}
+
+func TestGenerateAccountsIndex(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		accounts     []AccountIndexEntry
+		wantContains []string
+	}{
+		{
+			name: "links every account index",
+			accounts: []AccountIndexEntry{
+				{AccountID: "111111111111", DisplayName: "prod(111111111111)"},
+				{AccountID: "222222222222"},
+			},
+			wantContains: []string{
+				`<a href="111111111111/index.html">prod(111111111111)</a>`,
+				`<a href="222222222222/index.html">222222222222</a>`,
+				"2 account(s) collected by arc",
+			},
+		},
+		{
+			name:         "escapes html in display name",
+			accounts:     []AccountIndexEntry{{AccountID: "111111111111", DisplayName: "<b>x</b>"}},
+			wantContains: []string{html.EscapeString("<b>x</b>")},
+		},
+		{
+			name:         "shows ou path",
+			accounts:     []AccountIndexEntry{{AccountID: "111111111111", DisplayName: "prod(111111111111)", OUPath: "/Root/Workloads"}},
+			wantContains: []string{`prod(111111111111)<span class="meta">/Root/Workloads</span>`},
+		},
+		{
+			name:         "no accounts still writes index",
+			accounts:     nil,
+			wantContains: []string{"0 account(s) collected by arc"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			base := t.TempDir()
+			require.NoError(t, GenerateAccountsIndex(context.Background(), base, tt.accounts))
+			b, err := os.ReadFile(filepath.Join(base, "index.html"))
+			require.NoError(t, err)
+			for _, want := range tt.wantContains {
+				assert.Contains(t, string(b), want)
+			}
+		})
+	}
+}
+
+func TestGenerateAccountsIndex_CanceledContext(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := GenerateAccountsIndex(ctx, t.TempDir(), nil)
 	assert.Error(t, err)
 }
 
+func TestWriteAccountsManifest(t *testing.T) {
+	t.Parallel()
+
+	base := t.TempDir()
+	accounts := []AccountIndexEntry{
+		{AccountID: "111111111111", DisplayName: "prod(111111111111)", OUPath: "/Root/Workloads"},
+		{AccountID: "222222222222", DisplayName: "222222222222"},
+	}
+	require.NoError(t, WriteAccountsManifest(context.Background(), base, accounts))
+
+	b, err := os.ReadFile(filepath.Join(base, "accounts.json"))
+	require.NoError(t, err)
+	var got []map[string]string
+	require.NoError(t, json.Unmarshal(b, &got))
+	require.Len(t, got, 2)
+	assert.Equal(t, map[string]string{"account_id": "111111111111", "display_name": "prod(111111111111)", "ou_path": "/Root/Workloads"}, got[0])
+	assert.Equal(t, map[string]string{"account_id": "222222222222", "display_name": "222222222222"}, got[1])
+}
+
+func TestWriteAccountsManifest_Empty(t *testing.T) {
+	t.Parallel()
+
+	base := t.TempDir()
+	require.NoError(t, WriteAccountsManifest(context.Background(), base, nil))
+	b, err := os.ReadFile(filepath.Join(base, "accounts.json"))
+	require.NoError(t, err)
+	assert.JSONEq(t, "[]", string(b))
+}
+
 type stubCloser struct {
 	err error
 }
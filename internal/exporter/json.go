@@ -0,0 +1,85 @@
+package exporter
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/y-miyazaki/arc/internal/aws/resources"
+)
+
+// Output formats supported by arc.
+const (
+	// FormatCSV writes one CSV file per category plus all.csv.
+	FormatCSV = "csv"
+	// FormatJSON writes one JSON array per category plus all.json.
+	FormatJSON = "json"
+	// FormatNDJSON writes one newline-delimited JSON file per category plus all.ndjson.
+	FormatNDJSON = "ndjson"
+	// FormatSQLite writes a single SQLite database (all.db) with one table per category.
+	FormatSQLite = "sqlite"
+	// FormatXLSX writes a single Excel workbook (all.xlsx) with one sheet per category.
+	FormatXLSX = "xlsx"
+)
+
+// JSONResource is the JSON representation of a collected resource.
+// Field names match the CSV column headers; RawData keeps the typed values
+// (lists, numbers, booleans) instead of the flattened CSV strings.
+type JSONResource struct {
+	Category     string            `json:"Category"`       //nolint:tagliatelle // matches CSV column headers
+	SubCategory1 string            `json:"SubCategory1"`   //nolint:tagliatelle // matches CSV column headers
+	SubCategory2 string            `json:"SubCategory2"`   //nolint:tagliatelle // matches CSV column headers
+	SubCategory3 string            `json:"SubCategory3"`   //nolint:tagliatelle // matches CSV column headers
+	Name         string            `json:"Name"`           //nolint:tagliatelle // matches CSV column headers
+	Region       string            `json:"Region"`         //nolint:tagliatelle // matches CSV column headers
+	ARN          string            `json:"ARN"`            //nolint:tagliatelle // matches CSV column headers
+	RawData      map[string]any    `json:"RawData"`        //nolint:tagliatelle // matches CSV column headers
+	Tags         map[string]string `json:"Tags,omitempty"` //nolint:tagliatelle // matches CSV column headers
+}
+
+// NewJSONResource converts a resource to its JSON representation.
+// TypedData is used for RawData when available, falling back to the normalized RawData.
+func NewJSONResource(r *resources.Resource) JSONResource {
+	rawData := r.TypedData
+	if rawData == nil {
+		rawData = r.RawData
+	}
+	return JSONResource{
+		Category:     r.Category,
+		SubCategory1: r.SubCategory1,
+		SubCategory2: r.SubCategory2,
+		SubCategory3: r.SubCategory3,
+		Name:         r.Name,
+		Region:       r.Region,
+		ARN:          r.ARN,
+		RawData:      rawData,
+		Tags:         r.Tags,
+	}
+}
+
+// WriteJSON writes the resources as a single indented JSON array.
+// An empty list is written as [].
+func WriteJSON(w io.Writer, res []resources.Resource) error {
+	out := make([]JSONResource, 0, len(res))
+	for i := range res {
+		out = append(out, NewJSONResource(&res[i]))
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(out); err != nil {
+		return fmt.Errorf("failed to encode json: %w", err)
+	}
+	return nil
+}
+
+// WriteNDJSON writes the resources as newline-delimited JSON, one object per line.
+func WriteNDJSON(w io.Writer, res []resources.Resource) error {
+	encoder := json.NewEncoder(w)
+	for i := range res {
+		if err := encoder.Encode(NewJSONResource(&res[i])); err != nil {
+			return fmt.Errorf("failed to encode ndjson line: %w", err)
+		}
+	}
+	return nil
+}
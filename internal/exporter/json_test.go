@@ -0,0 +1,109 @@
+package exporter_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/y-miyazaki/arc/internal/aws/resources"
+	"github.com/y-miyazaki/arc/internal/exporter"
+)
+
+func TestWriteJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		res      []resources.Resource
+		expected []map[string]any
+	}{
+		{
+			name:     "empty resource list",
+			res:      []resources.Resource{},
+			expected: []map[string]any{},
+		},
+		{
+			name: "typed data is used for raw data",
+			res: []resources.Resource{
+				{
+					Category:     "EC2",
+					SubCategory1: "Instance",
+					Name:         "web-server-01",
+					Region:       "us-east-1",
+					ARN:          "arn:aws:ec2:us-east-1:123456789012:instance/i-1234567890abcdef0",
+					RawData:      map[string]any{"Ports": "443\n80", "Public": "true"},
+					TypedData:    map[string]any{"Ports": []any{443, 80}, "Public": true},
+					Tags:         map[string]string{"Owner": "team-a"},
+				},
+			},
+			expected: []map[string]any{
+				{
+					"Category":     "EC2",
+					"SubCategory1": "Instance",
+					"SubCategory2": "",
+					"SubCategory3": "",
+					"Name":         "web-server-01",
+					"Region":       "us-east-1",
+					"ARN":          "arn:aws:ec2:us-east-1:123456789012:instance/i-1234567890abcdef0",
+					"RawData":      map[string]any{"Ports": []any{float64(443), float64(80)}, "Public": true},
+					"Tags":         map[string]any{"Owner": "team-a"},
+				},
+			},
+		},
+		{
+			name: "falls back to normalized raw data",
+			res: []resources.Resource{
+				{Category: "S3", Name: "logs", RawData: map[string]any{"Versioning": "Enabled"}},
+			},
+			expected: []map[string]any{
+				{
+					"Category":     "S3",
+					"SubCategory1": "",
+					"SubCategory2": "",
+					"SubCategory3": "",
+					"Name":         "logs",
+					"Region":       "",
+					"ARN":          "",
+					"RawData":      map[string]any{"Versioning": "Enabled"},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			require.NoError(t, exporter.WriteJSON(&buf, tt.res))
+
+			var got []map[string]any
+			require.NoError(t, json.Unmarshal(buf.Bytes(), &got))
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func TestWriteNDJSON(t *testing.T) {
+	res := []resources.Resource{
+		{Category: "IAM", Name: "admin", TypedData: map[string]any{"MFA": false}},
+		{Category: "IAM", Name: "readonly", TypedData: map[string]any{"MFA": true}},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, exporter.WriteNDJSON(&buf, res))
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	require.Len(t, lines, 2)
+	for i, line := range lines {
+		var got map[string]any
+		require.NoError(t, json.Unmarshal([]byte(line), &got))
+		assert.Equal(t, res[i].Name, got["Name"])
+		assert.Equal(t, res[i].TypedData, got["RawData"])
+	}
+}
+
+func TestWriteNDJSON_Empty(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, exporter.WriteNDJSON(&buf, nil))
+	assert.Empty(t, buf.String())
+}
@@ -0,0 +1,226 @@
+package exporter
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/y-miyazaki/arc/internal/aws/resources"
+
+	_ "modernc.org/sqlite" // pure-Go SQLite driver registered as "sqlite"
+)
+
+// ErrReservedTableName is returned when a category name clashes with a table written by every export.
+var ErrReservedTableName = errors.New("category name is reserved")
+
+// SQLite table names that are always created next to the per-category tables.
+const (
+	sqliteResourcesTable = "resources"
+	sqliteRunTable       = "run"
+)
+
+// CategoryResources is the collected output of one collector, written as one
+// table or sheet by exporters that keep categories apart.
+type CategoryResources struct {
+	Name      string
+	Columns   []resources.Column
+	Resources []resources.Resource
+}
+
+// RunMetadata describes the collection run an export belongs to.
+type RunMetadata struct {
+	AccountID string
+	Regions   []string
+	StartedAt time.Time
+	Version   string
+}
+
+// WriteSQLite writes the categories to a new SQLite database at path.
+// Each category becomes a table named after it whose columns are the collector's
+// column headers. All resources are also written to the "resources" table, with
+// RawData stored as JSON, and the run metadata to the single-row "run" table.
+// An existing file at path is replaced.
+func WriteSQLite(ctx context.Context, path string, meta RunMetadata, categories []CategoryResources) (err error) {
+	if err = ctx.Err(); err != nil {
+		return fmt.Errorf("context canceled: %w", err)
+	}
+	if rmErr := os.Remove(path); rmErr != nil && !errors.Is(rmErr, fs.ErrNotExist) {
+		return fmt.Errorf("failed to remove existing database: %w", rmErr)
+	}
+
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return fmt.Errorf("failed to open sqlite database: %w", err)
+	}
+	defer func() {
+		err = closeAndJoin(err, db, "failed to close sqlite database")
+	}()
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			err = errors.Join(err, ignoreTxDone(tx.Rollback()))
+		}
+	}()
+
+	if err = writeSQLiteRun(ctx, tx, meta); err != nil {
+		return err
+	}
+	if err = createSQLiteResourcesTable(ctx, tx); err != nil {
+		return err
+	}
+	for i := range categories {
+		if err = writeSQLiteCategory(ctx, tx, &categories[i]); err != nil {
+			return fmt.Errorf("failed to write category %s: %w", categories[i].Name, err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// writeSQLiteRun creates the run table and inserts the run metadata.
+func writeSQLiteRun(ctx context.Context, tx *sql.Tx, meta RunMetadata) error {
+	createStmt := "CREATE TABLE " + quoteIdentifier(sqliteRunTable) +
+		" (account_id TEXT NOT NULL, regions TEXT NOT NULL, started_at TEXT NOT NULL, arc_version TEXT NOT NULL)"
+	if _, err := tx.ExecContext(ctx, createStmt); err != nil {
+		return fmt.Errorf("failed to create run table: %w", err)
+	}
+
+	regions := meta.Regions
+	if regions == nil {
+		regions = []string{}
+	}
+	regionsJSON, err := json.Marshal(regions)
+	if err != nil {
+		return fmt.Errorf("failed to encode regions: %w", err)
+	}
+	insertStmt := "INSERT INTO " + quoteIdentifier(sqliteRunTable) + " VALUES (?, ?, ?, ?)"
+	if _, err := tx.ExecContext(ctx, insertStmt, meta.AccountID, string(regionsJSON), meta.StartedAt.UTC().Format(time.RFC3339), meta.Version); err != nil {
+		return fmt.Errorf("failed to insert run metadata: %w", err)
+	}
+	return nil
+}
+
+// createSQLiteResourcesTable creates the unified resources table and its lookup index.
+// ARNs are not unique across regions (global resources) and may be empty, so the
+// (arn, category, region) key is an index rather than a primary key.
+func createSQLiteResourcesTable(ctx context.Context, tx *sql.Tx) error {
+	table := quoteIdentifier(sqliteResourcesTable)
+	stmts := []string{
+		"CREATE TABLE " + table + " (arn TEXT NOT NULL, category TEXT NOT NULL, region TEXT NOT NULL," +
+			" sub_category1 TEXT NOT NULL, sub_category2 TEXT NOT NULL, sub_category3 TEXT NOT NULL," +
+			" name TEXT NOT NULL, raw_data TEXT NOT NULL, tags TEXT NOT NULL)",
+		"CREATE INDEX resources_arn_category_region ON " + table + " (arn, category, region)",
+	}
+	for _, stmt := range stmts {
+		if _, err := tx.ExecContext(ctx, stmt); err != nil {
+			return fmt.Errorf("failed to create resources table: %w", err)
+		}
+	}
+	return nil
+}
+
+// writeSQLiteCategory creates the table of one category and inserts its rows into
+// that table and into the resources table.
+func writeSQLiteCategory(ctx context.Context, tx *sql.Tx, category *CategoryResources) (err error) {
+	if category.Name == sqliteResourcesTable || category.Name == sqliteRunTable {
+		return fmt.Errorf("%w: %s", ErrReservedTableName, category.Name)
+	}
+	table := quoteIdentifier(category.Name)
+	columnNames := uniqueColumnNames(category.Columns)
+
+	defs := make([]string, 0, len(columnNames))
+	for _, name := range columnNames {
+		defs = append(defs, quoteIdentifier(name)+" TEXT")
+	}
+	if _, err = tx.ExecContext(ctx, "CREATE TABLE "+table+" ("+strings.Join(defs, ", ")+")"); err != nil { //nolint:gosec // G202 - identifiers are quoted with quoteIdentifier
+		return fmt.Errorf("failed to create table: %w", err)
+	}
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(columnNames)), ", ")
+	categoryStmt, err := tx.PrepareContext(ctx, "INSERT INTO "+table+" VALUES ("+placeholders+")") //nolint:gosec // G202 - identifiers are quoted with quoteIdentifier
+	if err != nil {
+		return fmt.Errorf("failed to prepare insert: %w", err)
+	}
+	defer func() {
+		err = closeAndJoin(err, categoryStmt, "failed to close statement")
+	}()
+	resourcesStmt, err := tx.PrepareContext(ctx, "INSERT INTO "+quoteIdentifier(sqliteResourcesTable)+" VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)")
+	if err != nil {
+		return fmt.Errorf("failed to prepare resources insert: %w", err)
+	}
+	defer func() {
+		err = closeAndJoin(err, resourcesStmt, "failed to close statement")
+	}()
+
+	values := make([]any, len(category.Columns))
+	for i := range category.Resources {
+		r := category.Resources[i]
+		for j, col := range category.Columns {
+			values[j] = col.Value(r)
+		}
+		if _, err = categoryStmt.ExecContext(ctx, values...); err != nil {
+			return fmt.Errorf("failed to insert row: %w", err)
+		}
+
+		rawData, marshalErr := json.Marshal(NewJSONResource(&r).RawData)
+		if marshalErr != nil {
+			return fmt.Errorf("failed to encode raw data of %s: %w", r.Name, marshalErr)
+		}
+		tags := r.Tags
+		if tags == nil {
+			tags = map[string]string{}
+		}
+		tagsJSON, marshalErr := json.Marshal(tags)
+		if marshalErr != nil {
+			return fmt.Errorf("failed to encode tags of %s: %w", r.Name, marshalErr)
+		}
+		if _, err = resourcesStmt.ExecContext(ctx, r.ARN, r.Category, r.Region, r.SubCategory1, r.SubCategory2, r.SubCategory3, r.Name, string(rawData), string(tagsJSON)); err != nil {
+			return fmt.Errorf("failed to insert resource: %w", err)
+		}
+	}
+	return nil
+}
+
+// uniqueColumnNames returns the column headers, suffixing repeated headers
+// with _2, _3, ... so they can be used as SQL column names.
+func uniqueColumnNames(columns []resources.Column) []string {
+	names := make([]string, 0, len(columns))
+	seen := make(map[string]int, len(columns))
+	for _, col := range columns {
+		key := strings.ToLower(col.Header)
+		seen[key]++
+		if n := seen[key]; n > 1 {
+			names = append(names, col.Header+"_"+strconv.Itoa(n))
+			continue
+		}
+		names = append(names, col.Header)
+	}
+	return names
+}
+
+// quoteIdentifier quotes an SQL identifier such as a table or column name.
+func quoteIdentifier(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+// ignoreTxDone drops sql.ErrTxDone, returned when rolling back a committed transaction.
+func ignoreTxDone(err error) error {
+	if errors.Is(err, sql.ErrTxDone) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,118 @@
+package exporter
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/y-miyazaki/arc/internal/aws/resources"
+)
+
+func TestWriteSQLite(t *testing.T) {
+	t.Parallel()
+
+	columns := []resources.Column{
+		{Header: "Category", Value: func(r resources.Resource) string { return r.Category }},
+		{Header: "Name", Value: func(r resources.Resource) string { return r.Name }},
+		{Header: "Role", Value: func(r resources.Resource) string { return r.RawData["Role"].(string) }},
+	}
+	categories := []CategoryResources{
+		{
+			Name:    "lambda",
+			Columns: columns,
+			Resources: []resources.Resource{
+				{
+					ARN:       "arn:aws:lambda:us-east-1:123456789012:function:api",
+					Category:  "lambda",
+					Name:      "api",
+					Region:    "us-east-1",
+					RawData:   map[string]any{"Role": "arn:aws:iam::123456789012:role/api"},
+					Tags:      map[string]string{"Owner": "team-a"},
+					TypedData: map[string]any{"Role": "arn:aws:iam::123456789012:role/api", "Layers": []any{"a", "b"}},
+				},
+			},
+		},
+		{Name: "ecs", Columns: columns},
+	}
+	meta := RunMetadata{
+		AccountID: "123456789012",
+		Regions:   []string{"ap-northeast-1", "us-east-1"},
+		StartedAt: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		Version:   "v1.2.3",
+	}
+
+	path := filepath.Join(t.TempDir(), "all.db")
+	require.NoError(t, WriteSQLite(context.Background(), path, meta, categories))
+
+	db, err := sql.Open("sqlite", path)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	var name, role string
+	require.NoError(t, db.QueryRow(`SELECT "Name", "Role" FROM "lambda"`).Scan(&name, &role))
+	assert.Equal(t, "api", name)
+	assert.Equal(t, "arn:aws:iam::123456789012:role/api", role)
+
+	var count int
+	require.NoError(t, db.QueryRow(`SELECT COUNT(*) FROM "ecs"`).Scan(&count))
+	assert.Zero(t, count)
+
+	var arn, layer, owner string
+	require.NoError(t, db.QueryRow(`SELECT arn, json_extract(raw_data, '$.Layers[1]'), json_extract(tags, '$.Owner') FROM resources WHERE category = 'lambda'`).Scan(&arn, &layer, &owner))
+	assert.Equal(t, "arn:aws:lambda:us-east-1:123456789012:function:api", arn)
+	assert.Equal(t, "b", layer)
+	assert.Equal(t, "team-a", owner)
+
+	var accountID, regions, startedAt, version string
+	require.NoError(t, db.QueryRow(`SELECT account_id, regions, started_at, arc_version FROM run`).Scan(&accountID, &regions, &startedAt, &version))
+	assert.Equal(t, "123456789012", accountID)
+	assert.JSONEq(t, `["ap-northeast-1","us-east-1"]`, regions)
+	assert.Equal(t, "2024-01-02T03:04:05Z", startedAt)
+	assert.Equal(t, "v1.2.3", version)
+}
+
+func TestWriteSQLite_ReplacesExistingDatabase(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "all.db")
+	require.NoError(t, WriteSQLite(context.Background(), path, RunMetadata{AccountID: "111111111111"}, nil))
+	require.NoError(t, WriteSQLite(context.Background(), path, RunMetadata{AccountID: "222222222222"}, nil))
+
+	db, err := sql.Open("sqlite", path)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	var accountID string
+	require.NoError(t, db.QueryRow(`SELECT account_id FROM run`).Scan(&accountID))
+	assert.Equal(t, "222222222222", accountID)
+}
+
+func TestWriteSQLite_ReservedCategoryName(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "all.db")
+	err := WriteSQLite(context.Background(), path, RunMetadata{}, []CategoryResources{{Name: "run"}})
+	assert.True(t, errors.Is(err, ErrReservedTableName), "err = %v", err)
+}
+
+func TestWriteSQLite_CanceledContext(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := WriteSQLite(ctx, filepath.Join(t.TempDir(), "all.db"), RunMetadata{}, nil)
+	assert.Error(t, err)
+}
+
+func TestUniqueColumnNames(t *testing.T) {
+	t.Parallel()
+
+	columns := []resources.Column{{Header: "Name"}, {Header: "Tags"}, {Header: "name"}, {Header: "Name"}}
+	assert.Equal(t, []string{"Name", "Tags", "name_2", "Name_3"}, uniqueColumnNames(columns))
+}
@@ -0,0 +1,189 @@
+package exporter
+
+import (
+	"crypto/sha512"
+	"embed"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// HTML viewer modes.
+const (
+	// HTMLModeServer loads the viewer libraries from their CDN and fetches
+	// files.json and the CSV files, so index.html must be served over HTTP.
+	HTMLModeServer = "server"
+	// HTMLModeStandalone inlines the vendored viewer libraries and the collected
+	// data into index.html, which then works offline when opened from disk.
+	HTMLModeStandalone = "standalone"
+)
+
+// HTMLModes lists the supported HTML viewer modes.
+var HTMLModes = []string{HTMLModeServer, HTMLModeStandalone}
+
+var (
+	ErrAssetIntegrity   = errors.New("html viewer asset does not match its integrity")
+	ErrAssetNotVendored = errors.New("html viewer asset is not vendored")
+	ErrUnknownHTMLMode  = errors.New("unknown html mode")
+)
+
+// vendoredAssets holds the viewer libraries inlined by HTMLModeStandalone.
+// They are downloaded from their CDN URL with `make assets`.
+//
+//go:embed assets
+var vendoredAssets embed.FS
+
+// htmlAsset is a library of the HTML viewer: a file of the assets directory
+// and the CDN URL it was downloaded from, with its subresource integrity.
+type htmlAsset struct {
+	file      string
+	url       string
+	integrity string
+}
+
+// viewerStylesheets and viewerScripts are the libraries of the HTML viewer, in load order.
+var (
+	viewerStylesheets = []htmlAsset{
+		{
+			file:      "datatables.min.css",
+			url:       "https://cdn.datatables.net/v/dt/jq-3.7.0/dt-2.3.5/fc-5.0.5/datatables.min.css",
+			integrity: "sha384-z5g7UelsNVivfOmTyEVMYwcn21IjJM7emyaFvT3ZWjrtqpBQaqjU7ta6Z9hxToSq",
+		},
+	}
+	viewerScripts = []htmlAsset{
+		{
+			file:      "papaparse.min.js",
+			url:       "https://cdn.jsdelivr.net/npm/papaparse@5.4.1/papaparse.min.js",
+			integrity: "sha384-D/t0ZMqQW31H3az8ktEiNb39wyKnS82iFY52QPACM+IjKW3jDUhyIgh2PApRqJZs",
+		},
+		{
+			// DataTables bundle includes jQuery, DataTables core and FixedColumns
+			file:      "datatables.min.js",
+			url:       "https://cdn.datatables.net/v/dt/jq-3.7.0/dt-2.3.5/fc-5.0.5/datatables.min.js",
+			integrity: "sha384-g8XKc+5kND0Ef1VsOnwwytKZhM9s75E87l8/e34JfeZNsA4Revs37btWYklE6ahc",
+		},
+	}
+)
+
+// viewerPage holds the fragments substituted into the viewer template: the
+// library tags of the head and body and the inlined data, if any.
+type viewerPage struct {
+	stylesheets string
+	scripts     string
+	data        string
+}
+
+// standaloneData is the collected data inlined into a standalone viewer: the
+// files.json entries and the contents of the files they list, by path.
+type standaloneData struct {
+	Files    []FileManifestEntry `json:"files"`
+	Contents map[string]string   `json:"contents"`
+}
+
+// newViewerPage returns the viewer fragments of mode. Standalone pages inline
+// the libraries of assets and the files of entries, read from accountDir.
+func newViewerPage(mode string, assets fs.FS, accountDir string, entries []FileManifestEntry) (*viewerPage, error) {
+	switch mode {
+	case "", HTMLModeServer:
+		var page viewerPage
+		for _, a := range viewerStylesheets {
+			page.stylesheets += fmt.Sprintf("<link href=\"%s\" rel=\"stylesheet\" integrity=\"%s\" crossorigin=\"anonymous\" />\n", a.url, a.integrity)
+		}
+		for _, a := range viewerScripts {
+			page.scripts += fmt.Sprintf("<script src=\"%s\" integrity=\"%s\" crossorigin=\"anonymous\"></script>\n", a.url, a.integrity)
+		}
+		return &page, nil
+	case HTMLModeStandalone:
+		return newStandalonePage(assets, accountDir, entries)
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownHTMLMode, mode)
+	}
+}
+
+// newStandalonePage returns the viewer fragments of HTMLModeStandalone.
+func newStandalonePage(assets fs.FS, accountDir string, entries []FileManifestEntry) (*viewerPage, error) {
+	var page viewerPage
+	for _, a := range viewerStylesheets {
+		content, err := readAsset(assets, a)
+		if err != nil {
+			return nil, err
+		}
+		page.stylesheets += "<style>\n" + escapeEndTag(content, "</style") + "\n</style>\n"
+	}
+	for _, a := range viewerScripts {
+		content, err := readAsset(assets, a)
+		if err != nil {
+			return nil, err
+		}
+		page.scripts += "<script>\n" + escapeEndTag(content, "</script") + "\n</script>\n"
+	}
+
+	data := standaloneData{Files: entries, Contents: make(map[string]string, len(entries))}
+	if data.Files == nil {
+		data.Files = []FileManifestEntry{}
+	}
+	for _, entry := range entries {
+		content, err := os.ReadFile(filepath.Join(accountDir, filepath.FromSlash(entry.Path))) //nolint:gosec // G304: Path is controlled and sanitized
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", entry.Path, err)
+		}
+		data.Contents[entry.Path] = string(content)
+	}
+	// json.Marshal escapes <, > and &, so the data cannot close the script element.
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode standalone data: %w", err)
+	}
+	page.data = "<script id=\"arcData\" type=\"application/json\">" + string(encoded) + "</script>\n"
+	return &page, nil
+}
+
+// CheckVendoredAssets checks that every viewer library inlined by
+// HTMLModeStandalone is vendored and matches the subresource integrity of the
+// server mode, so that a standalone run can fail before collecting anything.
+func CheckVendoredAssets() error {
+	assets, err := fs.Sub(vendoredAssets, "assets")
+	if err != nil {
+		return fmt.Errorf("failed to open vendored assets: %w", err)
+	}
+	return checkAssets(assets)
+}
+
+// checkAssets checks the viewer libraries of assets against their integrity.
+func checkAssets(assets fs.FS) error {
+	for _, a := range append(slices.Clone(viewerStylesheets), viewerScripts...) {
+		content, err := readAsset(assets, a)
+		if err != nil {
+			return err
+		}
+		sum := sha512.Sum384([]byte(content))
+		if got := "sha384-" + base64.StdEncoding.EncodeToString(sum[:]); got != a.integrity {
+			return fmt.Errorf("%w: %s has %s, want %s (download %s with `make assets`)", ErrAssetIntegrity, a.file, got, a.integrity, a.url)
+		}
+	}
+	return nil
+}
+
+// readAsset returns the content of the vendored asset a.
+func readAsset(assets fs.FS, a htmlAsset) (string, error) {
+	content, err := fs.ReadFile(assets, a.file)
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", fmt.Errorf("%w: %s (download %s with `make assets`)", ErrAssetNotVendored, a.file, a.url)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read asset %s: %w", a.file, err)
+	}
+	return string(content), nil
+}
+
+// escapeEndTag escapes the occurrences of the end tag in the content of a
+// script or style element, which would otherwise close the element early.
+func escapeEndTag(content, tag string) string {
+	return strings.ReplaceAll(content, tag, `<\/`+tag[2:])
+}
@@ -0,0 +1,151 @@
+package exporter
+
+import (
+	"context"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testAssets() fstest.MapFS {
+	return fstest.MapFS{
+		"datatables.min.css": {Data: []byte("table.dataTable{width:100%}")},
+		"datatables.min.js":  {Data: []byte(`var jQuery={};var s="</script>";`)},
+		"papaparse.min.js":   {Data: []byte("var Papa={};")},
+	}
+}
+
+func TestNewViewerPage_Server(t *testing.T) {
+	t.Parallel()
+
+	for _, mode := range []string{"", HTMLModeServer} {
+		page, err := newViewerPage(mode, testAssets(), t.TempDir(), nil)
+		require.NoError(t, err)
+		for _, a := range append(viewerStylesheets, viewerScripts...) {
+			assert.Contains(t, page.stylesheets+page.scripts, `"`+a.url+`"`)
+			assert.Contains(t, page.stylesheets+page.scripts, `integrity="`+a.integrity+`"`)
+		}
+		assert.Empty(t, page.data)
+	}
+}
+
+func TestNewViewerPage_Standalone(t *testing.T) {
+	t.Parallel()
+
+	accountDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(accountDir, "resources"), 0o755))
+	csv := "Category,Name\nec2,</script><script>alert(1)</script>\n"
+	require.NoError(t, os.WriteFile(filepath.Join(accountDir, "resources", "ec2.csv"), []byte(csv), 0o644))
+	entries := []FileManifestEntry{{Path: "resources/ec2.csv", DisplayName: "ec2"}}
+
+	page, err := newViewerPage(HTMLModeStandalone, testAssets(), accountDir, entries)
+	require.NoError(t, err)
+
+	assert.Equal(t, "<style>\ntable.dataTable{width:100%}\n</style>\n", page.stylesheets)
+	assert.Contains(t, page.scripts, "<script>\nvar Papa={};\n</script>\n")
+	assert.Contains(t, page.scripts, `var s="<\/script>";`)
+	assert.NotContains(t, page.scripts, "cdn.")
+
+	// The data must not close its script element early.
+	body, ok := strings.CutPrefix(page.data, `<script id="arcData" type="application/json">`)
+	require.True(t, ok)
+	body, ok = strings.CutSuffix(body, "</script>\n")
+	require.True(t, ok)
+	assert.NotContains(t, body, "</script")
+	var data standaloneData
+	require.NoError(t, json.Unmarshal([]byte(body), &data))
+	assert.Equal(t, standaloneData{Files: entries, Contents: map[string]string{"resources/ec2.csv": csv}}, data)
+}
+
+func TestNewViewerPage_Errors(t *testing.T) {
+	t.Parallel()
+
+	assets := testAssets()
+	delete(assets, "papaparse.min.js")
+	_, err := newViewerPage(HTMLModeStandalone, assets, t.TempDir(), nil)
+	require.ErrorIs(t, err, ErrAssetNotVendored)
+	assert.Contains(t, err.Error(), "papaparse.min.js")
+
+	_, err = newViewerPage(HTMLModeStandalone, testAssets(), t.TempDir(), []FileManifestEntry{{Path: "resources/missing.csv"}})
+	require.ErrorIs(t, err, fs.ErrNotExist)
+
+	_, err = newViewerPage("inline", testAssets(), t.TempDir(), nil)
+	require.ErrorIs(t, err, ErrUnknownHTMLMode)
+}
+
+func TestGenerateIndexHTML_Standalone(t *testing.T) {
+	t.Parallel()
+
+	page := &viewerPage{
+		stylesheets: "<style>\n.x{}\n</style>\n",
+		scripts:     "<script>\nvar Papa={};\n</script>\n",
+		data:        `<script id="arcData" type="application/json">{"files":[],"contents":{"a.csv":"@@ACCOUNT_ID@@"}}</script>`,
+	}
+	indexPath := filepath.Join(t.TempDir(), "index.html")
+	require.NoError(t, generateIndexHTML(context.Background(), indexPath, "123456789012", "", "all.csv", page))
+	b, err := os.ReadFile(indexPath)
+	require.NoError(t, err)
+
+	got := string(b)
+	assert.Contains(t, got, page.stylesheets)
+	assert.Contains(t, got, page.scripts)
+	// Placeholders in the inlined data are kept as is.
+	assert.Contains(t, got, `"a.csv":"@@ACCOUNT_ID@@"`)
+	assert.NotContains(t, got, "@@STYLESHEETS@@")
+	assert.NotContains(t, got, "@@SCRIPTS@@")
+	assert.NotContains(t, got, "https://cdn.")
+}
+
+// TestVendoredAssets checks the vendored assets against the integrity of
+// their CDN URL. Assets that are not vendored are skipped.
+func TestVendoredAssets(t *testing.T) {
+	t.Parallel()
+
+	assets, err := fs.Sub(vendoredAssets, "assets")
+	require.NoError(t, err)
+	for _, a := range append(viewerStylesheets, viewerScripts...) {
+		t.Run(a.file, func(t *testing.T) {
+			t.Parallel()
+			content, readErr := fs.ReadFile(assets, a.file)
+			require.NoError(t, readErr, "%s must be vendored, run make assets and commit it", a.file)
+			sum := sha512.Sum384(content)
+			assert.Equal(t, a.integrity, "sha384-"+base64.StdEncoding.EncodeToString(sum[:]))
+		})
+	}
+	require.NoError(t, CheckVendoredAssets())
+}
+
+func TestCheckAssets(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		assets  fstest.MapFS
+		wantErr error
+	}{
+		{name: "missing asset", assets: fstest.MapFS{}, wantErr: ErrAssetNotVendored},
+		{
+			name: "modified asset",
+			assets: fstest.MapFS{
+				viewerStylesheets[0].file: {Data: []byte("body{}")},
+			},
+			wantErr: ErrAssetIntegrity,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			require.ErrorIs(t, checkAssets(tt.assets), tt.wantErr)
+		})
+	}
+}
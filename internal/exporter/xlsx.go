@@ -0,0 +1,192 @@
+package exporter
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"unicode/utf8"
+
+	"github.com/xuri/excelize/v2"
+)
+
+const (
+	// XLSXSummarySheet is the name of the first worksheet, listing resource counts per category and region.
+	XLSXSummarySheet = "Summary"
+	// xlsxMaxCellChars is the maximum number of characters Excel accepts in a cell.
+	xlsxMaxCellChars = 32767
+	// xlsxMaxSheetNameChars is the maximum length of an Excel worksheet name.
+	xlsxMaxSheetNameChars = 31
+	// xlsxTruncatedSuffix is appended to cell values cut to xlsxMaxCellChars.
+	xlsxTruncatedSuffix = "...(truncated)"
+)
+
+// WriteXLSX writes the categories as an Excel workbook: a summary sheet with
+// resource counts per category and region, followed by one worksheet per category
+// in the given order. Each category sheet has a frozen header row, an auto-filter
+// over its columns and wrapped text so multi-line values stay readable.
+func WriteXLSX(w io.Writer, categories []CategoryResources) (err error) {
+	f := excelize.NewFile()
+	defer func() {
+		err = closeAndJoin(err, f, "failed to close workbook")
+	}()
+
+	styles, err := newXLSXStyles(f)
+	if err != nil {
+		return err
+	}
+
+	defaultSheet := f.GetSheetName(0)
+	if err = f.SetSheetName(defaultSheet, XLSXSummarySheet); err != nil {
+		return fmt.Errorf("failed to rename summary sheet: %w", err)
+	}
+	if err = writeXLSXSummary(f, styles, categories); err != nil {
+		return err
+	}
+
+	for i := range categories {
+		if err = writeXLSXCategory(f, styles, &categories[i]); err != nil {
+			return fmt.Errorf("failed to write sheet %s: %w", categories[i].Name, err)
+		}
+	}
+
+	f.SetActiveSheet(0)
+	if _, err = f.WriteTo(w); err != nil {
+		return fmt.Errorf("failed to write workbook: %w", err)
+	}
+	return nil
+}
+
+// xlsxStyles holds the style IDs shared by all worksheets.
+type xlsxStyles struct {
+	header int
+	body   int
+}
+
+func newXLSXStyles(f *excelize.File) (xlsxStyles, error) {
+	header, err := f.NewStyle(&excelize.Style{
+		Font:      &excelize.Font{Bold: true},
+		Fill:      excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{"D9E1F2"}},
+		Alignment: &excelize.Alignment{Vertical: "top"},
+	})
+	if err != nil {
+		return xlsxStyles{}, fmt.Errorf("failed to create header style: %w", err)
+	}
+	body, err := f.NewStyle(&excelize.Style{
+		Alignment: &excelize.Alignment{Vertical: "top", WrapText: true},
+	})
+	if err != nil {
+		return xlsxStyles{}, fmt.Errorf("failed to create body style: %w", err)
+	}
+	return xlsxStyles{header: header, body: body}, nil
+}
+
+// writeXLSXSummary writes the number of resources per category and region, sorted by both.
+func writeXLSXSummary(f *excelize.File, styles xlsxStyles, categories []CategoryResources) error {
+	rows := [][]any{{"Category", "Region", "Count"}}
+	for i := range categories {
+		counts := make(map[string]int)
+		for j := range categories[i].Resources {
+			counts[categories[i].Resources[j].Region]++
+		}
+		regions := make([]string, 0, len(counts))
+		for region := range counts {
+			regions = append(regions, region)
+		}
+		slices.Sort(regions)
+		for _, region := range regions {
+			rows = append(rows, []any{categories[i].Name, region, counts[region]})
+		}
+	}
+
+	if err := writeXLSXRows(f, XLSXSummarySheet, styles, rows); err != nil {
+		return fmt.Errorf("failed to write summary sheet: %w", err)
+	}
+	return nil
+}
+
+// writeXLSXCategory adds the worksheet of one category using its collector columns.
+func writeXLSXCategory(f *excelize.File, styles xlsxStyles, category *CategoryResources) error {
+	sheet := xlsxSheetName(category.Name)
+	if _, err := f.NewSheet(sheet); err != nil {
+		return fmt.Errorf("failed to create sheet: %w", err)
+	}
+
+	header := make([]any, 0, len(category.Columns))
+	for _, col := range category.Columns {
+		header = append(header, col.Header)
+	}
+	rows := make([][]any, 0, len(category.Resources)+1)
+	rows = append(rows, header)
+	for i := range category.Resources {
+		row := make([]any, 0, len(category.Columns))
+		for _, col := range category.Columns {
+			row = append(row, truncateXLSXCell(col.Value(category.Resources[i])))
+		}
+		rows = append(rows, row)
+	}
+	return writeXLSXRows(f, sheet, styles, rows)
+}
+
+// writeXLSXRows writes rows to sheet, treating the first row as the header:
+// the header row is styled and frozen and an auto-filter is added over all rows.
+func writeXLSXRows(f *excelize.File, sheet string, styles xlsxStyles, rows [][]any) error {
+	if len(rows) == 0 || len(rows[0]) == 0 {
+		return nil
+	}
+	for i := range rows {
+		cell, err := excelize.CoordinatesToCellName(1, i+1)
+		if err != nil {
+			return fmt.Errorf("failed to resolve cell: %w", err)
+		}
+		if err := f.SetSheetRow(sheet, cell, &rows[i]); err != nil {
+			return fmt.Errorf("failed to write row %d: %w", i+1, err)
+		}
+	}
+
+	lastCell, err := excelize.CoordinatesToCellName(len(rows[0]), len(rows))
+	if err != nil {
+		return fmt.Errorf("failed to resolve cell: %w", err)
+	}
+	headerEnd, err := excelize.CoordinatesToCellName(len(rows[0]), 1)
+	if err != nil {
+		return fmt.Errorf("failed to resolve cell: %w", err)
+	}
+	if err := f.SetCellStyle(sheet, "A1", headerEnd, styles.header); err != nil {
+		return fmt.Errorf("failed to style header: %w", err)
+	}
+	if len(rows) > 1 {
+		if err := f.SetCellStyle(sheet, "A2", lastCell, styles.body); err != nil {
+			return fmt.Errorf("failed to style rows: %w", err)
+		}
+	}
+	if err := f.SetPanes(sheet, &excelize.Panes{
+		Freeze:      true,
+		YSplit:      1,
+		TopLeftCell: "A2",
+		ActivePane:  "bottomLeft",
+	}); err != nil {
+		return fmt.Errorf("failed to freeze header: %w", err)
+	}
+	if err := f.AutoFilter(sheet, "A1:"+lastCell, nil); err != nil {
+		return fmt.Errorf("failed to add auto filter: %w", err)
+	}
+	return nil
+}
+
+// xlsxSheetName returns name cut to the worksheet name length limit.
+// Category names never contain characters Excel rejects in sheet names.
+func xlsxSheetName(name string) string {
+	if utf8.RuneCountInString(name) <= xlsxMaxSheetNameChars {
+		return name
+	}
+	return string([]rune(name)[:xlsxMaxSheetNameChars])
+}
+
+// truncateXLSXCell cuts values longer than Excel's cell limit, such as large policy documents.
+func truncateXLSXCell(value string) string {
+	if utf8.RuneCountInString(value) <= xlsxMaxCellChars {
+		return value
+	}
+	keep := xlsxMaxCellChars - utf8.RuneCountInString(xlsxTruncatedSuffix)
+	return string([]rune(value)[:keep]) + xlsxTruncatedSuffix
+}
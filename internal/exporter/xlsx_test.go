@@ -0,0 +1,107 @@
+package exporter
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xuri/excelize/v2"
+
+	"github.com/y-miyazaki/arc/internal/aws/resources"
+)
+
+func TestWriteXLSX(t *testing.T) {
+	t.Parallel()
+
+	columns := []resources.Column{
+		{Header: "Name", Value: func(r resources.Resource) string { return r.Name }},
+		{Header: "Region", Value: func(r resources.Resource) string { return r.Region }},
+	}
+	categories := []CategoryResources{
+		{
+			Name:    "ec2",
+			Columns: columns,
+			Resources: []resources.Resource{
+				{Name: "web\nsecondary", Region: "us-east-1"},
+				{Name: "batch", Region: "ap-northeast-1"},
+				{Name: "api", Region: "ap-northeast-1"},
+			},
+		},
+		{
+			Name:      "s3_bucket",
+			Columns:   columns,
+			Resources: []resources.Resource{{Name: "logs", Region: "us-east-1"}},
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteXLSX(&buf, categories))
+
+	f, err := excelize.OpenReader(&buf)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = f.Close() })
+
+	assert.Equal(t, []string{XLSXSummarySheet, "ec2", "s3_bucket"}, f.GetSheetList())
+
+	summary, err := f.GetRows(XLSXSummarySheet)
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{"Category", "Region", "Count"},
+		{"ec2", "ap-northeast-1", "2"},
+		{"ec2", "us-east-1", "1"},
+		{"s3_bucket", "us-east-1", "1"},
+	}, summary)
+
+	rows, err := f.GetRows("ec2")
+	require.NoError(t, err)
+	require.Len(t, rows, 4)
+	assert.Equal(t, []string{"Name", "Region"}, rows[0])
+	assert.Equal(t, []string{"web\nsecondary", "us-east-1"}, rows[1])
+
+	panes, err := f.GetPanes("ec2")
+	require.NoError(t, err)
+	assert.True(t, panes.Freeze)
+	assert.Equal(t, 1, panes.YSplit)
+
+	styleID, err := f.GetCellStyle("ec2", "A2")
+	require.NoError(t, err)
+	style, err := f.GetStyle(styleID)
+	require.NoError(t, err)
+	require.NotNil(t, style.Alignment)
+	assert.True(t, style.Alignment.WrapText)
+}
+
+func TestWriteXLSX_Empty(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteXLSX(&buf, nil))
+
+	f, err := excelize.OpenReader(&buf)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = f.Close() })
+
+	assert.Equal(t, []string{XLSXSummarySheet}, f.GetSheetList())
+	summary, err := f.GetRows(XLSXSummarySheet)
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{{"Category", "Region", "Count"}}, summary)
+}
+
+func TestTruncateXLSXCell(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "short", truncateXLSXCell("short"))
+
+	got := truncateXLSXCell(strings.Repeat("x", xlsxMaxCellChars+10))
+	assert.Len(t, got, xlsxMaxCellChars)
+	assert.True(t, strings.HasSuffix(got, xlsxTruncatedSuffix))
+}
+
+func TestXLSXSheetName(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "ec2", xlsxSheetName("ec2"))
+	assert.Len(t, xlsxSheetName(strings.Repeat("a", 40)), xlsxMaxSheetNameChars)
+}
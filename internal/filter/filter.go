@@ -0,0 +1,229 @@
+// Package filter selects collected resources by tag, name/ARN pattern and
+// SubCategory1 before they are written.
+package filter
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/y-miyazaki/arc/internal/aws/resources"
+)
+
+// RegexPrefix marks a name pattern as a regular expression instead of a glob.
+const RegexPrefix = "re:"
+
+// Sentinel errors for filter options (alphabetical order).
+var (
+	ErrInvalidPattern     = errors.New("invalid name pattern")
+	ErrInvalidSubCategory = errors.New("invalid sub category filter")
+	ErrInvalidTagFilter   = errors.New("invalid tag filter")
+)
+
+// Options lists the filter conditions. Empty lists do not filter.
+type Options struct {
+	// ExcludeNames drops resources whose Name or ARN matches any pattern.
+	ExcludeNames []string
+	// ExcludeTags drops resources matching any "key" or "key=value" condition.
+	ExcludeTags []string
+	// IncludeNames keeps only resources whose Name or ARN matches at least one pattern.
+	IncludeNames []string
+	// IncludeTags keeps only resources matching the "key" or "key=value" conditions.
+	// Conditions on different keys must all match; values of the same key are alternatives.
+	IncludeTags []string
+	// SubCategories keeps only resources whose SubCategory1 is listed, either as
+	// "SubCategory1" for every category or "category:SubCategory1" for one category.
+	SubCategories []string
+}
+
+// Filter is a compiled set of include and exclude conditions.
+// Names and tag values are matched case-sensitively. Glob patterns support *
+// (any sequence, including /) and ? (any single character).
+type Filter struct {
+	excludeNames  []*regexp.Regexp
+	excludeTags   []tagCondition
+	includeNames  []*regexp.Regexp
+	includeTags   map[string][]*regexp.Regexp
+	subCategories []subCategory
+}
+
+// tagCondition matches a tag key and, when value is set, its value.
+type tagCondition struct {
+	value *regexp.Regexp
+	key   string
+}
+
+// subCategory is a SubCategory1 selection, optionally limited to one category.
+type subCategory struct {
+	category string
+	name     string
+}
+
+// New compiles opts into a Filter. It returns nil when opts has no condition.
+func New(opts *Options) (*Filter, error) {
+	if len(opts.ExcludeNames)+len(opts.ExcludeTags)+len(opts.IncludeNames)+len(opts.IncludeTags)+len(opts.SubCategories) == 0 {
+		return nil, nil //nolint:nilnil // a nil Filter keeps every resource
+	}
+
+	f := &Filter{}
+	var err error
+	if f.includeNames, err = compilePatterns(opts.IncludeNames); err != nil {
+		return nil, err
+	}
+	if f.excludeNames, err = compilePatterns(opts.ExcludeNames); err != nil {
+		return nil, err
+	}
+	includeTags, err := parseTagConditions(opts.IncludeTags)
+	if err != nil {
+		return nil, err
+	}
+	if len(includeTags) > 0 {
+		f.includeTags = make(map[string][]*regexp.Regexp)
+		for _, cond := range includeTags {
+			f.includeTags[cond.key] = append(f.includeTags[cond.key], cond.value)
+		}
+	}
+	if f.excludeTags, err = parseTagConditions(opts.ExcludeTags); err != nil {
+		return nil, err
+	}
+	for _, s := range opts.SubCategories {
+		category, name, found := strings.Cut(s, ":")
+		if !found {
+			category, name = "", s
+		}
+		if name == "" {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidSubCategory, s)
+		}
+		f.subCategories = append(f.subCategories, subCategory{category: category, name: name})
+	}
+	return f, nil
+}
+
+// Apply returns the resources of category that pass the filter, keeping their order.
+// Rows with an empty SubCategory1 are detail rows of the preceding resource (for
+// example the record sets of a hosted zone) and are kept or dropped together with it.
+// A nil Filter returns res unchanged.
+func (f *Filter) Apply(category string, res []resources.Resource) []resources.Resource {
+	if f == nil {
+		return res
+	}
+	out := make([]resources.Resource, 0, len(res))
+	keep := false
+	hasParent := false
+	for i := range res {
+		r := &res[i]
+		if r.SubCategory1 != "" || !hasParent {
+			keep = f.Match(category, r)
+			hasParent = r.SubCategory1 != ""
+		}
+		if keep {
+			out = append(out, *r)
+		}
+	}
+	return out
+}
+
+// Match reports whether a single resource of category passes the filter.
+func (f *Filter) Match(category string, r *resources.Resource) bool {
+	if f == nil {
+		return true
+	}
+	if len(f.subCategories) > 0 && !f.matchSubCategory(category, r.SubCategory1) {
+		return false
+	}
+	if len(f.includeNames) > 0 && !matchAnyPattern(f.includeNames, r) {
+		return false
+	}
+	if len(f.excludeNames) > 0 && matchAnyPattern(f.excludeNames, r) {
+		return false
+	}
+	for key, values := range f.includeTags {
+		if !matchTag(r.Tags, key, values) {
+			return false
+		}
+	}
+	for _, cond := range f.excludeTags {
+		if matchTag(r.Tags, cond.key, []*regexp.Regexp{cond.value}) {
+			return false
+		}
+	}
+	return true
+}
+
+func (f *Filter) matchSubCategory(category, name string) bool {
+	for _, s := range f.subCategories {
+		if (s.category == "" || s.category == category) && s.name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// matchAnyPattern reports whether the Name or the ARN of r matches one of patterns.
+func matchAnyPattern(patterns []*regexp.Regexp, r *resources.Resource) bool {
+	for _, p := range patterns {
+		if p.MatchString(r.Name) || (r.ARN != "" && p.MatchString(r.ARN)) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchTag reports whether tags has key with a value matching one of values.
+// A nil value pattern matches any value.
+func matchTag(tags map[string]string, key string, values []*regexp.Regexp) bool {
+	value, ok := tags[key]
+	if !ok {
+		return false
+	}
+	for _, v := range values {
+		if v == nil || v.MatchString(value) {
+			return true
+		}
+	}
+	return false
+}
+
+// compilePatterns compiles name patterns: "re:<regexp>" is a regular expression
+// (unanchored) and anything else is a glob matched against the whole value.
+func compilePatterns(patterns []string) ([]*regexp.Regexp, error) {
+	compiled := make([]*regexp.Regexp, 0, len(patterns))
+	for _, p := range patterns {
+		expr, isRegex := strings.CutPrefix(p, RegexPrefix)
+		if !isRegex {
+			expr = globToRegexp(p)
+		}
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q: %w", ErrInvalidPattern, p, err)
+		}
+		compiled = append(compiled, re)
+	}
+	return compiled, nil
+}
+
+// parseTagConditions parses "key" and "key=value" conditions. Values are globs.
+func parseTagConditions(conditions []string) ([]tagCondition, error) {
+	parsed := make([]tagCondition, 0, len(conditions))
+	for _, c := range conditions {
+		key, value, hasValue := strings.Cut(c, "=")
+		if key == "" {
+			return nil, fmt.Errorf("%w: %q (expected key or key=value)", ErrInvalidTagFilter, c)
+		}
+		cond := tagCondition{key: key}
+		if hasValue {
+			cond.value = regexp.MustCompile(globToRegexp(value))
+		}
+		parsed = append(parsed, cond)
+	}
+	return parsed, nil
+}
+
+// globToRegexp converts a glob with * and ? wildcards to an anchored regular expression.
+func globToRegexp(glob string) string {
+	expr := regexp.QuoteMeta(glob)
+	expr = strings.ReplaceAll(expr, `\*`, ".*")
+	expr = strings.ReplaceAll(expr, `\?`, ".")
+	return "^" + expr + "$"
+}
@@ -0,0 +1,145 @@
+package filter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/y-miyazaki/arc/internal/aws/resources"
+)
+
+func names(res []resources.Resource) []string {
+	out := make([]string, 0, len(res))
+	for i := range res {
+		out = append(out, res[i].Name)
+	}
+	return out
+}
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		opts    Options
+		wantNil bool
+		wantErr error
+	}{
+		{name: "no conditions", opts: Options{}, wantNil: true},
+		{name: "valid conditions", opts: Options{IncludeTags: []string{"Team=payments"}, IncludeNames: []string{"re:^api-"}, SubCategories: []string{"ecs:TaskDefinition"}}},
+		{name: "invalid regex", opts: Options{IncludeNames: []string{"re:("}}, wantErr: ErrInvalidPattern},
+		{name: "empty tag key", opts: Options{ExcludeTags: []string{"=prod"}}, wantErr: ErrInvalidTagFilter},
+		{name: "empty sub category", opts: Options{SubCategories: []string{"ecs:"}}, wantErr: ErrInvalidSubCategory},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			f, err := New(&tt.opts)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantNil, f == nil)
+		})
+	}
+}
+
+func TestFilter_Match(t *testing.T) {
+	t.Parallel()
+
+	payments := resources.Resource{
+		Name:         "api-payments",
+		ARN:          "arn:aws:lambda:us-east-1:123456789012:function:api-payments",
+		SubCategory1: "Function",
+		Tags:         map[string]string{"Team": "payments", "Env": "prod"},
+	}
+	untagged := resources.Resource{Name: "legacy-batch", SubCategory1: "Function"}
+
+	tests := []struct {
+		name     string
+		opts     Options
+		category string
+		resource resources.Resource
+		want     bool
+	}{
+		{name: "tag key and value", opts: Options{IncludeTags: []string{"Team=payments"}}, resource: payments, want: true},
+		{name: "tag value glob", opts: Options{IncludeTags: []string{"Env=pr*"}}, resource: payments, want: true},
+		{name: "tag key only", opts: Options{IncludeTags: []string{"Team"}}, resource: payments, want: true},
+		{name: "tag value mismatch", opts: Options{IncludeTags: []string{"Team=billing"}}, resource: payments, want: false},
+		{name: "same key values are alternatives", opts: Options{IncludeTags: []string{"Team=billing", "Team=payments"}}, resource: payments, want: true},
+		{name: "different keys must all match", opts: Options{IncludeTags: []string{"Team=payments", "Env=dev"}}, resource: payments, want: false},
+		{name: "untagged resource is not included", opts: Options{IncludeTags: []string{"Team"}}, resource: untagged, want: false},
+		{name: "exclude tag", opts: Options{ExcludeTags: []string{"Env=prod"}}, resource: payments, want: false},
+		{name: "exclude tag keeps untagged", opts: Options{ExcludeTags: []string{"Env"}}, resource: untagged, want: true},
+		{name: "name glob", opts: Options{IncludeNames: []string{"api-*"}}, resource: payments, want: true},
+		{name: "glob is anchored", opts: Options{IncludeNames: []string{"payments"}}, resource: payments, want: false},
+		{name: "arn glob across slashes", opts: Options{IncludeNames: []string{"arn:aws:lambda:*:function:*"}}, resource: payments, want: true},
+		{name: "name regex", opts: Options{IncludeNames: []string{"re:pay(ments)?$"}}, resource: payments, want: true},
+		{name: "exclude name", opts: Options{ExcludeNames: []string{"legacy-?atch"}}, resource: untagged, want: false},
+		{name: "sub category", opts: Options{SubCategories: []string{"Function"}}, category: "lambda", resource: payments, want: true},
+		{name: "sub category of category", opts: Options{SubCategories: []string{"lambda:Function"}}, category: "lambda", resource: payments, want: true},
+		{name: "sub category of other category", opts: Options{SubCategories: []string{"ecs:Function"}}, category: "lambda", resource: payments, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			f, err := New(&tt.opts)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, f.Match(tt.category, &tt.resource))
+		})
+	}
+}
+
+func TestFilter_Apply(t *testing.T) {
+	t.Parallel()
+
+	res := []resources.Resource{
+		{Name: "cluster-a", SubCategory1: "Cluster", Tags: map[string]string{"Team": "payments"}},
+		{Name: "service-a", SubCategory2: "Service"},
+		{Name: "cluster-b", SubCategory1: "Cluster", Tags: map[string]string{"Team": "search"}},
+		{Name: "service-b", SubCategory2: "Service"},
+		{Name: "taskdef-a", SubCategory1: "TaskDefinition", Tags: map[string]string{"Team": "payments"}},
+	}
+
+	tests := []struct {
+		name string
+		opts Options
+		want []string
+	}{
+		{name: "detail rows follow their parent", opts: Options{IncludeTags: []string{"Team=payments"}}, want: []string{"cluster-a", "service-a", "taskdef-a"}},
+		{name: "sub category selection", opts: Options{SubCategories: []string{"ecs:TaskDefinition"}}, want: []string{"taskdef-a"}},
+		{name: "exclude parent drops detail rows", opts: Options{ExcludeNames: []string{"cluster-b"}}, want: []string{"cluster-a", "service-a", "taskdef-a"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			f, err := New(&tt.opts)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, names(f.Apply("ecs", res)))
+		})
+	}
+}
+
+func TestFilter_Apply_Nil(t *testing.T) {
+	t.Parallel()
+
+	var f *Filter
+	res := []resources.Resource{{Name: "a"}, {Name: "b"}}
+	assert.Equal(t, res, f.Apply("ecs", res))
+	assert.True(t, f.Match("ecs", &res[0]))
+}
+
+func TestFilter_Apply_DetailRowsWithoutParent(t *testing.T) {
+	t.Parallel()
+
+	f, err := New(&Options{IncludeNames: []string{"keep-*"}})
+	require.NoError(t, err)
+
+	res := []resources.Resource{{Name: "keep-1"}, {Name: "drop-1"}, {Name: "keep-2"}}
+	assert.Equal(t, []string{"keep-1", "keep-2"}, names(f.Apply("vpc", res)))
+}
@@ -0,0 +1,205 @@
+package findings
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/y-miyazaki/arc/internal/aws/helpers"
+	"github.com/y-miyazaki/arc/internal/aws/resources"
+)
+
+// Public grantees of S3 ACLs.
+const (
+	allUsersURI           = "http://acs.amazonaws.com/groups/global/AllUsers"
+	authenticatedUsersURI = "http://acs.amazonaws.com/groups/global/AuthenticatedUsers"
+)
+
+// adminPorts are the remote administration ports that must not be open to the internet.
+var adminPorts = map[int]string{22: "SSH", 3389: "RDP"}
+
+// openCIDRs are the CIDR blocks matching any address.
+var openCIDRs = []string{"0.0.0.0/0", "::/0"}
+
+// s3PublicAccessBlockKeys are the RawData keys of the S3 Block Public Access settings.
+var s3PublicAccessBlockKeys = []string{"PABBlockPublicACLs", "PABIgnorePublicACLs", "PABBlockPublicPolicy", "PABRestrictPublicBuckets"}
+
+// Builtin returns the built-in rules.
+func Builtin() []Rule {
+	return []Rule{
+		{
+			ID:       "dynamodb-pitr-disabled",
+			Title:    "DynamoDB table without point-in-time recovery",
+			Severity: SeverityMedium,
+			Category: "dynamodb",
+			Check: func(r *resources.Resource) string {
+				if r.SubCategory1 != "Table" || rawString(r, "PointInTimeRecovery") == "ENABLED" {
+					return ""
+				}
+				return "PointInTimeRecovery=" + rawOrNotAvailable(r, "PointInTimeRecovery")
+			},
+		},
+		{
+			ID:       "efs-unencrypted",
+			Title:    "EFS file system not encrypted at rest",
+			Severity: SeverityHigh,
+			Category: "efs",
+			Check:    boolCheck("FileSystem", "Encrypted"),
+		},
+		{
+			ID:       "rds-unencrypted",
+			Title:    "RDS database storage not encrypted",
+			Severity: SeverityHigh,
+			Category: "rds",
+			Check: func(r *resources.Resource) string {
+				// Only encrypted DB clusters and instances have a KMS key.
+				if (r.SubCategory1 != "DBCluster" && r.SubCategory1 != "DBInstance") || rawString(r, "KmsKey") != "" {
+					return ""
+				}
+				return "KmsKey=" + helpers.NotAvailable
+			},
+		},
+		{
+			ID:       "redshift-unencrypted",
+			Title:    "Redshift cluster not encrypted",
+			Severity: SeverityHigh,
+			Category: "redshift",
+			Check:    boolCheck("Cluster", "Encrypted"),
+		},
+		{
+			ID:       "s3-public-access-block",
+			Title:    "S3 bucket without full Block Public Access",
+			Severity: SeverityMedium,
+			Category: "s3_bucket",
+			Check: func(r *resources.Resource) string {
+				if r.SubCategory1 != "Bucket" {
+					return ""
+				}
+				var disabled []string
+				for _, key := range s3PublicAccessBlockKeys {
+					if rawString(r, key) != "true" {
+						disabled = append(disabled, key+"="+rawOrNotAvailable(r, key))
+					}
+				}
+				return strings.Join(disabled, ", ")
+			},
+		},
+		{
+			ID:       "s3-public-acl",
+			Title:    "S3 bucket ACL grants access to everyone",
+			Severity: SeverityCritical,
+			Category: "s3_bucket",
+			Check: func(r *resources.Resource) string {
+				if r.SubCategory1 != "Bucket" {
+					return ""
+				}
+				var public []string
+				for grant := range strings.Lines(rawString(r, "ACL")) {
+					grant = strings.TrimSpace(grant)
+					if strings.Contains(grant, allUsersURI) || strings.Contains(grant, authenticatedUsersURI) {
+						public = append(public, grant)
+					}
+				}
+				return strings.Join(public, ", ")
+			},
+		},
+		{
+			ID:       "s3-unencrypted",
+			Title:    "S3 bucket without default encryption",
+			Severity: SeverityHigh,
+			Category: "s3_bucket",
+			Check: func(r *resources.Resource) string {
+				if r.SubCategory1 != "Bucket" || rawString(r, "Encryption") != "None" {
+					return ""
+				}
+				return "Encryption=None"
+			},
+		},
+		{
+			ID:       "secretsmanager-rotation-disabled",
+			Title:    "Secrets Manager secret without automatic rotation",
+			Severity: SeverityMedium,
+			Category: "secretsmanager",
+			Check: func(r *resources.Resource) string {
+				if r.SubCategory1 != "Secret" || rawString(r, "RotationEnabled") == "true" {
+					return ""
+				}
+				return "RotationEnabled=" + rawOrNotAvailable(r, "RotationEnabled")
+			},
+		},
+		{
+			ID:       "vpc-sg-open-admin-port",
+			Title:    "Security group allows administration ports from the internet",
+			Severity: SeverityCritical,
+			Category: "vpc",
+			Check: func(r *resources.Resource) string {
+				if r.SubCategory2 != "SecurityGroup" {
+					return ""
+				}
+				var open []string
+				for rule := range strings.Lines(rawString(r, "Inbound")) {
+					rule = strings.TrimSpace(rule)
+					if service, ok := opensAdminPort(rule); ok {
+						open = append(open, fmt.Sprintf("%s (%s)", rule, service))
+					}
+				}
+				return strings.Join(open, ", ")
+			},
+		},
+	}
+}
+
+// boolCheck returns a check failing resources of subCategory1 whose boolean RawData key is "false".
+func boolCheck(subCategory1, key string) func(r *resources.Resource) string {
+	return func(r *resources.Resource) string {
+		if r.SubCategory1 != subCategory1 || rawString(r, key) != "false" {
+			return ""
+		}
+		return key + "=false"
+	}
+}
+
+// opensAdminPort reports whether a formatted security group rule
+// ("Protocol: tcp | FromPort: 22 | ToPort: 22 | CIDR: 0.0.0.0/0") allows an
+// administration port from any address, and returns the service name.
+func opensAdminPort(rule string) (string, bool) {
+	fields := make(map[string]string)
+	for part := range strings.SplitSeq(rule, "|") {
+		key, value, found := strings.Cut(part, ":")
+		if found {
+			fields[strings.TrimSpace(key)] = strings.TrimSpace(value)
+		}
+	}
+	if !slices.Contains(openCIDRs, fields["CIDR"]) {
+		return "", false
+	}
+	protocol := fields["Protocol"]
+	if protocol == "-1" {
+		return "all traffic", true
+	}
+	if protocol != "tcp" && protocol != "6" {
+		return "", false
+	}
+	from, fromErr := strconv.Atoi(fields["FromPort"])
+	to, toErr := strconv.Atoi(fields["ToPort"])
+	if fromErr != nil || toErr != nil {
+		return "", false
+	}
+	var services []string
+	for _, port := range slices.Sorted(maps.Keys(adminPorts)) {
+		if from <= port && port <= to {
+			services = append(services, adminPorts[port])
+		}
+	}
+	return strings.Join(services, ", "), len(services) > 0
+}
+
+// rawOrNotAvailable returns the RawData value of key, or "N/A" when it has no value.
+func rawOrNotAvailable(r *resources.Resource, key string) string {
+	if v := rawString(r, key); v != "" {
+		return v
+	}
+	return helpers.NotAvailable
+}
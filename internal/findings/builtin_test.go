@@ -0,0 +1,137 @@
+package findings
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/y-miyazaki/arc/internal/aws/resources"
+)
+
+func builtinRule(t *testing.T, id string) Rule {
+	t.Helper()
+	for _, rule := range Builtin() {
+		if rule.ID == id {
+			return rule
+		}
+	}
+	require.Failf(t, "rule not found", "rule %s", id)
+	return Rule{}
+}
+
+func TestBuiltin_UniqueIDs(t *testing.T) {
+	t.Parallel()
+
+	seen := make(map[string]bool)
+	for _, rule := range Builtin() {
+		assert.False(t, seen[rule.ID], "duplicate rule %s", rule.ID)
+		seen[rule.ID] = true
+		assert.NotEmpty(t, rule.Title, rule.ID)
+		assert.Contains(t, severities, rule.Severity, rule.ID)
+		assert.NotNil(t, rule.Check, rule.ID)
+	}
+}
+
+func TestBuiltin_Checks(t *testing.T) {
+	t.Parallel()
+
+	secureBucket := map[string]any{
+		"Encryption":               "AES256",
+		"ACL":                      "CanonicalUser:abc=FULL_CONTROL",
+		"PABBlockPublicACLs":       "true",
+		"PABIgnorePublicACLs":      "true",
+		"PABBlockPublicPolicy":     "true",
+		"PABRestrictPublicBuckets": "true",
+	}
+	publicBucket := map[string]any{
+		"Encryption":               "None",
+		"ACL":                      "CanonicalUser:abc=FULL_CONTROL\nGroup:http://acs.amazonaws.com/groups/global/AllUsers=READ",
+		"PABBlockPublicACLs":       "false",
+		"PABIgnorePublicACLs":      "N/A",
+		"PABBlockPublicPolicy":     "true",
+		"PABRestrictPublicBuckets": "true",
+	}
+
+	tests := []struct {
+		name     string
+		rule     string
+		resource resources.Resource
+		want     string
+	}{
+		{
+			name:     "public acl",
+			rule:     "s3-public-acl",
+			resource: resources.Resource{SubCategory1: "Bucket", RawData: publicBucket},
+			want:     "Group:http://acs.amazonaws.com/groups/global/AllUsers=READ",
+		},
+		{name: "private acl", rule: "s3-public-acl", resource: resources.Resource{SubCategory1: "Bucket", RawData: secureBucket}},
+		{
+			name:     "public access block disabled",
+			rule:     "s3-public-access-block",
+			resource: resources.Resource{SubCategory1: "Bucket", RawData: publicBucket},
+			want:     "PABBlockPublicACLs=false, PABIgnorePublicACLs=N/A",
+		},
+		{name: "public access block enabled", rule: "s3-public-access-block", resource: resources.Resource{SubCategory1: "Bucket", RawData: secureBucket}},
+		{name: "bucket without encryption", rule: "s3-unencrypted", resource: resources.Resource{SubCategory1: "Bucket", RawData: publicBucket}, want: "Encryption=None"},
+		{name: "bucket with encryption", rule: "s3-unencrypted", resource: resources.Resource{SubCategory1: "Bucket", RawData: secureBucket}},
+		{
+			name: "ssh open to the internet",
+			rule: "vpc-sg-open-admin-port",
+			resource: resources.Resource{SubCategory2: "SecurityGroup", RawData: map[string]any{
+				"Inbound": "Protocol: tcp | FromPort: 22 | ToPort: 22 | CIDR: 0.0.0.0/0\nProtocol: tcp | FromPort: 443 | ToPort: 443 | CIDR: 0.0.0.0/0",
+			}},
+			want: "Protocol: tcp | FromPort: 22 | ToPort: 22 | CIDR: 0.0.0.0/0 (SSH)",
+		},
+		{
+			name: "port range including rdp",
+			rule: "vpc-sg-open-admin-port",
+			resource: resources.Resource{SubCategory2: "SecurityGroup", RawData: map[string]any{
+				"Inbound": "Protocol: tcp | FromPort: 1024 | ToPort: 65535 | CIDR: 0.0.0.0/0",
+			}},
+			want: "Protocol: tcp | FromPort: 1024 | ToPort: 65535 | CIDR: 0.0.0.0/0 (RDP)",
+		},
+		{
+			name: "all traffic from the internet",
+			rule: "vpc-sg-open-admin-port",
+			resource: resources.Resource{SubCategory2: "SecurityGroup", RawData: map[string]any{
+				"Inbound": "Protocol: -1 | FromPort: 0 | ToPort: 0 | CIDR: 0.0.0.0/0",
+			}},
+			want: "Protocol: -1 | FromPort: 0 | ToPort: 0 | CIDR: 0.0.0.0/0 (all traffic)",
+		},
+		{
+			name: "ssh open to any ipv6 address",
+			rule: "vpc-sg-open-admin-port",
+			resource: resources.Resource{SubCategory2: "SecurityGroup", RawData: map[string]any{
+				"Inbound": "Protocol: tcp | FromPort: 22 | ToPort: 22 | CIDR: 10.0.0.0/8\nProtocol: tcp | FromPort: 22 | ToPort: 22 | CIDR: ::/0",
+			}},
+			want: "Protocol: tcp | FromPort: 22 | ToPort: 22 | CIDR: ::/0 (SSH)",
+		},
+		{
+			name: "ssh from a private range",
+			rule: "vpc-sg-open-admin-port",
+			resource: resources.Resource{SubCategory2: "SecurityGroup", RawData: map[string]any{
+				"Inbound": "Protocol: tcp | FromPort: 22 | ToPort: 22 | CIDR: 10.0.0.0/8",
+			}},
+		},
+		{name: "unencrypted rds instance", rule: "rds-unencrypted", resource: resources.Resource{SubCategory1: "DBInstance", RawData: map[string]any{"KmsKey": "N/A"}}, want: "KmsKey=N/A"},
+		{name: "encrypted rds cluster", rule: "rds-unencrypted", resource: resources.Resource{SubCategory1: "DBCluster", RawData: map[string]any{"KmsKey": "aws/rds"}}},
+		{name: "rds cluster member row", rule: "rds-unencrypted", resource: resources.Resource{RawData: map[string]any{"KmsKey": "N/A"}}},
+		{name: "unencrypted efs", rule: "efs-unencrypted", resource: resources.Resource{SubCategory1: "FileSystem", RawData: map[string]any{"Encrypted": "false"}}, want: "Encrypted=false"},
+		{name: "efs mount target", rule: "efs-unencrypted", resource: resources.Resource{SubCategory1: "MountTarget", RawData: map[string]any{"Encrypted": "false"}}},
+		{name: "unencrypted redshift", rule: "redshift-unencrypted", resource: resources.Resource{SubCategory1: "Cluster", RawData: map[string]any{"Encrypted": "false"}}, want: "Encrypted=false"},
+		{name: "pitr disabled", rule: "dynamodb-pitr-disabled", resource: resources.Resource{SubCategory1: "Table", RawData: map[string]any{"PointInTimeRecovery": "DISABLED"}}, want: "PointInTimeRecovery=DISABLED"},
+		{name: "pitr unknown", rule: "dynamodb-pitr-disabled", resource: resources.Resource{SubCategory1: "Table", RawData: map[string]any{}}, want: "PointInTimeRecovery=N/A"},
+		{name: "pitr enabled", rule: "dynamodb-pitr-disabled", resource: resources.Resource{SubCategory1: "Table", RawData: map[string]any{"PointInTimeRecovery": "ENABLED"}}},
+		{name: "rotation disabled", rule: "secretsmanager-rotation-disabled", resource: resources.Resource{SubCategory1: "Secret", RawData: map[string]any{"RotationEnabled": "false"}}, want: "RotationEnabled=false"},
+		{name: "rotation enabled", rule: "secretsmanager-rotation-disabled", resource: resources.Resource{SubCategory1: "Secret", RawData: map[string]any{"RotationEnabled": "true"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			rule := builtinRule(t, tt.rule)
+			assert.Equal(t, tt.want, rule.Check(&tt.resource))
+		})
+	}
+}
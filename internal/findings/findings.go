@@ -0,0 +1,132 @@
+// Package findings evaluates security posture rules over collected resources
+// and reports the resources that fail them.
+package findings
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/y-miyazaki/arc/internal/aws/helpers"
+	"github.com/y-miyazaki/arc/internal/aws/resources"
+)
+
+// Severities of a rule, from the most to the least severe.
+const (
+	SeverityCritical = "critical"
+	SeverityHigh     = "high"
+	SeverityMedium   = "medium"
+	SeverityLow      = "low"
+)
+
+// severities lists the severities from the least to the most severe; the index is the rank.
+var severities = []string{SeverityLow, SeverityMedium, SeverityHigh, SeverityCritical}
+
+// Finding is a resource failing a rule.
+// Field names match the columns of findings.csv.
+type Finding struct {
+	Category    string `json:"Category"`    //nolint:tagliatelle // matches CSV column headers
+	Evidence    string `json:"Evidence"`    //nolint:tagliatelle // matches CSV column headers
+	Name        string `json:"Name"`        //nolint:tagliatelle // matches CSV column headers
+	Region      string `json:"Region"`      //nolint:tagliatelle // matches CSV column headers
+	ResourceARN string `json:"ResourceARN"` //nolint:tagliatelle // matches CSV column headers
+	ResourceID  string `json:"ResourceID"`  //nolint:tagliatelle // matches CSV column headers
+	RuleID      string `json:"RuleID"`      //nolint:tagliatelle // matches CSV column headers
+	Severity    string `json:"Severity"`    //nolint:tagliatelle // matches CSV column headers
+	Title       string `json:"Title"`       //nolint:tagliatelle // matches CSV column headers
+}
+
+// Rule is a check over the resources of one category.
+type Rule struct {
+	// Check returns the evidence of a failure, or an empty string when the
+	// resource passes or the rule does not apply to it (for example another SubCategory1).
+	Check    func(r *resources.Resource) string
+	Category string
+	ID       string
+	Severity string
+	Title    string
+}
+
+// Evaluate runs rules over the resources of category and returns the findings
+// in resource order. Rules of other categories are ignored.
+func Evaluate(rules []Rule, category string, res []resources.Resource) []Finding {
+	var out []Finding
+	for i := range res {
+		r := &res[i]
+		for j := range rules {
+			rule := &rules[j]
+			if rule.Category != category {
+				continue
+			}
+			evidence := rule.Check(r)
+			if evidence == "" {
+				continue
+			}
+			out = append(out, Finding{
+				Category:    category,
+				Evidence:    evidence,
+				Name:        r.Name,
+				Region:      r.Region,
+				ResourceARN: r.ARN,
+				ResourceID:  rawString(r, "ID"),
+				RuleID:      rule.ID,
+				Severity:    rule.Severity,
+				Title:       rule.Title,
+			})
+		}
+	}
+	return out
+}
+
+// ResourceKey identifies r among the resources of its category, from values
+// that are never redacted: its region, name and ARN.
+func ResourceKey(r *resources.Resource) string {
+	return r.Region + "\x00" + r.Name + "\x00" + r.ARN
+}
+
+// ResourceKey returns the ResourceKey of the resource of f.
+func (f *Finding) ResourceKey() string {
+	return f.Region + "\x00" + f.Name + "\x00" + f.ResourceARN
+}
+
+// Sort orders findings by descending severity, then by rule ID, category, region and name.
+func Sort(list []Finding) {
+	slices.SortStableFunc(list, func(a, b Finding) int {
+		if order := cmp.Compare(severityRank(b.Severity), severityRank(a.Severity)); order != 0 {
+			return order
+		}
+		if order := cmp.Compare(a.RuleID, b.RuleID); order != 0 {
+			return order
+		}
+		if order := cmp.Compare(a.Category, b.Category); order != 0 {
+			return order
+		}
+		if order := cmp.Compare(a.Region, b.Region); order != 0 {
+			return order
+		}
+		return cmp.Compare(a.Name, b.Name)
+	})
+}
+
+// CountBySeverity returns the number of findings per severity.
+func CountBySeverity(list []Finding) map[string]int {
+	counts := make(map[string]int)
+	for i := range list {
+		counts[list[i].Severity]++
+	}
+	return counts
+}
+
+// severityRank returns the rank of a severity, higher being more severe, or -1 when unknown.
+func severityRank(s string) int {
+	return slices.Index(severities, s)
+}
+
+// rawString returns the normalized RawData value of key, or an empty string
+// when the key is missing or has no value ("N/A").
+func rawString(r *resources.Resource, key string) string {
+	v, ok := r.RawData[key].(string)
+	if !ok || v == helpers.NotAvailable {
+		return ""
+	}
+	return v
+}
@@ -0,0 +1,80 @@
+package findings
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/y-miyazaki/arc/internal/aws/resources"
+)
+
+func TestEvaluate(t *testing.T) {
+	t.Parallel()
+
+	rules := []Rule{
+		{
+			ID:       "sqs-no-dlq",
+			Title:    "Queue without DLQ",
+			Severity: SeverityLow,
+			Category: "sqs",
+			Check: func(r *resources.Resource) string {
+				if r.RawData["DLQ"] != "N/A" {
+					return ""
+				}
+				return "DLQ=N/A"
+			},
+		},
+		{
+			ID:       "other-category",
+			Severity: SeverityCritical,
+			Category: "sns",
+			Check:    func(*resources.Resource) string { return "always" },
+		},
+	}
+	res := []resources.Resource{
+		{Name: "jobs", Region: "us-east-1", ARN: "arn:aws:sqs:us-east-1:123456789012:jobs", RawData: map[string]any{"DLQ": "N/A", "ID": "jobs-id"}},
+		{Name: "events", Region: "us-east-1", RawData: map[string]any{"DLQ": "events-dlq", "ID": "N/A"}},
+	}
+
+	got := Evaluate(rules, "sqs", res)
+	assert.Equal(t, []Finding{{
+		Category:    "sqs",
+		Evidence:    "DLQ=N/A",
+		Name:        "jobs",
+		Region:      "us-east-1",
+		ResourceARN: "arn:aws:sqs:us-east-1:123456789012:jobs",
+		ResourceID:  "jobs-id",
+		RuleID:      "sqs-no-dlq",
+		Severity:    SeverityLow,
+		Title:       "Queue without DLQ",
+	}}, got)
+
+	assert.Empty(t, Evaluate(rules, "lambda", res))
+	assert.Equal(t, ResourceKey(&res[0]), got[0].ResourceKey())
+	assert.NotEqual(t, ResourceKey(&res[1]), got[0].ResourceKey())
+}
+
+func TestSort(t *testing.T) {
+	t.Parallel()
+
+	list := []Finding{
+		{Severity: SeverityLow, RuleID: "a", Name: "x"},
+		{Severity: SeverityCritical, RuleID: "b", Name: "y"},
+		{Severity: SeverityHigh, RuleID: "b", Name: "z"},
+		{Severity: SeverityHigh, RuleID: "a", Name: "w"},
+	}
+	Sort(list)
+
+	names := make([]string, 0, len(list))
+	for _, f := range list {
+		names = append(names, f.Name)
+	}
+	assert.Equal(t, []string{"y", "w", "z", "x"}, names)
+}
+
+func TestCountBySeverity(t *testing.T) {
+	t.Parallel()
+
+	list := []Finding{{Severity: SeverityHigh}, {Severity: SeverityHigh}, {Severity: SeverityLow}}
+	assert.Equal(t, map[string]int{SeverityHigh: 2, SeverityLow: 1}, CountBySeverity(list))
+}
@@ -0,0 +1,52 @@
+package findings
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// Output file names, relative to the account output directory.
+const (
+	// CSVFile is the findings CSV read by the HTML viewer.
+	CSVFile = "findings.csv"
+	// JSONFile is the findings JSON array.
+	JSONFile = "findings.json"
+)
+
+// csvHeaders are the columns of findings.csv.
+var csvHeaders = []string{"Severity", "RuleID", "Title", "Category", "Region", "Name", "ResourceARN", "ResourceID", "Evidence"}
+
+// WriteCSV writes the findings as CSV with a header row.
+func WriteCSV(w io.Writer, list []Finding) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeaders); err != nil {
+		return fmt.Errorf("failed to write header: %w", err)
+	}
+	for i := range list {
+		f := &list[i]
+		if err := cw.Write([]string{f.Severity, f.RuleID, f.Title, f.Category, f.Region, f.Name, f.ResourceARN, f.ResourceID, f.Evidence}); err != nil {
+			return fmt.Errorf("failed to write row: %w", err)
+		}
+	}
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return fmt.Errorf("failed to flush csv: %w", err)
+	}
+	return nil
+}
+
+// WriteJSON writes the findings as a single indented JSON array.
+// An empty list is written as [].
+func WriteJSON(w io.Writer, list []Finding) error {
+	if list == nil {
+		list = []Finding{}
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(list); err != nil {
+		return fmt.Errorf("failed to encode json: %w", err)
+	}
+	return nil
+}
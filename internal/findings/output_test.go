@@ -0,0 +1,42 @@
+package findings
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteCSV(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteCSV(&buf, []Finding{{
+		Severity:    SeverityHigh,
+		RuleID:      "efs-unencrypted",
+		Title:       "EFS file system not encrypted at rest",
+		Category:    "efs",
+		Region:      "us-east-1",
+		Name:        "shared",
+		ResourceARN: "fs-12345678",
+		Evidence:    "Encrypted=false",
+	}}))
+
+	want := "Severity,RuleID,Title,Category,Region,Name,ResourceARN,ResourceID,Evidence\n" +
+		"high,efs-unencrypted,EFS file system not encrypted at rest,efs,us-east-1,shared,fs-12345678,,Encrypted=false\n"
+	assert.Equal(t, want, buf.String())
+}
+
+func TestWriteJSON(t *testing.T) {
+	t.Parallel()
+
+	var empty bytes.Buffer
+	require.NoError(t, WriteJSON(&empty, nil))
+	assert.Equal(t, "[]\n", empty.String())
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteJSON(&buf, []Finding{{Severity: SeverityLow, RuleID: "r1"}}))
+	assert.Contains(t, buf.String(), `"RuleID": "r1"`)
+	assert.Contains(t, buf.String(), `"Severity": "low"`)
+}
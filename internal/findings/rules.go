@@ -0,0 +1,267 @@
+package findings
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/ext"
+	"gopkg.in/yaml.v3"
+
+	"github.com/y-miyazaki/arc/internal/aws/resources"
+)
+
+// Sentinel errors for rule files (alphabetical order).
+var (
+	ErrDuplicateRule   = errors.New("duplicate rule id")
+	ErrInvalidRule     = errors.New("invalid rule")
+	ErrUnknownCategory = errors.New("unknown category")
+	ErrUnknownSeverity = errors.New("unknown severity")
+)
+
+// Variables available to rule expressions.
+const (
+	varARN          = "arn"
+	varCategory     = "category"
+	varName         = "name"
+	varRaw          = "raw"
+	varRegion       = "region"
+	varSubCategory1 = "subCategory1"
+	varSubCategory2 = "subCategory2"
+	varSubCategory3 = "subCategory3"
+	varTags         = "tags"
+)
+
+// RuleFile is the content of a rule file:
+//
+//	rules:
+//	  - id: ec2-previous-generation
+//	    title: EC2 instance of a previous generation type
+//	    severity: low
+//	    category: ec2
+//	    condition: subCategory1 == "Instance" && raw["InstanceType"].startsWith("t2.")
+//	    evidence: '"InstanceType=" + raw["InstanceType"]'
+type RuleFile struct {
+	Rules []RuleSpec `yaml:"rules"`
+}
+
+// RuleSpec is a user-defined rule. Condition is a CEL expression returning
+// true when a resource fails the rule; Evidence is an optional CEL expression
+// returning the evidence string of a failure.
+type RuleSpec struct {
+	Category  string `yaml:"category"`
+	Condition string `yaml:"condition"`
+	Evidence  string `yaml:"evidence"`
+	ID        string `yaml:"id"`
+	Severity  string `yaml:"severity"`
+	Title     string `yaml:"title"`
+}
+
+// ValidateSeverity returns ErrUnknownSeverity when s is not a known severity.
+func ValidateSeverity(s string) error {
+	if severityRank(s) < 0 {
+		return fmt.Errorf("%w: %q (valid: %s)", ErrUnknownSeverity, s, strings.Join(severities, ", "))
+	}
+	return nil
+}
+
+// AtLeast returns the findings whose severity is minSeverity or more severe.
+func AtLeast(list []Finding, minSeverity string) []Finding {
+	rank := severityRank(minSeverity)
+	var out []Finding
+	for i := range list {
+		if severityRank(list[i].Severity) >= rank {
+			out = append(out, list[i])
+		}
+	}
+	return out
+}
+
+// LoadRules reads the rule files at paths and compiles their rules.
+// Rule IDs must be unique across the files and the built-in rules, and
+// categories must be in categories. All problems are returned joined.
+func LoadRules(paths, categories []string) ([]Rule, error) {
+	env, err := newRuleEnv()
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]string)
+	for _, rule := range Builtin() {
+		seen[rule.ID] = "built-in rules"
+	}
+	var (
+		rules []Rule
+		errs  []error
+	)
+	for _, path := range paths {
+		file, loadErr := loadRuleFile(path)
+		if loadErr != nil {
+			errs = append(errs, loadErr)
+			continue
+		}
+		for i := range file.Rules {
+			spec := &file.Rules[i]
+			if where, ok := seen[spec.ID]; ok && spec.ID != "" {
+				errs = append(errs, fmt.Errorf("%s: %w: %s (also in %s)", path, ErrDuplicateRule, spec.ID, where))
+				continue
+			}
+			seen[spec.ID] = path
+			rule, compileErr := compileRule(env, spec, categories)
+			if compileErr != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", path, compileErr))
+				continue
+			}
+			rules = append(rules, rule)
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return rules, nil
+}
+
+// loadRuleFile reads and parses the rule file at path.
+func loadRuleFile(path string) (*RuleFile, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // G304 - path is provided by the user on purpose
+	if err != nil {
+		return nil, fmt.Errorf("failed to read rule file: %w", err)
+	}
+	file := &RuleFile{}
+	if err := yaml.Unmarshal(data, file); err != nil {
+		return nil, fmt.Errorf("failed to parse rule file %s: %w", path, err)
+	}
+	return file, nil
+}
+
+// newRuleEnv returns the CEL environment of rule expressions, with the CEL
+// string extensions (split, lowerAscii, ...) enabled.
+func newRuleEnv() (*cel.Env, error) {
+	env, err := cel.NewEnv(
+		ext.Strings(),
+		cel.Variable(varARN, cel.StringType),
+		cel.Variable(varCategory, cel.StringType),
+		cel.Variable(varName, cel.StringType),
+		cel.Variable(varRaw, cel.MapType(cel.StringType, cel.StringType)),
+		cel.Variable(varRegion, cel.StringType),
+		cel.Variable(varSubCategory1, cel.StringType),
+		cel.Variable(varSubCategory2, cel.StringType),
+		cel.Variable(varSubCategory3, cel.StringType),
+		cel.Variable(varTags, cel.MapType(cel.StringType, cel.StringType)),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create rule environment: %w", err)
+	}
+	return env, nil
+}
+
+// compileRule validates spec and compiles its expressions into a Rule.
+func compileRule(env *cel.Env, spec *RuleSpec, categories []string) (Rule, error) {
+	switch {
+	case spec.ID == "":
+		return Rule{}, fmt.Errorf("%w: id is required", ErrInvalidRule)
+	case spec.Title == "":
+		return Rule{}, fmt.Errorf("%w: %s: title is required", ErrInvalidRule, spec.ID)
+	case spec.Condition == "":
+		return Rule{}, fmt.Errorf("%w: %s: condition is required", ErrInvalidRule, spec.ID)
+	}
+	if err := ValidateSeverity(spec.Severity); err != nil {
+		return Rule{}, fmt.Errorf("rule %s: %w", spec.ID, err)
+	}
+	if !slices.Contains(categories, spec.Category) {
+		return Rule{}, fmt.Errorf("rule %s: %w: %q", spec.ID, ErrUnknownCategory, spec.Category)
+	}
+	condition, err := compileExpression(env, spec.Condition, cel.BoolType)
+	if err != nil {
+		return Rule{}, fmt.Errorf("%w: %s: condition: %w", ErrInvalidRule, spec.ID, err)
+	}
+	var evidence cel.Program
+	if spec.Evidence != "" {
+		if evidence, err = compileExpression(env, spec.Evidence, cel.StringType); err != nil {
+			return Rule{}, fmt.Errorf("%w: %s: evidence: %w", ErrInvalidRule, spec.ID, err)
+		}
+	}
+	return Rule{
+		Category: spec.Category,
+		Check:    expressionCheck(condition, evidence, spec.Condition),
+		ID:       spec.ID,
+		Severity: spec.Severity,
+		Title:    spec.Title,
+	}, nil
+}
+
+// compileExpression compiles a CEL expression that must return want.
+func compileExpression(env *cel.Env, expr string, want *cel.Type) (cel.Program, error) {
+	ast, issues := env.Compile(expr)
+	if issues.Err() != nil {
+		return nil, issues.Err()
+	}
+	if !ast.OutputType().IsExactType(want) {
+		return nil, fmt.Errorf("%w: expression returns %s, want %s", ErrInvalidRule, ast.OutputType(), want)
+	}
+	program, err := env.Program(ast)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build program: %w", err)
+	}
+	return program, nil
+}
+
+// expressionCheck returns a Rule.Check evaluating condition. The evidence of a
+// failure is the result of evidence, or the condition source when evidence is nil.
+// An expression that cannot be evaluated, for example because it reads a raw
+// key the resource does not have, fails the resource so that a broken rule is
+// never silently passing.
+func expressionCheck(condition, evidence cel.Program, source string) func(r *resources.Resource) string {
+	return func(r *resources.Resource) string {
+		vars := ruleVariables(r)
+		out, _, err := condition.Eval(vars)
+		if err != nil {
+			return "evaluation error: " + err.Error()
+		}
+		if failed, ok := out.Value().(bool); !ok || !failed {
+			return ""
+		}
+		if evidence == nil {
+			return source
+		}
+		out, _, err = evidence.Eval(vars)
+		if err != nil {
+			return "evaluation error: " + err.Error()
+		}
+		if s, ok := out.Value().(string); ok && s != "" {
+			return s
+		}
+		return source
+	}
+}
+
+// ruleVariables returns the expression variables of r. RawData values are
+// formatted as strings; missing values keep their "N/A" form.
+func ruleVariables(r *resources.Resource) map[string]any {
+	raw := make(map[string]string, len(r.RawData))
+	for key, value := range r.RawData {
+		if s, ok := value.(string); ok {
+			raw[key] = s
+			continue
+		}
+		raw[key] = fmt.Sprint(value)
+	}
+	tags := maps.Clone(r.Tags)
+	if tags == nil {
+		tags = map[string]string{}
+	}
+	return map[string]any{
+		varARN:          r.ARN,
+		varCategory:     r.Category,
+		varName:         r.Name,
+		varRaw:          raw,
+		varRegion:       r.Region,
+		varSubCategory1: r.SubCategory1,
+		varSubCategory2: r.SubCategory2,
+		varSubCategory3: r.SubCategory3,
+		varTags:         tags,
+	}
+}
@@ -0,0 +1,118 @@
+package findings
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/y-miyazaki/arc/internal/aws/resources"
+)
+
+var testCategories = []string{"ec2", "s3_bucket"}
+
+func writeRuleFixture(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "rules.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestLoadRules(t *testing.T) {
+	t.Parallel()
+
+	path := writeRuleFixture(t, `
+rules:
+  - id: ec2-previous-generation
+    title: EC2 instance of a previous generation type
+    severity: low
+    category: ec2
+    condition: subCategory1 == "Instance" && raw["InstanceType"].startsWith("t2.")
+    evidence: '"InstanceType=" + raw["InstanceType"]'
+  - id: bucket-owner-tag
+    title: Bucket without Owner tag
+    severity: low
+    category: s3_bucket
+    condition: subCategory1 == "Bucket" && !("Owner" in tags)
+  - id: instance-missing-key
+    title: Instance reads a missing key
+    severity: medium
+    category: ec2
+    condition: raw["Missing"] == "x"
+`)
+	rules, err := LoadRules([]string{path}, testCategories)
+	require.NoError(t, err)
+	require.Len(t, rules, 3)
+
+	instance := resources.Resource{
+		Category:     "ec2",
+		SubCategory1: "Instance",
+		RawData:      map[string]any{"InstanceType": "t2.micro"},
+		Tags:         map[string]string{"Owner": "team"},
+	}
+	bucket := resources.Resource{Category: "s3_bucket", SubCategory1: "Bucket"}
+
+	assert.Equal(t, "InstanceType=t2.micro", rules[0].Check(&instance))
+	instance.RawData["InstanceType"] = "t3.micro"
+	assert.Empty(t, rules[0].Check(&instance))
+
+	assert.Equal(t, `subCategory1 == "Bucket" && !("Owner" in tags)`, rules[1].Check(&bucket))
+	bucket.Tags = map[string]string{"Owner": "team"}
+	assert.Empty(t, rules[1].Check(&bucket))
+
+	assert.Contains(t, rules[2].Check(&instance), "evaluation error")
+
+	got := Evaluate(rules, "s3_bucket", []resources.Resource{{Category: "s3_bucket", SubCategory1: "Bucket", Name: "logs"}})
+	require.Len(t, got, 1)
+	assert.Equal(t, "bucket-owner-tag", got[0].RuleID)
+	assert.Equal(t, SeverityLow, got[0].Severity)
+}
+
+func TestLoadRules_Invalid(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		content string
+		wantErr error
+	}{
+		{name: "missing id", content: "rules:\n  - title: t\n    severity: low\n    category: ec2\n    condition: 'true'\n", wantErr: ErrInvalidRule},
+		{name: "unknown severity", content: "rules:\n  - id: a\n    title: t\n    severity: urgent\n    category: ec2\n    condition: 'true'\n", wantErr: ErrUnknownSeverity},
+		{name: "unknown category", content: "rules:\n  - id: a\n    title: t\n    severity: low\n    category: ec3\n    condition: 'true'\n", wantErr: ErrUnknownCategory},
+		{name: "syntax error", content: "rules:\n  - id: a\n    title: t\n    severity: low\n    category: ec2\n    condition: 'name =='\n", wantErr: ErrInvalidRule},
+		{name: "condition not bool", content: "rules:\n  - id: a\n    title: t\n    severity: low\n    category: ec2\n    condition: name\n", wantErr: ErrInvalidRule},
+		{name: "unknown variable", content: "rules:\n  - id: a\n    title: t\n    severity: low\n    category: ec2\n    condition: instance == 'x'\n", wantErr: ErrInvalidRule},
+		{name: "evidence not string", content: "rules:\n  - id: a\n    title: t\n    severity: low\n    category: ec2\n    condition: 'true'\n    evidence: '1'\n", wantErr: ErrInvalidRule},
+		{name: "built-in id", content: "rules:\n  - id: s3-unencrypted\n    title: t\n    severity: low\n    category: ec2\n    condition: 'true'\n", wantErr: ErrDuplicateRule},
+		{name: "duplicate id", content: "rules:\n  - id: a\n    title: t\n    severity: low\n    category: ec2\n    condition: 'true'\n  - id: a\n    title: t\n    severity: low\n    category: ec2\n    condition: 'false'\n", wantErr: ErrDuplicateRule},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := LoadRules([]string{writeRuleFixture(t, tt.content)}, testCategories)
+			require.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+
+	_, err := LoadRules([]string{filepath.Join(t.TempDir(), "missing.yaml")}, testCategories)
+	require.Error(t, err)
+}
+
+func TestValidateSeverity(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, ValidateSeverity(SeverityHigh))
+	require.ErrorIs(t, ValidateSeverity("urgent"), ErrUnknownSeverity)
+}
+
+func TestAtLeast(t *testing.T) {
+	t.Parallel()
+
+	list := []Finding{{RuleID: "a", Severity: SeverityLow}, {RuleID: "b", Severity: SeverityCritical}, {RuleID: "c", Severity: SeverityHigh}}
+	assert.Equal(t, []Finding{{RuleID: "b", Severity: SeverityCritical}, {RuleID: "c", Severity: SeverityHigh}}, AtLeast(list, SeverityHigh))
+	assert.Len(t, AtLeast(list, SeverityLow), 3)
+	assert.Len(t, AtLeast(list, SeverityCritical), 1)
+}
@@ -0,0 +1,172 @@
+// Package graph extracts the relationships between collected resources, such as
+// the IAM role of a Lambda function or the KMS key of an RDS instance, and
+// exports them as a graph of resources and typed edges.
+package graph
+
+import (
+	"cmp"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/y-miyazaki/arc/internal/aws/helpers"
+	"github.com/y-miyazaki/arc/internal/aws/resources"
+)
+
+// Edge kinds, named after what the source resource does with the target.
+const (
+	KindAttachedTo        = "attached-to"
+	KindEncryptedBy       = "encrypted-by"
+	KindInSubnet          = "in-subnet"
+	KindInVPC             = "in-vpc"
+	KindProtectedBy       = "protected-by"
+	KindRunsTask          = "runs-task-definition"
+	KindUsesRole          = "uses-role"
+	KindUsesSecurityGroup = "uses-security-group"
+)
+
+// Node is a resource of the graph. ID is the resource ARN when it has one,
+// otherwise its AWS ID (vpc-..., sg-...) or category, region and name.
+// External nodes are referenced by ARN but were not collected, for example
+// the IAM role of a Lambda function when iam_role is not collected.
+type Node struct {
+	ARN         string `json:"arn,omitempty"`
+	Category    string `json:"category"`
+	ID          string `json:"id"`
+	Name        string `json:"name,omitempty"`
+	Region      string `json:"region,omitempty"`
+	SubCategory string `json:"subCategory,omitempty"`
+	External    bool   `json:"external,omitempty"`
+}
+
+// Edge is a reference from the resource From to the resource To.
+// Key is the RawData key holding the reference.
+type Edge struct {
+	From string `json:"from"`
+	Key  string `json:"key"`
+	Kind string `json:"kind"`
+	To   string `json:"to"`
+}
+
+// Graph is a set of resources and the references between them.
+// Nodes are sorted by ID and edges by source, kind and target.
+type Graph struct {
+	Edges []Edge `json:"edges"`
+	Nodes []Node `json:"nodes"`
+}
+
+// Build returns the graph of the resources of every category, keyed by category.
+func Build(categoryResources map[string][]resources.Resource) *Graph {
+	idx := newIndex(categoryResources)
+	// Two references may describe the same edge, such as the WAF of a load
+	// balancer and the resources of the web ACL: the first one is kept.
+	type edgeKey struct{ from, kind, to string }
+	edges := make(map[edgeKey]Edge)
+	for _, category := range slices.Sorted(maps.Keys(categoryResources)) {
+		res := categoryResources[category]
+		for i := range res {
+			for _, edge := range idx.edges(category, &res[i]) {
+				key := edgeKey{edge.From, edge.Kind, edge.To}
+				if _, ok := edges[key]; !ok {
+					edges[key] = edge
+				}
+			}
+		}
+	}
+	g := &Graph{Nodes: idx.nodes(), Edges: slices.Collect(maps.Values(edges))}
+	g.sortEdges()
+	return g
+}
+
+// Focus returns the subgraph of the resources that depend on the resources
+// matching target (by ARN, ID or name), directly or through other resources,
+// together with those resources: the blast radius of target.
+func (g *Graph) Focus(target string) *Graph {
+	keep := make(map[string]bool)
+	var queue []string
+	for i := range g.Nodes {
+		n := &g.Nodes[i]
+		if n.ID == target || n.ARN == target || n.Name == target {
+			keep[n.ID] = true
+			queue = append(queue, n.ID)
+		}
+	}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		for _, e := range g.Edges {
+			if e.To == id && !keep[e.From] {
+				keep[e.From] = true
+				queue = append(queue, e.From)
+			}
+		}
+	}
+	out := &Graph{}
+	for _, n := range g.Nodes {
+		if keep[n.ID] {
+			out.Nodes = append(out.Nodes, n)
+		}
+	}
+	for _, e := range g.Edges {
+		if keep[e.From] && keep[e.To] {
+			out.Edges = append(out.Edges, e)
+		}
+	}
+	return out
+}
+
+// sortEdges orders the edges by source, kind and target.
+func (g *Graph) sortEdges() {
+	slices.SortFunc(g.Edges, func(a, b Edge) int {
+		if order := cmp.Compare(a.From, b.From); order != 0 {
+			return order
+		}
+		if order := cmp.Compare(a.Kind, b.Kind); order != 0 {
+			return order
+		}
+		if order := cmp.Compare(a.To, b.To); order != 0 {
+			return order
+		}
+		return cmp.Compare(a.Key, b.Key)
+	})
+}
+
+// NodeID returns the graph node ID of a resource of category.
+func NodeID(category string, r *resources.Resource) string {
+	if arn := value(r.ARN); arn != "" {
+		return arn
+	}
+	if id := rawString(r, "ID"); id != "" {
+		return id
+	}
+	return category + ":" + r.Region + ":" + r.Name
+}
+
+// subCategory returns the most specific SubCategory of r.
+func subCategory(r *resources.Resource) string {
+	for _, s := range []string{r.SubCategory3, r.SubCategory2, r.SubCategory1} {
+		if s != "" {
+			return s
+		}
+	}
+	return ""
+}
+
+// rawString returns the normalized RawData value of key, or an empty string
+// when the key is missing or has no value ("N/A").
+func rawString(r *resources.Resource, key string) string {
+	v, ok := r.RawData[key].(string)
+	if !ok {
+		return ""
+	}
+	return value(v)
+}
+
+// value returns s, or an empty string when s has no value ("N/A").
+func value(s string) string {
+	s = strings.TrimSpace(s)
+	if s == helpers.NotAvailable {
+		return ""
+	}
+	return s
+}
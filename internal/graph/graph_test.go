@@ -0,0 +1,122 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/y-miyazaki/arc/internal/aws/resources"
+)
+
+const (
+	testRoleARN   = "arn:aws:iam::123456789012:role/app"
+	testKeyARN    = "arn:aws:kms:us-east-1:123456789012:key/1234abcd"
+	testLambdaARN = "arn:aws:lambda:us-east-1:123456789012:function:api"
+	testSecretARN = "arn:aws:secretsmanager:us-east-1:123456789012:secret:db"
+)
+
+// testResources returns a small account: a Lambda function and a secret using
+// an IAM role and a KMS key, and an EC2 instance in a VPC.
+func testResources() map[string][]resources.Resource {
+	return map[string][]resources.Resource{
+		"iam_role": {{Category: "iam_role_policy", SubCategory1: "Role", Name: "app", Region: "Global", ARN: testRoleARN}},
+		"kms":      {{Category: "kms", SubCategory1: "Key", Name: "alias/app", Region: "us-east-1", ARN: testKeyARN}},
+		"lambda": {{
+			Category: "lambda", SubCategory1: "Function", Name: "api", Region: "us-east-1", ARN: testLambdaARN,
+			RawData: map[string]any{"RoleARN": testRoleARN},
+		}},
+		"secretsmanager": {{
+			Category: "secretsmanager", SubCategory1: "Secret", Name: "db", Region: "us-east-1", ARN: testSecretARN,
+			RawData: map[string]any{"KmsKey": "alias/app"},
+		}},
+		"ec2": {{
+			Category: "ec2", SubCategory1: "Instance", Name: "web", Region: "us-east-1",
+			RawData: map[string]any{"InstanceID": "i-1", "VPC": "main", "Subnet": "subnet-1", "SecurityGroup": "web\ndefault"},
+		}},
+		"vpc": {
+			{Category: "vpc", SubCategory1: "VPC", Name: "main", Region: "us-east-1", RawData: map[string]any{"ID": "vpc-1"}},
+			{Category: "vpc", SubCategory2: "PrivateSubnet", Name: "private-a", Region: "us-east-1", RawData: map[string]any{"ID": "subnet-1"}},
+			{Category: "vpc", SubCategory2: "SecurityGroup", Name: "web", Region: "us-east-1", RawData: map[string]any{"ID": "sg-1"}},
+			{Category: "vpc", SubCategory2: "SecurityGroup", Name: "default", Region: "us-east-1", RawData: map[string]any{"ID": "sg-2"}},
+			{Category: "vpc", SubCategory2: "SecurityGroup", Name: "default", Region: "us-east-1", RawData: map[string]any{"ID": "sg-3"}},
+		},
+	}
+}
+
+func TestBuild(t *testing.T) {
+	t.Parallel()
+
+	g := Build(testResources())
+
+	instanceID := "ec2:us-east-1:web"
+	assert.Equal(t, []Edge{
+		{From: testLambdaARN, Key: "RoleARN", Kind: KindUsesRole, To: testRoleARN},
+		{From: testSecretARN, Key: "KmsKey", Kind: KindEncryptedBy, To: testKeyARN},
+		{From: instanceID, Key: "Subnet", Kind: KindInSubnet, To: "subnet-1"},
+		{From: instanceID, Key: "VPC", Kind: KindInVPC, To: "vpc-1"},
+		// "default" is ambiguous: two security groups of the region have that name.
+		{From: instanceID, Key: "SecurityGroup", Kind: KindUsesSecurityGroup, To: "sg-1"},
+	}, g.Edges)
+	require.Len(t, g.Nodes, 10)
+	assert.Equal(t, "arn:aws:iam::123456789012:role/app", g.Nodes[0].ID)
+}
+
+func TestBuild_ExternalNodes(t *testing.T) {
+	t.Parallel()
+
+	res := testResources()
+	delete(res, "iam_role")
+	g := Build(res)
+
+	var role *Node
+	for i := range g.Nodes {
+		if g.Nodes[i].ID == testRoleARN {
+			role = &g.Nodes[i]
+		}
+	}
+	require.NotNil(t, role)
+	assert.Equal(t, Node{ARN: testRoleARN, Category: "iam_role", ID: testRoleARN, Name: "app", External: true}, *role)
+}
+
+func TestFocus(t *testing.T) {
+	t.Parallel()
+
+	res := testResources()
+	res["lambda"] = append(res["lambda"], resources.Resource{
+		Category: "lambda", SubCategory1: "Function", Name: "worker", Region: "us-east-1",
+		ARN: "arn:aws:lambda:us-east-1:123456789012:function:worker", RawData: map[string]any{"RoleARN": testRoleARN},
+	})
+	g := Build(res).Focus(testRoleARN)
+
+	ids := make([]string, 0, len(g.Nodes))
+	for _, n := range g.Nodes {
+		ids = append(ids, n.ID)
+	}
+	assert.Equal(t, []string{testRoleARN, testLambdaARN, "arn:aws:lambda:us-east-1:123456789012:function:worker"}, ids)
+	assert.Len(t, g.Edges, 2)
+
+	assert.Len(t, Build(res).Focus("alias/app").Nodes, 2)
+	assert.Empty(t, Build(res).Focus("missing").Nodes)
+}
+
+func TestNodeID(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		resource resources.Resource
+		want     string
+	}{
+		{name: "arn", resource: resources.Resource{ARN: testRoleARN, RawData: map[string]any{"ID": "x"}}, want: testRoleARN},
+		{name: "id", resource: resources.Resource{ARN: "N/A", RawData: map[string]any{"ID": "sg-1"}}, want: "sg-1"},
+		{name: "name", resource: resources.Resource{Name: "web", Region: "us-east-1"}, want: "ec2:us-east-1:web"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, NodeID("ec2", &tt.resource))
+		})
+	}
+}
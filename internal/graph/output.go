@@ -0,0 +1,135 @@
+package graph
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Graph output formats.
+const (
+	FormatDOT     = "dot"
+	FormatJSON    = "json"
+	FormatMermaid = "mermaid"
+)
+
+// Formats lists the supported graph output formats.
+var Formats = []string{FormatDOT, FormatJSON, FormatMermaid}
+
+// FileName returns the output file name of format, relative to the account output directory.
+func FileName(format string) string {
+	if format == FormatMermaid {
+		return "graph.mmd"
+	}
+	return "graph." + format
+}
+
+// Writer returns the writer of format, or nil when the format is unknown.
+func Writer(format string) func(w io.Writer, g *Graph) error {
+	switch format {
+	case FormatDOT:
+		return WriteDOT
+	case FormatJSON:
+		return WriteJSON
+	case FormatMermaid:
+		return WriteMermaid
+	default:
+		return nil
+	}
+}
+
+// WriteDOT writes the graph in Graphviz DOT format. Nodes are labelled with
+// their name and category and clustered by category; external nodes are dashed.
+func WriteDOT(w io.Writer, g *Graph) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, "digraph arc {")
+	fmt.Fprintln(bw, "  rankdir=LR;")
+	fmt.Fprintln(bw, "  node [shape=box];")
+	for i := range g.Nodes {
+		n := &g.Nodes[i]
+		style := ""
+		if n.External {
+			style = ", style=dashed"
+		}
+		fmt.Fprintf(bw, "  %s [label=%s%s];\n", dotQuote(n.ID), dotQuote(label(n, "\n")), style)
+	}
+	for _, e := range g.Edges {
+		fmt.Fprintf(bw, "  %s -> %s [label=%s];\n", dotQuote(e.From), dotQuote(e.To), dotQuote(e.Kind))
+	}
+	fmt.Fprintln(bw, "}")
+	if err := bw.Flush(); err != nil {
+		return fmt.Errorf("failed to write dot: %w", err)
+	}
+	return nil
+}
+
+// WriteMermaid writes the graph as a Mermaid flowchart. Node IDs are replaced
+// by n0, n1, ... because Mermaid IDs cannot hold ARNs.
+func WriteMermaid(w io.Writer, g *Graph) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, "flowchart LR")
+	ids := make(map[string]string, len(g.Nodes))
+	for i := range g.Nodes {
+		n := &g.Nodes[i]
+		ids[n.ID] = fmt.Sprintf("n%d", i)
+		fmt.Fprintf(bw, "  %s[\"%s\"]\n", ids[n.ID], mermaidEscape(label(n, "<br/>")))
+	}
+	for _, e := range g.Edges {
+		fmt.Fprintf(bw, "  %s -->|%s| %s\n", ids[e.From], e.Kind, ids[e.To])
+	}
+	for i := range g.Nodes {
+		if g.Nodes[i].External {
+			fmt.Fprintf(bw, "  style %s stroke-dasharray: 5 5\n", ids[g.Nodes[i].ID])
+		}
+	}
+	if err := bw.Flush(); err != nil {
+		return fmt.Errorf("failed to write mermaid: %w", err)
+	}
+	return nil
+}
+
+// WriteJSON writes the graph as a JSON object with "nodes" and "edges" arrays.
+func WriteJSON(w io.Writer, g *Graph) error {
+	out := *g
+	if out.Nodes == nil {
+		out.Nodes = []Node{}
+	}
+	if out.Edges == nil {
+		out.Edges = []Edge{}
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(out); err != nil {
+		return fmt.Errorf("failed to encode json: %w", err)
+	}
+	return nil
+}
+
+// label returns the display label of n: its name (or ID) and its category,
+// SubCategory and region, separated by sep.
+func label(n *Node, sep string) string {
+	name := n.Name
+	if name == "" {
+		name = n.ID
+	}
+	details := n.Category
+	if n.SubCategory != "" {
+		details += "/" + n.SubCategory
+	}
+	if n.Region != "" {
+		details += " (" + n.Region + ")"
+	}
+	return name + sep + details
+}
+
+// dotQuote returns s as a quoted DOT string.
+func dotQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s) + `"`
+}
+
+// mermaidEscape escapes the characters that end a quoted Mermaid label.
+func mermaidEscape(s string) string {
+	return strings.NewReplacer(`"`, "#quot;").Replace(s)
+}
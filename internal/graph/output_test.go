@@ -0,0 +1,74 @@
+package graph
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testGraph() *Graph {
+	return &Graph{
+		Nodes: []Node{
+			{ARN: testRoleARN, Category: "iam_role", ID: testRoleARN, Name: "app", External: true},
+			{ARN: testLambdaARN, Category: "lambda", ID: testLambdaARN, Name: `api "v2"`, Region: "us-east-1", SubCategory: "Function"},
+		},
+		Edges: []Edge{{From: testLambdaARN, Key: "RoleARN", Kind: KindUsesRole, To: testRoleARN}},
+	}
+}
+
+func TestWriteDOT(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteDOT(&buf, testGraph()))
+	assert.Equal(t, `digraph arc {
+  rankdir=LR;
+  node [shape=box];
+  "arn:aws:iam::123456789012:role/app" [label="app\niam_role", style=dashed];
+  "arn:aws:lambda:us-east-1:123456789012:function:api" [label="api \"v2\"\nlambda/Function (us-east-1)"];
+  "arn:aws:lambda:us-east-1:123456789012:function:api" -> "arn:aws:iam::123456789012:role/app" [label="uses-role"];
+}
+`, buf.String())
+}
+
+func TestWriteMermaid(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteMermaid(&buf, testGraph()))
+	assert.Equal(t, `flowchart LR
+  n0["app<br/>iam_role"]
+  n1["api #quot;v2#quot;<br/>lambda/Function (us-east-1)"]
+  n1 -->|uses-role| n0
+  style n0 stroke-dasharray: 5 5
+`, buf.String())
+}
+
+func TestWriteJSON(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteJSON(&buf, testGraph()))
+	var got Graph
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &got))
+	assert.Equal(t, *testGraph(), got)
+	assert.Contains(t, buf.String(), `"subCategory": "Function"`)
+
+	buf.Reset()
+	require.NoError(t, WriteJSON(&buf, &Graph{}))
+	assert.JSONEq(t, `{"edges": [], "nodes": []}`, buf.String())
+}
+
+func TestFormats(t *testing.T) {
+	t.Parallel()
+
+	for _, format := range Formats {
+		assert.NotNil(t, Writer(format), format)
+	}
+	assert.Nil(t, Writer("svg"))
+	assert.Equal(t, "graph.mmd", FileName(FormatMermaid))
+	assert.Equal(t, "graph.dot", FileName(FormatDOT))
+}
@@ -0,0 +1,232 @@
+package graph
+
+import (
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/y-miyazaki/arc/internal/aws/helpers"
+	"github.com/y-miyazaki/arc/internal/aws/resources"
+)
+
+// regionGlobal is the Region of global resources such as IAM roles.
+const regionGlobal = "Global"
+
+// reference is a RawData key of a category holding references to resources of
+// another category, as ARNs, IDs or names (lists are joined with newlines).
+type reference struct {
+	// category and key locate the reference.
+	category string
+	key      string
+	kind     string
+	// target is the category of the referenced resources; empty matches any category.
+	target string
+	// targetSubs restricts the referenced resources to these SubCategory values.
+	targetSubs []string
+	// reverse makes the edge go from the referenced resource to the resource
+	// holding the reference, for example a WAF web ACL listing the resources it protects.
+	reverse bool
+}
+
+var (
+	securityGroupSubs = []string{"SecurityGroup"}
+	subnetSubs        = []string{"PrivateSubnet", "PublicSubnet"}
+	vpcSubs           = []string{"VPC"}
+)
+
+// references lists the references recorded by the collectors, by category and key.
+var references = []reference{
+	{category: "apigateway", key: "WAF", kind: KindProtectedBy, target: "waf"},
+	{category: "cloudfront", key: "WAF", kind: KindProtectedBy, target: "waf"},
+	{category: "cloudwatch_logs", key: "KmsKey", kind: KindEncryptedBy, target: "kms"},
+	{category: "dynamodb", key: "KmsKey", kind: KindEncryptedBy, target: "kms"},
+	{category: "ec2", key: "SecurityGroup", kind: KindUsesSecurityGroup, target: "vpc", targetSubs: securityGroupSubs},
+	{category: "ec2", key: "Subnet", kind: KindInSubnet, target: "vpc", targetSubs: subnetSubs},
+	{category: "ec2", key: "VPC", kind: KindInVPC, target: "vpc", targetSubs: vpcSubs},
+	{category: "ecr", key: "KMSKey", kind: KindEncryptedBy, target: "kms"},
+	{category: "ecs", key: "RoleARN", kind: KindUsesRole, target: "iam_role"},
+	{category: "ecs", key: "TaskDefinition", kind: KindRunsTask, target: "ecs", targetSubs: []string{"TaskDefinition"}},
+	{category: "efs", key: "KmsKey", kind: KindEncryptedBy, target: "kms"},
+	{category: "efs", key: "SecurityGroup", kind: KindUsesSecurityGroup, target: "vpc", targetSubs: securityGroupSubs},
+	{category: "efs", key: "Subnet", kind: KindInSubnet, target: "vpc", targetSubs: subnetSubs},
+	{category: "elasticache", key: "SecurityGroup", kind: KindUsesSecurityGroup, target: "vpc", targetSubs: securityGroupSubs},
+	{category: "elb", key: "LoadBalancer", kind: KindAttachedTo, target: "elb", targetSubs: []string{"LoadBalancer"}},
+	{category: "elb", key: "SecurityGroup", kind: KindUsesSecurityGroup, target: "vpc", targetSubs: securityGroupSubs},
+	{category: "elb", key: "VPC", kind: KindInVPC, target: "vpc", targetSubs: vpcSubs},
+	{category: "elb", key: "WAF", kind: KindProtectedBy, target: "waf"},
+	{category: "eventbridge", key: "RoleARN", kind: KindUsesRole, target: "iam_role"},
+	{category: "glue", key: "RoleARN", kind: KindUsesRole, target: "iam_role"},
+	{category: "lambda", key: "RoleARN", kind: KindUsesRole, target: "iam_role"},
+	{category: "rds", key: "KmsKey", kind: KindEncryptedBy, target: "kms"},
+	{category: "redshift", key: "KmsKey", kind: KindEncryptedBy, target: "kms"},
+	{category: "redshift", key: "SecurityGroup", kind: KindUsesSecurityGroup, target: "vpc", targetSubs: securityGroupSubs},
+	{category: "s3_bucket", key: "KMSKey", kind: KindEncryptedBy, target: "kms"},
+	{category: "secretsmanager", key: "KmsKey", kind: KindEncryptedBy, target: "kms"},
+	{category: "stepfunctions", key: "KMSKeyID", kind: KindEncryptedBy, target: "kms"},
+	{category: "stepfunctions", key: "RoleARN", kind: KindUsesRole, target: "iam_role"},
+	{category: "waf", key: "AssociatedResources", kind: KindProtectedBy, reverse: true},
+}
+
+// entry is a collected resource that references may point to.
+type entry struct {
+	node *Node
+	subs []string
+}
+
+// index resolves reference values to graph nodes.
+type index struct {
+	// byCategory maps a category to its lookup keys (ARN, ID, name and the last
+	// segment of the ARN, such as a role name or a KMS key ID) and their entries.
+	byCategory map[string]map[string][]*entry
+	byID       map[string]*Node
+}
+
+// newIndex returns the index of the resources of every category.
+func newIndex(categoryResources map[string][]resources.Resource) *index {
+	idx := &index{
+		byCategory: make(map[string]map[string][]*entry),
+		byID:       make(map[string]*Node),
+	}
+	for category, res := range categoryResources {
+		keys := make(map[string][]*entry)
+		idx.byCategory[category] = keys
+		for i := range res {
+			r := &res[i]
+			id := NodeID(category, r)
+			node, ok := idx.byID[id]
+			if !ok {
+				node = &Node{
+					ARN:         value(r.ARN),
+					Category:    category,
+					ID:          id,
+					Name:        r.Name,
+					Region:      r.Region,
+					SubCategory: subCategory(r),
+				}
+				idx.byID[id] = node
+			}
+			e := &entry{node: node, subs: []string{r.SubCategory1, r.SubCategory2, r.SubCategory3}}
+			for _, key := range lookupKeys(r) {
+				keys[key] = append(keys[key], e)
+			}
+		}
+	}
+	return idx
+}
+
+// lookupKeys returns the values a reference to r may hold.
+func lookupKeys(r *resources.Resource) []string {
+	var keys []string
+	for _, key := range []string{value(r.ARN), rawString(r, "ID"), value(r.Name), arnTail(value(r.ARN))} {
+		if key != "" && !slices.Contains(keys, key) {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// edges returns the edges of the references held by r, a resource of category.
+func (idx *index) edges(category string, r *resources.Resource) []Edge {
+	from := NodeID(category, r)
+	var out []Edge
+	for i := range references {
+		ref := &references[i]
+		if ref.category != category {
+			continue
+		}
+		for v := range strings.SplitSeq(rawString(r, ref.key), "\n") {
+			to := idx.resolve(ref, r.Region, value(v))
+			if to == "" || to == from {
+				continue
+			}
+			edge := Edge{From: from, Key: ref.key, Kind: ref.kind, To: to}
+			if ref.reverse {
+				edge.From, edge.To = to, from
+			}
+			out = append(out, edge)
+		}
+	}
+	return out
+}
+
+// resolve returns the node ID referenced by v, or an empty string when no
+// single collected resource matches. Unmatched ARNs become external nodes.
+func (idx *index) resolve(ref *reference, region, v string) string {
+	if v == "" {
+		return ""
+	}
+	var candidates []*entry
+	for category, keys := range idx.byCategory {
+		if ref.target != "" && category != ref.target {
+			continue
+		}
+		for _, e := range keys[v] {
+			if !ref.matches(e) || slices.ContainsFunc(candidates, func(c *entry) bool { return c.node == e.node }) {
+				continue
+			}
+			candidates = append(candidates, e)
+		}
+	}
+	if len(candidates) > 1 {
+		// Names are unique per region at best: keep the resources of the same region.
+		candidates = slices.DeleteFunc(candidates, func(e *entry) bool {
+			return e.node.Region != region && e.node.Region != regionGlobal
+		})
+	}
+	switch {
+	case len(candidates) == 1:
+		return candidates[0].node.ID
+	case len(candidates) == 0 && strings.HasPrefix(v, "arn:"):
+		return idx.external(ref.target, v)
+	default:
+		return ""
+	}
+}
+
+// matches reports whether e has one of the SubCategory values of the reference.
+func (ref *reference) matches(e *entry) bool {
+	if len(ref.targetSubs) == 0 {
+		return true
+	}
+	return slices.ContainsFunc(e.subs, func(s string) bool { return slices.Contains(ref.targetSubs, s) })
+}
+
+// external returns the ID of the external node of arn, adding it when missing.
+func (idx *index) external(category, arn string) string {
+	if _, ok := idx.byID[arn]; ok {
+		return arn
+	}
+	node := &Node{ARN: arn, Category: category, ID: arn, Name: arnTail(arn), External: true}
+	if parsed, err := helpers.ParseARN(arn); err == nil {
+		node.Region = parsed.Region
+		if node.Category == "" {
+			node.Category = parsed.Service
+		}
+	}
+	idx.byID[arn] = node
+	return arn
+}
+
+// nodes returns the nodes sorted by ID.
+func (idx *index) nodes() []Node {
+	out := make([]Node, 0, len(idx.byID))
+	for _, id := range slices.Sorted(maps.Keys(idx.byID)) {
+		out = append(out, *idx.byID[id])
+	}
+	return out
+}
+
+// arnTail returns the last "/"-separated segment of the resource part of an
+// ARN (a role name, a KMS key ID, ...), or an empty string for other values.
+func arnTail(arn string) string {
+	if !strings.HasPrefix(arn, "arn:") {
+		return ""
+	}
+	if i := strings.LastIndex(arn, "/"); i >= 0 {
+		return arn[i+1:]
+	}
+	if i := strings.LastIndex(arn, ":"); i >= 0 {
+		return arn[i+1:]
+	}
+	return ""
+}
@@ -0,0 +1,111 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/y-miyazaki/arc/internal/aws/resources"
+)
+
+func TestReferences_Categories(t *testing.T) {
+	t.Parallel()
+
+	seen := make(map[string]bool)
+	for _, ref := range references {
+		key := ref.category + "/" + ref.key
+		assert.False(t, seen[key], "duplicate reference %s", key)
+		seen[key] = true
+		assert.NotEmpty(t, ref.kind, key)
+	}
+}
+
+func TestBuild_References(t *testing.T) {
+	t.Parallel()
+
+	const (
+		albARN    = "arn:aws:elasticloadbalancing:us-east-1:123456789012:loadbalancer/app/web/1"
+		webACLARN = "arn:aws:wafv2:us-east-1:123456789012:regional/webacl/edge/abcd"
+		tdARN     = "arn:aws:ecs:us-east-1:123456789012:task-definition/api:3"
+	)
+
+	tests := []struct {
+		name  string
+		input map[string][]resources.Resource
+		want  []Edge
+	}{
+		{
+			name: "waf associations point from the protected resource",
+			input: map[string][]resources.Resource{
+				"elb": {{SubCategory1: "LoadBalancer", Name: "web", Region: "us-east-1", ARN: albARN, RawData: map[string]any{"WAF": webACLARN}}},
+				"waf": {{SubCategory1: "WebACL", Name: "edge", Region: "us-east-1", ARN: webACLARN, RawData: map[string]any{"AssociatedResources": albARN}}},
+			},
+			// Both references describe the same edge, kept once.
+			want: []Edge{{From: albARN, Key: "WAF", Kind: KindProtectedBy, To: webACLARN}},
+		},
+		{
+			name: "kms key by id",
+			input: map[string][]resources.Resource{
+				"kms": {{SubCategory1: "Key", Name: "1234abcd", Region: "us-east-1", ARN: "arn:aws:kms:us-east-1:123456789012:key/1234abcd"}},
+				"s3_bucket": {{
+					SubCategory1: "Bucket", Name: "logs", Region: "us-east-1", ARN: "arn:aws:s3:::logs",
+					RawData: map[string]any{"KMSKey": "1234abcd"},
+				}},
+			},
+			want: []Edge{{From: "arn:aws:s3:::logs", Key: "KMSKey", Kind: KindEncryptedBy, To: "arn:aws:kms:us-east-1:123456789012:key/1234abcd"}},
+		},
+		{
+			name: "task definition of a service, not of the task definition itself",
+			input: map[string][]resources.Resource{
+				"ecs": {
+					{SubCategory1: "TaskDefinition", Name: "api:3", Region: "us-east-1", ARN: tdARN, RawData: map[string]any{"TaskDefinition": tdARN, "RoleARN": "N/A"}},
+					{SubCategory2: "Service", Name: "api", Region: "us-east-1", ARN: "svc", RawData: map[string]any{"TaskDefinition": tdARN}},
+				},
+			},
+			want: []Edge{{From: "svc", Key: "TaskDefinition", Kind: KindRunsTask, To: tdARN}},
+		},
+		{
+			name: "security group of the same region",
+			input: map[string][]resources.Resource{
+				"elasticache": {{SubCategory1: "Cluster", Name: "cache", Region: "eu-west-1", ARN: "cache", RawData: map[string]any{"SecurityGroup": "db"}}},
+				"vpc": {
+					{SubCategory2: "SecurityGroup", Name: "db", Region: "us-east-1", RawData: map[string]any{"ID": "sg-1"}},
+					{SubCategory2: "SecurityGroup", Name: "db", Region: "eu-west-1", RawData: map[string]any{"ID": "sg-2"}},
+				},
+			},
+			want: []Edge{{From: "cache", Key: "SecurityGroup", Kind: KindUsesSecurityGroup, To: "sg-2"}},
+		},
+		{
+			name: "target group of a load balancer",
+			input: map[string][]resources.Resource{
+				"elb": {
+					{SubCategory1: "LoadBalancer", Name: "web", Region: "us-east-1", ARN: albARN},
+					{SubCategory2: "TargetGroup", Name: "web-tg", Region: "us-east-1", ARN: "tg", RawData: map[string]any{"LoadBalancer": albARN}},
+				},
+			},
+			want: []Edge{{From: "tg", Key: "LoadBalancer", Kind: KindAttachedTo, To: albARN}},
+		},
+		{
+			name: "names of other subcategories are not matched",
+			input: map[string][]resources.Resource{
+				"ec2": {{SubCategory1: "Instance", Name: "web", Region: "us-east-1", RawData: map[string]any{"InstanceID": "i-1", "VPC": "main"}}},
+				"vpc": {{SubCategory2: "RouteTable", Name: "main", Region: "us-east-1", RawData: map[string]any{"ID": "rtb-1"}}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, Build(tt.input).Edges)
+		})
+	}
+}
+
+func TestArnTail(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "app", arnTail("arn:aws:iam::123456789012:role/service/app"))
+	assert.Equal(t, "api", arnTail("arn:aws:lambda:us-east-1:123456789012:function:api"))
+	assert.Empty(t, arnTail("alias/app"))
+}
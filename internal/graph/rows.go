@@ -0,0 +1,62 @@
+package graph
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+
+	"github.com/y-miyazaki/arc/internal/aws/resources"
+)
+
+// RowIndexFile is the file name of the row index, written next to files.json.
+const RowIndexFile = "relations.json"
+
+// RowIndex is the relationship index of the HTML viewer: the graph of the
+// resources and the node of every row of the category CSV files, so that the
+// viewer can list the rows a row references or is referenced by.
+type RowIndex struct {
+	Edges []Edge `json:"edges"`
+	Nodes []Node `json:"nodes"`
+	// Rows maps each category to the node IDs of the rows of <category>.csv, in file order.
+	Rows map[string][]string `json:"rows"`
+}
+
+// BuildRowIndex returns the row index of the resources of every category.
+// The resources of a category must be in the order of its CSV file.
+func BuildRowIndex(categoryResources map[string][]resources.Resource) *RowIndex {
+	g := Build(categoryResources)
+	rows := make(map[string][]string, len(categoryResources))
+	for _, category := range slices.Sorted(maps.Keys(categoryResources)) {
+		res := categoryResources[category]
+		if len(res) == 0 {
+			// No CSV file is written for empty categories.
+			continue
+		}
+		ids := make([]string, len(res))
+		for i := range res {
+			ids[i] = NodeID(category, &res[i])
+		}
+		rows[category] = ids
+	}
+	return &RowIndex{Edges: g.Edges, Nodes: g.Nodes, Rows: rows}
+}
+
+// WriteRowIndex writes the row index as a JSON object with "nodes", "edges" and "rows".
+func WriteRowIndex(w io.Writer, idx *RowIndex) error {
+	out := *idx
+	if out.Nodes == nil {
+		out.Nodes = []Node{}
+	}
+	if out.Edges == nil {
+		out.Edges = []Edge{}
+	}
+	if out.Rows == nil {
+		out.Rows = map[string][]string{}
+	}
+	if err := json.NewEncoder(w).Encode(out); err != nil {
+		return fmt.Errorf("failed to encode row index: %w", err)
+	}
+	return nil
+}
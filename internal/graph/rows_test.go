@@ -0,0 +1,38 @@
+package graph
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildRowIndex(t *testing.T) {
+	t.Parallel()
+
+	res := testResources()
+	res["rds"] = nil
+	idx := BuildRowIndex(res)
+
+	assert.Equal(t, Build(res).Edges, idx.Edges)
+	assert.Equal(t, []string{testLambdaARN}, idx.Rows["lambda"])
+	assert.Equal(t, []string{"vpc-1", "subnet-1", "sg-1", "sg-2", "sg-3"}, idx.Rows["vpc"])
+	assert.Equal(t, []string{"ec2:us-east-1:web"}, idx.Rows["ec2"])
+	assert.NotContains(t, idx.Rows, "rds")
+}
+
+func TestWriteRowIndex(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteRowIndex(&buf, BuildRowIndex(testResources())))
+	var got RowIndex
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &got))
+	assert.Equal(t, *BuildRowIndex(testResources()), got)
+
+	buf.Reset()
+	require.NoError(t, WriteRowIndex(&buf, &RowIndex{}))
+	assert.JSONEq(t, `{"edges": [], "nodes": [], "rows": {}}`, buf.String())
+}
@@ -0,0 +1,365 @@
+// Package redact masks, hashes or drops sensitive values of the collected
+// resources before they are written. A value is sensitive when it is the value
+// of a key=value entry whose key matches a key pattern, such as an environment
+// variable named DB_PASSWORD, or when a detector recognizes it, such as an AWS
+// access key ID or a JWT.
+package redact
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"path"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/y-miyazaki/arc/internal/aws/resources"
+)
+
+// Redaction modes.
+const (
+	// ModeMask replaces sensitive values with Mask.
+	ModeMask = "mask"
+	// ModeHash replaces sensitive values with HashPrefix and their HMAC-SHA256
+	// keyed with the salt, so that a changed value can still be detected.
+	ModeHash = "hash"
+	// ModeDrop removes the entries holding sensitive values.
+	ModeDrop = "drop"
+	// ModeNone disables redaction.
+	ModeNone = "none"
+)
+
+// Value detectors.
+const (
+	// DetectorAWSKeys recognizes AWS access key IDs.
+	DetectorAWSKeys = "aws-keys"
+	// DetectorEmail recognizes email addresses.
+	DetectorEmail = "email"
+	// DetectorJWT recognizes JSON Web Tokens.
+	DetectorJWT = "jwt"
+	// DetectorPassword recognizes passwords in password=... or "password": ...
+	// settings and in the user information of URLs.
+	DetectorPassword = "password"
+)
+
+// Replacement values.
+const (
+	// Mask replaces the sensitive values of ModeMask.
+	Mask = "*****"
+	// HashPrefix prefixes the hashes of ModeHash.
+	HashPrefix = "hmac:"
+	// hashLength is the number of hexadecimal digits of the hashes of ModeHash.
+	hashLength = 16
+)
+
+// Modes lists the supported redaction modes.
+var Modes = []string{ModeMask, ModeHash, ModeDrop, ModeNone}
+
+// Detectors lists the supported value detectors.
+var Detectors = []string{DetectorAWSKeys, DetectorEmail, DetectorJWT, DetectorPassword}
+
+// DefaultKeyPatterns are the key patterns of the entries redacted by default.
+// They match key suffixes so that settings such as TemporaryPasswordValidityDays
+// are kept.
+var DefaultKeyPatterns = []string{
+	"*api_key", "*apikey", "*credentials", "*passwd", "*password", "*private_key*",
+	"*secret", "*secret_access_key", "*secret_key", "*token",
+}
+
+// detectorPatterns are the regular expressions of the detectors. When an
+// expression has a group, only the text of the group is sensitive.
+var detectorPatterns = map[string][]*regexp.Regexp{
+	DetectorAWSKeys: {regexp.MustCompile(`\b(?:AKIA|ASIA|ABIA|ACCA)[0-9A-Z]{16}\b`)},
+	DetectorEmail:   {regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`)},
+	DetectorJWT:     {regexp.MustCompile(`\beyJ[A-Za-z0-9_-]+\.eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*`)},
+	DetectorPassword: {
+		regexp.MustCompile(`(?i)\bpass(?:word|wd)?["']?\s*[=:]\s*["']?([^\s"'&;,]+)`),
+		regexp.MustCompile(`[A-Za-z][A-Za-z0-9+.-]*://[^/\s:@]+:([^/\s@]+)@`),
+	},
+}
+
+// Sentinel errors for redaction options (alphabetical order).
+var (
+	ErrInvalidKeyPattern = errors.New("invalid redaction key pattern")
+	ErrSaltRequired      = errors.New("hash redaction requires a salt")
+	ErrUnknownDetector   = errors.New("unknown redaction detector")
+	ErrUnknownMode       = errors.New("unknown redaction mode")
+)
+
+// Options configures a Redactor.
+type Options struct {
+	// Detectors are the names of the value detectors, from Detectors.
+	Detectors []string
+	// KeyPatterns are case-insensitive glob patterns (* and ?) of the keys of
+	// key=value entries, such as environment variables, whose value is sensitive.
+	KeyPatterns []string
+	// Mode is one of Modes.
+	Mode string
+	// Salt keys the hashes of ModeHash.
+	Salt string
+}
+
+// Redactor redacts the sensitive values of resources.
+type Redactor struct {
+	detectors   []*regexp.Regexp
+	keyPatterns []string
+	mode        string
+	salt        []byte
+}
+
+// New returns the Redactor of opts. It returns nil when opts redacts nothing:
+// with ModeNone, or without key patterns and detectors.
+func New(opts *Options) (*Redactor, error) {
+	if !slices.Contains(Modes, opts.Mode) {
+		return nil, fmt.Errorf("%w: %q (supported: %s)", ErrUnknownMode, opts.Mode, strings.Join(Modes, ", "))
+	}
+	if opts.Mode == ModeNone || len(opts.KeyPatterns)+len(opts.Detectors) == 0 {
+		return nil, nil //nolint:nilnil // a nil Redactor redacts nothing
+	}
+	if opts.Mode == ModeHash && opts.Salt == "" {
+		return nil, ErrSaltRequired
+	}
+
+	r := &Redactor{mode: opts.Mode, salt: []byte(opts.Salt)}
+	for _, p := range opts.KeyPatterns {
+		pattern := strings.ToLower(p)
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("%w: %q: %w", ErrInvalidKeyPattern, p, err)
+		}
+		r.keyPatterns = append(r.keyPatterns, pattern)
+	}
+	for _, name := range opts.Detectors {
+		patterns, ok := detectorPatterns[name]
+		if !ok {
+			return nil, fmt.Errorf("%w: %q (supported: %s)", ErrUnknownDetector, name, strings.Join(Detectors, ", "))
+		}
+		r.detectors = append(r.detectors, patterns...)
+	}
+	return r, nil
+}
+
+// Apply redacts the RawData and TypedData of res in place and returns the
+// number of sensitive values redacted, counted on RawData. String values are
+// redacted line by line, since list values are written one entry per line.
+// The RawData keys are the output columns and are not matched against the key
+// patterns. Values that are already redacted, such as those of resumed
+// checkpoints, are kept and not counted again, so that Apply can be repeated.
+// A nil Redactor redacts nothing.
+func (r *Redactor) Apply(res []resources.Resource) int {
+	if r == nil {
+		return 0
+	}
+	count := 0
+	for i := range res {
+		for key, value := range res[i].RawData {
+			if s, ok := value.(string); ok {
+				redacted, n := r.redactLines(s)
+				res[i].RawData[key] = redacted
+				count += n
+			}
+		}
+		for key, value := range res[i].TypedData {
+			if redacted, keep := r.redactValue(value); keep {
+				res[i].TypedData[key] = redacted
+			} else {
+				res[i].TypedData[key] = nil
+			}
+		}
+	}
+	return count
+}
+
+// Redacted returns redacted copies of res and leaves res unchanged.
+// A nil Redactor returns res.
+func (r *Redactor) Redacted(res []resources.Resource) []resources.Resource {
+	if r == nil {
+		return res
+	}
+	out := make([]resources.Resource, len(res))
+	for i := range res {
+		out[i] = res[i]
+		// Apply replaces the map values without modifying them, so copying
+		// the maps is enough.
+		out[i].RawData = maps.Clone(res[i].RawData)
+		out[i].TypedData = maps.Clone(res[i].TypedData)
+	}
+	r.Apply(out)
+	return out
+}
+
+// Text returns s with its sensitive values redacted line by line, for text
+// computed from resources before they are redacted, such as the evidence of
+// findings. A nil Redactor returns s.
+func (r *Redactor) Text(s string) string {
+	if r == nil {
+		return s
+	}
+	redacted, _ := r.redactLines(s)
+	return redacted
+}
+
+// redactLines redacts every line of s and returns the result and the number
+// of sensitive values. ModeDrop removes the lines holding sensitive values.
+func (r *Redactor) redactLines(s string) (string, int) {
+	if s == "" {
+		return s, 0
+	}
+	lines := strings.Split(s, "\n")
+	out := lines[:0]
+	count := 0
+	for _, line := range lines {
+		redacted, n := r.redactEntry(line)
+		count += n
+		if n > 0 && r.mode == ModeDrop {
+			continue
+		}
+		out = append(out, redacted)
+	}
+	if count == 0 {
+		return s, 0
+	}
+	return strings.Join(out, "\n"), count
+}
+
+// redactEntry redacts one line: the whole value of a key=value entry whose key
+// matches a key pattern, and otherwise the detected values.
+func (r *Redactor) redactEntry(line string) (string, int) {
+	if key, value, ok := strings.Cut(line, "="); ok && r.matchKey(key) {
+		if isRedacted(value) {
+			return line, 0
+		}
+		return key + "=" + r.replacement(value), 1
+	}
+	count := 0
+	for _, re := range r.detectors {
+		matches := re.FindAllStringSubmatchIndex(line, -1)
+		if len(matches) == 0 {
+			continue
+		}
+		var b strings.Builder
+		last := 0
+		for _, m := range matches {
+			// Redact the group of the expression when it has one, or the whole match.
+			start, end := m[0], m[1]
+			if len(m) >= 4 && m[2] >= 0 {
+				start, end = m[2], m[3]
+			}
+			if isRedacted(line[start:end]) {
+				continue
+			}
+			b.WriteString(line[last:start])
+			b.WriteString(r.replacement(line[start:end]))
+			last = end
+			count++
+		}
+		b.WriteString(line[last:])
+		line = b.String()
+	}
+	return line, count
+}
+
+// redactValue redacts a TypedData value: strings line by line, the elements
+// of lists and the values of maps. ModeDrop removes the list elements holding
+// sensitive values. The second result is false when the whole value is dropped.
+// Values that are not JSON-native, such as structs, are redacted in the form
+// they are written in: their JSON encoding, or their string form.
+func (r *Redactor) redactValue(value any) (any, bool) {
+	switch v := value.(type) {
+	case string:
+		redacted, n := r.redactLines(v)
+		return redacted, n == 0 || r.mode != ModeDrop || redacted != ""
+	case []any:
+		out := make([]any, 0, len(v))
+		for _, elem := range v {
+			if redacted, keep := r.redactValue(elem); keep {
+				out = append(out, redacted)
+			}
+		}
+		return out, true
+	case map[string]any:
+		return r.redactMap(v), true
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Invalid, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return value, true
+	case reflect.String:
+		// Named string types, such as the enums of the AWS SDK.
+		return r.redactValue(rv.String())
+	default:
+		if data, err := json.Marshal(value); err == nil {
+			var decoded any
+			if err := json.Unmarshal(data, &decoded); err == nil {
+				return r.redactValue(decoded)
+			}
+		}
+		return r.redactValue(fmt.Sprint(value))
+	}
+}
+
+// redactMap returns a copy of m with the values of the keys matching a key
+// pattern replaced (or removed with ModeDrop) and the other values redacted.
+func (r *Redactor) redactMap(m map[string]any) map[string]any {
+	out := make(map[string]any, len(m))
+	for key, value := range m {
+		if value != nil && r.matchKey(key) {
+			switch s := fmt.Sprint(value); {
+			case isRedacted(s):
+				out[key] = value
+			case r.mode != ModeDrop:
+				out[key] = r.replacement(s)
+			}
+			continue
+		}
+		if redacted, keep := r.redactValue(value); keep {
+			out[key] = redacted
+		}
+	}
+	return out
+}
+
+// matchKey reports whether key matches one of the key patterns, ignoring case.
+func (r *Redactor) matchKey(key string) bool {
+	key = strings.ToLower(strings.TrimSpace(key))
+	for _, pattern := range r.keyPatterns {
+		if ok, _ := path.Match(pattern, key); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// replacement returns the text replacing the sensitive value under the mode.
+// ModeDrop removes the entries holding the replaced values afterwards.
+func (r *Redactor) replacement(value string) string {
+	if r.mode != ModeHash {
+		return Mask
+	}
+	mac := hmac.New(sha256.New, r.salt)
+	mac.Write([]byte(value))
+	return HashPrefix + hex.EncodeToString(mac.Sum(nil))[:hashLength]
+}
+
+// isRedacted reports whether value is already a replacement: Mask or a hash
+// of ModeHash.
+func isRedacted(value string) bool {
+	if value == Mask {
+		return true
+	}
+	digits, ok := strings.CutPrefix(value, HashPrefix)
+	if !ok || len(digits) != hashLength {
+		return false
+	}
+	_, err := hex.DecodeString(digits)
+	return err == nil
+}
@@ -0,0 +1,161 @@
+// Package runreport writes run.json, the machine-readable report of a
+// collection run. It records the outcome and statistics of every (category,
+// region) task, so that an interrupted or partially failed run can be told
+// apart from a complete one and regressions can be alerted on without parsing logs.
+package runreport
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"time"
+)
+
+// FileName is the file name of the run report, written next to all.csv.
+const FileName = "run.json"
+
+// Run statuses.
+const (
+	// RunCompleted is the status of a run whose tasks all completed or were skipped,
+	// including tasks skipped for lack of permissions.
+	RunCompleted = "completed"
+	// RunPartial is the status of a run with failed tasks.
+	RunPartial = "partial"
+	// RunInterrupted is the status of a run canceled by a signal or the timeout.
+	RunInterrupted = "interrupted"
+)
+
+// Task statuses.
+const (
+	// TaskComplete is the status of a task whose resources are all in the outputs.
+	TaskComplete = "complete"
+	// TaskFailed is the status of a task that returned an error.
+	TaskFailed = "failed"
+	// TaskIncomplete is the status of a task that was not started, or not
+	// finished within the grace period, when the run was interrupted.
+	TaskIncomplete = "incomplete"
+	// TaskPermissionSkipped is the status of a task denied by IAM (AccessDenied,
+	// UnauthorizedOperation), which does not fail the run.
+	TaskPermissionSkipped = "permission-skipped"
+	// TaskSkipped is the status of a task in a region not enabled for the account.
+	TaskSkipped = "skipped"
+)
+
+// Task is the outcome of collecting one category in one region.
+type Task struct {
+	Category string `json:"category"`
+	Region   string `json:"region"`
+	Status   string `json:"status"`
+	// Error is the error text of a task that did not complete.
+	Error string `json:"error,omitempty"`
+	// DurationMs is the collection time in milliseconds.
+	DurationMs int64 `json:"durationMs"`
+	// Resources is the number of resources returned by the collector, before filtering.
+	Resources int `json:"resources"`
+	// APICalls is the number of AWS API call attempts, including retries.
+	APICalls int64 `json:"apiCalls"`
+	// Resumed is set for tasks read back from a checkpoint with --resume,
+	// whose duration and API calls are not counted again.
+	Resumed bool `json:"resumed,omitempty"`
+}
+
+// Run identifies a collection run of an account.
+type Run struct {
+	StartedAt time.Time
+	EndedAt   time.Time
+	// Redactions are the numbers of sensitive values redacted per category.
+	Redactions map[string]int
+	Version    string
+	AccountID  string
+	Regions    []string
+}
+
+// Report is the content of run.json.
+type Report struct {
+	Version   string `json:"version"`
+	AccountID string `json:"accountId"`
+	Status    string `json:"status"`
+	// Interruption is the cause of the interruption of a RunInterrupted run.
+	Interruption string    `json:"interruption,omitempty"`
+	StartedAt    time.Time `json:"startedAt"`
+	EndedAt      time.Time `json:"endedAt"`
+	DurationMs   int64     `json:"durationMs"`
+	Regions      []string  `json:"regions"`
+	// Resources and APICalls are the totals of the tasks.
+	Resources int   `json:"resources"`
+	APICalls  int64 `json:"apiCalls"`
+	// Redactions are the numbers of sensitive values redacted per category,
+	// omitting the categories without any.
+	Redactions map[string]int `json:"redactions,omitempty"`
+	Tasks      []Task         `json:"tasks"`
+}
+
+// New returns the report of the tasks of run, sorted by category and region.
+// The run status is RunInterrupted when interruption is not nil, RunPartial
+// when a task failed or is incomplete, and RunCompleted otherwise.
+func New(run Run, tasks []Task, interruption error) *Report {
+	tasks = slices.Clone(tasks)
+	slices.SortFunc(tasks, func(a, b Task) int {
+		return cmp.Or(cmp.Compare(a.Category, b.Category), cmp.Compare(a.Region, b.Region))
+	})
+	r := &Report{
+		Version:    run.Version,
+		AccountID:  run.AccountID,
+		Status:     RunCompleted,
+		StartedAt:  run.StartedAt.UTC(),
+		EndedAt:    run.EndedAt.UTC(),
+		DurationMs: run.EndedAt.Sub(run.StartedAt).Milliseconds(),
+		Regions:    slices.Clone(run.Regions),
+		Tasks:      tasks,
+	}
+	for category, n := range run.Redactions {
+		if n == 0 {
+			continue
+		}
+		if r.Redactions == nil {
+			r.Redactions = make(map[string]int)
+		}
+		r.Redactions[category] = n
+	}
+	if r.Regions == nil {
+		r.Regions = []string{}
+	}
+	if r.Tasks == nil {
+		r.Tasks = []Task{}
+	}
+	for _, t := range tasks {
+		r.Resources += t.Resources
+		r.APICalls += t.APICalls
+	}
+	if slices.ContainsFunc(tasks, func(t Task) bool { return t.Status == TaskFailed || t.Status == TaskIncomplete }) {
+		r.Status = RunPartial
+	}
+	if interruption != nil {
+		r.Status = RunInterrupted
+		r.Interruption = interruption.Error()
+	}
+	return r
+}
+
+// Incomplete returns the tasks with the TaskIncomplete status.
+func (r *Report) Incomplete() []Task {
+	var tasks []Task
+	for _, t := range r.Tasks {
+		if t.Status == TaskIncomplete {
+			tasks = append(tasks, t)
+		}
+	}
+	return tasks
+}
+
+// Write writes the report as indented JSON.
+func Write(w io.Writer, r *Report) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(r); err != nil {
+		return fmt.Errorf("failed to encode run report: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,168 @@
+package runreport
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name             string
+		tasks            []Task
+		interruption     error
+		wantStatus       string
+		wantInterruption string
+		wantIncomplete   []Task
+	}{
+		{
+			name: "completed with skipped regions",
+			tasks: []Task{
+				{Category: "sqs", Region: "us-east-1", Status: TaskComplete},
+				{Category: "sqs", Region: "ap-east-1", Status: TaskSkipped},
+			},
+			wantStatus: RunCompleted,
+		},
+		{
+			name: "completed with permission skipped tasks",
+			tasks: []Task{
+				{Category: "sqs", Region: "us-east-1", Status: TaskComplete},
+				{Category: "ec2", Region: "us-east-1", Status: TaskPermissionSkipped},
+			},
+			wantStatus: RunCompleted,
+		},
+		{
+			name: "partial with a failed task",
+			tasks: []Task{
+				{Category: "sqs", Region: "us-east-1", Status: TaskComplete},
+				{Category: "ec2", Region: "us-east-1", Status: TaskFailed},
+			},
+			wantStatus: RunPartial,
+		},
+		{
+			name: "interrupted",
+			tasks: []Task{
+				{Category: "sqs", Region: "us-east-1", Status: TaskIncomplete},
+				{Category: "ec2", Region: "us-east-1", Status: TaskComplete},
+			},
+			interruption:     context.DeadlineExceeded,
+			wantStatus:       RunInterrupted,
+			wantInterruption: "context deadline exceeded",
+			wantIncomplete:   []Task{{Category: "sqs", Region: "us-east-1", Status: TaskIncomplete}},
+		},
+		{
+			name:       "no task",
+			wantStatus: RunCompleted,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			r := New(Run{}, tt.tasks, tt.interruption)
+			assert.Equal(t, tt.wantStatus, r.Status)
+			assert.Equal(t, tt.wantInterruption, r.Interruption)
+			assert.Equal(t, tt.wantIncomplete, r.Incomplete())
+			assert.Len(t, r.Tasks, len(tt.tasks))
+		})
+	}
+}
+
+func TestNew_SortsTasks(t *testing.T) {
+	t.Parallel()
+
+	tasks := []Task{
+		{Category: "sqs", Region: "us-east-1"},
+		{Category: "ec2", Region: "us-west-2"},
+		{Category: "ec2", Region: "eu-west-1"},
+	}
+	r := New(Run{}, tasks, nil)
+
+	assert.Equal(t, []Task{
+		{Category: "ec2", Region: "eu-west-1"},
+		{Category: "ec2", Region: "us-west-2"},
+		{Category: "sqs", Region: "us-east-1"},
+	}, r.Tasks)
+	assert.Equal(t, "sqs", tasks[0].Category, "the given tasks are not modified")
+}
+
+func TestNew_Run(t *testing.T) {
+	t.Parallel()
+
+	startedAt := time.Date(2026, 1, 2, 12, 0, 0, 0, time.FixedZone("JST", 9*60*60))
+	run := Run{
+		StartedAt: startedAt,
+		EndedAt:   startedAt.Add(1500 * time.Millisecond),
+		Version:   "v1.2.3",
+		AccountID: "123456789012",
+		Regions:   []string{"us-east-1"},
+		// Categories without redactions are omitted.
+		Redactions: map[string]int{"lambda": 3, "sqs": 0},
+	}
+	r := New(run, []Task{
+		{Category: "sqs", Region: "us-east-1", Status: TaskComplete, Resources: 2, APICalls: 3},
+		{Category: "ec2", Region: "us-east-1", Status: TaskComplete, Resources: 5, APICalls: 7},
+	}, nil)
+
+	assert.Equal(t, "v1.2.3", r.Version)
+	assert.Equal(t, "123456789012", r.AccountID)
+	assert.Equal(t, time.UTC, r.StartedAt.Location())
+	assert.True(t, r.StartedAt.Equal(startedAt))
+	assert.Equal(t, int64(1500), r.DurationMs)
+	assert.Equal(t, []string{"us-east-1"}, r.Regions)
+	assert.Equal(t, 7, r.Resources)
+	assert.Equal(t, int64(10), r.APICalls)
+	assert.Equal(t, map[string]int{"lambda": 3}, r.Redactions)
+}
+
+func TestWrite(t *testing.T) {
+	t.Parallel()
+
+	startedAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	run := Run{
+		StartedAt:  startedAt,
+		EndedAt:    startedAt.Add(2 * time.Second),
+		Version:    "v1.2.3",
+		AccountID:  "123456789012",
+		Regions:    []string{"us-east-1"},
+		Redactions: map[string]int{"sqs": 1},
+	}
+	tasks := []Task{{Category: "sqs", Region: "us-east-1", Status: TaskIncomplete, Error: "context canceled", DurationMs: 1500, APICalls: 2}}
+
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, New(run, tasks, context.Canceled)))
+	assert.JSONEq(t, `{
+		"version": "v1.2.3",
+		"accountId": "123456789012",
+		"status": "interrupted",
+		"interruption": "context canceled",
+		"startedAt": "2026-01-02T03:04:05Z",
+		"endedAt": "2026-01-02T03:04:07Z",
+		"durationMs": 2000,
+		"regions": ["us-east-1"],
+		"resources": 0,
+		"apiCalls": 2,
+		"redactions": {"sqs": 1},
+		"tasks": [{"category": "sqs", "region": "us-east-1", "status": "incomplete", "error": "context canceled", "durationMs": 1500, "resources": 0, "apiCalls": 2}]
+	}`, buf.String())
+
+	buf.Reset()
+	require.NoError(t, Write(&buf, New(Run{StartedAt: startedAt, EndedAt: startedAt}, nil, nil)))
+	assert.JSONEq(t, `{
+		"version": "",
+		"accountId": "",
+		"status": "completed",
+		"startedAt": "2026-01-02T03:04:05Z",
+		"endedAt": "2026-01-02T03:04:05Z",
+		"durationMs": 0,
+		"regions": [],
+		"resources": 0,
+		"apiCalls": 0,
+		"tasks": []
+	}`, buf.String())
+}
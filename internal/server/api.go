@@ -0,0 +1,289 @@
+package server
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/y-miyazaki/arc/internal/exporter"
+)
+
+// manifestFile is the manifest of the category CSV files of an account.
+const manifestFile = "files.json"
+
+// Search result limits.
+const (
+	DefaultSearchLimit = 100
+	MaxSearchLimit     = 1000
+)
+
+var (
+	ErrInvalidParameter = errors.New("invalid parameter")
+	ErrNotFound         = errors.New("not found")
+)
+
+// Category is a category of an account, as returned by the categories endpoint.
+type Category struct {
+	Name      string `json:"name"`
+	Path      string `json:"path"`
+	Resources int    `json:"resources"`
+}
+
+// Resources is a page of the resources of a category: the CSV columns and one
+// object per row, keyed by column.
+type Resources struct {
+	Category  string              `json:"category"`
+	Columns   []string            `json:"columns"`
+	Resources []map[string]string `json:"resources"`
+	Total     int                 `json:"total"`
+}
+
+// SearchResult is a resource matching a search.
+type SearchResult struct {
+	Category string            `json:"category"`
+	Resource map[string]string `json:"resource"`
+}
+
+// SearchResults are the resources matching a search, in category order.
+// Truncated is set when more resources match than the limit.
+type SearchResults struct {
+	Query     string         `json:"query"`
+	Results   []SearchResult `json:"results"`
+	Truncated bool           `json:"truncated"`
+}
+
+// serveAccounts serves the accounts of the output directory.
+func (h *handler) serveAccounts(w http.ResponseWriter, _ *http.Request) {
+	accounts, err := h.accounts()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, accounts)
+}
+
+// serveCategories serves the categories of an account with their resource count.
+func (h *handler) serveCategories(w http.ResponseWriter, r *http.Request) {
+	entries, err := h.categoryEntries(r.PathValue("account"))
+	if err != nil {
+		writeLookupError(w, err)
+		return
+	}
+	categories := make([]Category, 0, len(entries))
+	for _, entry := range entries {
+		_, rows, readErr := h.readCSV(r.PathValue("account"), entry.Path)
+		if readErr != nil {
+			writeError(w, http.StatusInternalServerError, readErr)
+			return
+		}
+		categories = append(categories, Category{Name: entry.DisplayName, Path: entry.Path, Resources: len(rows)})
+	}
+	writeJSON(w, categories)
+}
+
+// serveResources serves the resources of a category, paged with the offset and
+// limit query parameters (all resources by default).
+func (h *handler) serveResources(w http.ResponseWriter, r *http.Request) {
+	account, category := r.PathValue("account"), r.PathValue("category")
+	entry, err := h.categoryEntry(account, category)
+	if err != nil {
+		writeLookupError(w, err)
+		return
+	}
+	offset, err := intParam(r, "offset", 0)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	limit, err := intParam(r, "limit", 0)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	columns, rows, err := h.readCSV(account, entry.Path)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	page := rows[min(offset, len(rows)):]
+	if limit > 0 && limit < len(page) {
+		page = page[:limit]
+	}
+	writeJSON(w, Resources{Category: category, Columns: columns, Resources: page, Total: len(rows)})
+}
+
+// serveSearch serves the resources of an account with a value containing the
+// q query parameter (case-insensitive), optionally restricted to the category
+// parameter and limited to limit results (DefaultSearchLimit by default).
+func (h *handler) serveSearch(w http.ResponseWriter, r *http.Request) {
+	account := r.PathValue("account")
+	query := strings.TrimSpace(r.URL.Query().Get("q"))
+	if query == "" {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("%w: q is required", ErrInvalidParameter))
+		return
+	}
+	limit, err := intParam(r, "limit", DefaultSearchLimit)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	limit = min(max(limit, 1), MaxSearchLimit)
+	entries, err := h.categoryEntries(account)
+	if err != nil {
+		writeLookupError(w, err)
+		return
+	}
+	if category := r.URL.Query().Get("category"); category != "" {
+		entries = slices.DeleteFunc(entries, func(e exporter.FileManifestEntry) bool { return e.DisplayName != category })
+	}
+
+	needle := strings.ToLower(query)
+	out := SearchResults{Query: query, Results: []SearchResult{}}
+	for _, entry := range entries {
+		_, rows, readErr := h.readCSV(account, entry.Path)
+		if readErr != nil {
+			writeError(w, http.StatusInternalServerError, readErr)
+			return
+		}
+		for _, row := range rows {
+			if !matches(row, needle) {
+				continue
+			}
+			if len(out.Results) == limit {
+				out.Truncated = true
+				writeJSON(w, out)
+				return
+			}
+			out.Results = append(out.Results, SearchResult{Category: entry.DisplayName, Resource: row})
+		}
+	}
+	writeJSON(w, out)
+}
+
+// matches reports whether a value of row contains the lower-case needle.
+func matches(row map[string]string, needle string) bool {
+	for _, v := range row {
+		if strings.Contains(strings.ToLower(v), needle) {
+			return true
+		}
+	}
+	return false
+}
+
+// categoryEntries returns the files.json entries of the category CSV files of
+// account. The account must be a collected account of the output directory.
+func (h *handler) categoryEntries(account string) ([]exporter.FileManifestEntry, error) {
+	accounts, err := h.accounts()
+	if err != nil {
+		return nil, err
+	}
+	if !slices.ContainsFunc(accounts, func(a exporter.AccountIndexEntry) bool { return a.AccountID == account }) {
+		return nil, fmt.Errorf("%w: account %q", ErrNotFound, account)
+	}
+	b, err := os.ReadFile(filepath.Join(h.dir, account, manifestFile)) //nolint:gosec // G304: account is a directory of the output directory
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", manifestFile, err)
+	}
+	var entries []exporter.FileManifestEntry
+	if err := json.Unmarshal(b, &entries); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", manifestFile, err)
+	}
+	// Findings and the relationship index are not categories.
+	return slices.DeleteFunc(entries, func(e exporter.FileManifestEntry) bool { return e.Kind != "" }), nil
+}
+
+// categoryEntry returns the files.json entry of category of account.
+func (h *handler) categoryEntry(account, category string) (*exporter.FileManifestEntry, error) {
+	entries, err := h.categoryEntries(account)
+	if err != nil {
+		return nil, err
+	}
+	for i := range entries {
+		if entries[i].DisplayName == category {
+			return &entries[i], nil
+		}
+	}
+	return nil, fmt.Errorf("%w: category %q", ErrNotFound, category)
+}
+
+// readCSV reads a category CSV file listed in the files.json of account and
+// returns its columns and one map per row.
+func (h *handler) readCSV(account, file string) (columns []string, rows []map[string]string, err error) {
+	f, err := os.Open(filepath.Join(h.dir, account, filepath.FromSlash(file))) //nolint:gosec // G304: file is listed in files.json
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open %s: %w", file, err)
+	}
+	defer func() {
+		if closeErr := f.Close(); closeErr != nil {
+			err = errors.Join(err, fmt.Errorf("failed to close %s: %w", file, closeErr))
+		}
+	}()
+	reader := csv.NewReader(f)
+	reader.FieldsPerRecord = -1
+	rows = []map[string]string{}
+	for {
+		record, readErr := reader.Read()
+		if errors.Is(readErr, io.EOF) {
+			return columns, rows, nil
+		}
+		if readErr != nil {
+			return nil, nil, fmt.Errorf("failed to parse %s: %w", file, readErr)
+		}
+		if columns == nil {
+			columns = record
+			continue
+		}
+		row := make(map[string]string, len(columns))
+		for i, column := range columns {
+			if i < len(record) {
+				row[column] = record[i]
+			}
+		}
+		rows = append(rows, row)
+	}
+}
+
+// intParam returns the non-negative integer query parameter name of r, or def when it is not set.
+func intParam(r *http.Request, name string, def int) (int, error) {
+	s := r.URL.Query().Get(name)
+	if s == "" {
+		return def, nil
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("%w: %s must be a non-negative integer", ErrInvalidParameter, name)
+	}
+	return n, nil
+}
+
+// writeJSON writes v as a JSON response.
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	_ = encoder.Encode(v)
+}
+
+// writeLookupError writes err as a 404 response when it is ErrNotFound, and a 500 response otherwise.
+func writeLookupError(w http.ResponseWriter, err error) {
+	if errors.Is(err, ErrNotFound) {
+		writeError(w, http.StatusNotFound, err)
+		return
+	}
+	writeError(w, http.StatusInternalServerError, err)
+}
+
+// writeError writes err as a JSON response {"error": "..."} with status.
+func writeError(w http.ResponseWriter, status int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+}
@@ -0,0 +1,133 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/y-miyazaki/arc/internal/exporter"
+)
+
+// decode decodes a JSON body into a value of type T.
+func decode[T any](t *testing.T, body []byte) T {
+	t.Helper()
+	var v T
+	require.NoError(t, json.Unmarshal(body, &v))
+	return v
+}
+
+func TestServeAccounts(t *testing.T) {
+	t.Parallel()
+
+	rec := get(t, newTestHandler(t, Options{}), "/api/accounts", nil)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	assert.Equal(t, []exporter.AccountIndexEntry{
+		{AccountID: "222222222222", DisplayName: "prod(222222222222)"},
+		{AccountID: "111111111111"},
+	}, decode[[]exporter.AccountIndexEntry](t, rec.Body.Bytes()))
+}
+
+func TestServeCategories(t *testing.T) {
+	t.Parallel()
+
+	h := newTestHandler(t, Options{})
+
+	rec := get(t, h, "/api/accounts/111111111111/categories", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, []Category{
+		{Name: "ec2", Path: "resources/ec2.csv", Resources: 3},
+		{Name: "s3", Path: "resources/s3.csv", Resources: 1},
+	}, decode[[]Category](t, rec.Body.Bytes()))
+
+	rec = get(t, h, "/api/accounts/222222222222/categories", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `[]`, rec.Body.String())
+
+	rec = get(t, h, "/api/accounts/999999999999/categories", nil)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Contains(t, decode[map[string]string](t, rec.Body.Bytes())["error"], "999999999999")
+}
+
+func TestServeResources(t *testing.T) {
+	t.Parallel()
+
+	h := newTestHandler(t, Options{})
+	tests := []struct {
+		name      string
+		target    string
+		wantCode  int
+		wantNames []string
+		wantTotal int
+	}{
+		{name: "all resources", target: "/api/accounts/111111111111/categories/ec2/resources", wantCode: http.StatusOK, wantNames: []string{"web", "batch", "Worker"}, wantTotal: 3},
+		{name: "paged", target: "/api/accounts/111111111111/categories/ec2/resources?offset=1&limit=1", wantCode: http.StatusOK, wantNames: []string{"batch"}, wantTotal: 3},
+		{name: "offset past the end", target: "/api/accounts/111111111111/categories/ec2/resources?offset=10", wantCode: http.StatusOK, wantNames: []string{}, wantTotal: 3},
+		{name: "invalid limit", target: "/api/accounts/111111111111/categories/ec2/resources?limit=-1", wantCode: http.StatusBadRequest},
+		{name: "unknown category", target: "/api/accounts/111111111111/categories/rds/resources", wantCode: http.StatusNotFound},
+		{name: "findings are not a category", target: "/api/accounts/111111111111/categories/findings/resources", wantCode: http.StatusNotFound},
+		{name: "unknown account", target: "/api/accounts/999999999999/categories/ec2/resources", wantCode: http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			rec := get(t, h, tt.target, nil)
+			require.Equal(t, tt.wantCode, rec.Code)
+			if tt.wantCode != http.StatusOK {
+				return
+			}
+			got := decode[Resources](t, rec.Body.Bytes())
+			assert.Equal(t, "ec2", got.Category)
+			assert.Equal(t, []string{"Category", "Name", "State"}, got.Columns)
+			assert.Equal(t, tt.wantTotal, got.Total)
+			names := []string{}
+			for _, r := range got.Resources {
+				names = append(names, r["Name"])
+			}
+			assert.Equal(t, tt.wantNames, names)
+		})
+	}
+}
+
+func TestServeSearch(t *testing.T) {
+	t.Parallel()
+
+	h := newTestHandler(t, Options{})
+	tests := []struct {
+		name          string
+		target        string
+		wantCode      int
+		wantResults   []string
+		wantTruncated bool
+	}{
+		{name: "matches across categories", target: "/api/accounts/111111111111/search?q=WEB", wantCode: http.StatusOK, wantResults: []string{"ec2/web", "s3/web-assets"}},
+		{name: "restricted to a category", target: "/api/accounts/111111111111/search?q=web&category=s3", wantCode: http.StatusOK, wantResults: []string{"s3/web-assets"}},
+		{name: "truncated", target: "/api/accounts/111111111111/search?q=running&limit=1", wantCode: http.StatusOK, wantResults: []string{"ec2/web"}, wantTruncated: true},
+		{name: "no match", target: "/api/accounts/111111111111/search?q=nothing", wantCode: http.StatusOK, wantResults: []string{}},
+		{name: "findings are not searched", target: "/api/accounts/111111111111/search?q=r1", wantCode: http.StatusOK, wantResults: []string{}},
+		{name: "missing query", target: "/api/accounts/111111111111/search?q=%20", wantCode: http.StatusBadRequest},
+		{name: "invalid limit", target: "/api/accounts/111111111111/search?q=web&limit=x", wantCode: http.StatusBadRequest},
+		{name: "unknown account", target: "/api/accounts/999999999999/search?q=web", wantCode: http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			rec := get(t, h, tt.target, nil)
+			require.Equal(t, tt.wantCode, rec.Code)
+			if tt.wantCode != http.StatusOK {
+				return
+			}
+			got := decode[SearchResults](t, rec.Body.Bytes())
+			results := []string{}
+			for _, r := range got.Results {
+				results = append(results, r.Category+"/"+r.Resource["Name"])
+			}
+			assert.Equal(t, tt.wantResults, results)
+			assert.Equal(t, tt.wantTruncated, got.Truncated)
+		})
+	}
+}
@@ -0,0 +1,221 @@
+// Package server serves arc output directories over HTTP: an account picker,
+// the HTML viewer of every account and a read-only JSON API over the collected
+// resources, with gzip compression and optional basic authentication.
+package server
+
+import (
+	"compress/gzip"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/y-miyazaki/arc/internal/exporter"
+)
+
+// ErrNotDirectory is returned when the served output directory is not a directory.
+var ErrNotDirectory = errors.New("not a directory")
+
+// Options configures the handler returned by NewHandler.
+type Options struct {
+	// Dir is the output directory: one {account-id} directory per collected account.
+	Dir string
+	// Username and Password enable HTTP basic authentication when Username is set.
+	Username string
+	Password string
+}
+
+// handler serves the output directory dir.
+type handler struct {
+	dir    string
+	static http.Handler
+}
+
+// NewHandler returns the HTTP handler serving the output directory of opts.
+// Only GET and HEAD requests are served.
+func NewHandler(opts Options) (http.Handler, error) {
+	info, err := os.Stat(opts.Dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open output directory: %w", err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("%w: %s", ErrNotDirectory, opts.Dir)
+	}
+	h := &handler{
+		dir:    opts.Dir,
+		static: http.FileServerFS(noListingFS{os.DirFS(opts.Dir)}),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /{$}", h.serveAccountsIndex)
+	mux.HandleFunc("GET /api/accounts", h.serveAccounts)
+	mux.HandleFunc("GET /api/accounts/{account}/categories", h.serveCategories)
+	mux.HandleFunc("GET /api/accounts/{account}/categories/{category}/resources", h.serveResources)
+	mux.HandleFunc("GET /api/accounts/{account}/search", h.serveSearch)
+	mux.Handle("GET /", h.static)
+
+	var next http.Handler = mux
+	next = withGzip(next)
+	if opts.Username != "" {
+		next = withBasicAuth(opts.Username, opts.Password, next)
+	}
+	return next, nil
+}
+
+// serveAccountsIndex serves the account picker, linking the viewer of every account.
+func (h *handler) serveAccountsIndex(w http.ResponseWriter, r *http.Request) {
+	accounts, err := h.accounts()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := exporter.WriteAccountsIndex(w, accounts); err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+	}
+}
+
+// accounts returns the accounts of the output directory: the directories with
+// a files.json manifest. Accounts listed in accounts.json come first, in its
+// order and with its display names; the others follow by account ID.
+func (h *handler) accounts() ([]exporter.AccountIndexEntry, error) {
+	dirs, err := os.ReadDir(h.dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read output directory: %w", err)
+	}
+	var ids []string
+	for _, d := range dirs {
+		if !d.IsDir() {
+			continue
+		}
+		if _, statErr := os.Stat(filepath.Join(h.dir, d.Name(), manifestFile)); statErr == nil {
+			ids = append(ids, d.Name())
+		}
+	}
+
+	var listed []exporter.AccountIndexEntry
+	if b, readErr := os.ReadFile(filepath.Join(h.dir, "accounts.json")); readErr == nil {
+		if jsonErr := json.Unmarshal(b, &listed); jsonErr != nil {
+			return nil, fmt.Errorf("failed to parse accounts.json: %w", jsonErr)
+		}
+	}
+	accounts := make([]exporter.AccountIndexEntry, 0, len(ids))
+	for _, account := range listed {
+		if slices.Contains(ids, account.AccountID) {
+			accounts = append(accounts, account)
+		}
+	}
+	for _, id := range ids {
+		if !slices.ContainsFunc(accounts, func(a exporter.AccountIndexEntry) bool { return a.AccountID == id }) {
+			accounts = append(accounts, exporter.AccountIndexEntry{AccountID: id})
+		}
+	}
+	return accounts, nil
+}
+
+// noListingFS hides the directories without an index.html, so that the file
+// server does not list them.
+type noListingFS struct {
+	fsys fs.FS
+}
+
+// Open opens name, or returns fs.ErrNotExist for directories without an index.html.
+func (n noListingFS) Open(name string) (fs.File, error) {
+	f, err := n.fsys.Open(name)
+	if err != nil {
+		return nil, err //nolint:wrapcheck // the file server checks fs.ErrNotExist and fs.ErrPermission
+	}
+	info, err := f.Stat()
+	if err != nil || !info.IsDir() {
+		return f, nil
+	}
+	if _, indexErr := fs.Stat(n.fsys, path.Join(name, "index.html")); indexErr != nil {
+		_ = f.Close()
+		return nil, fs.ErrNotExist
+	}
+	return f, nil
+}
+
+// withBasicAuth requires the HTTP basic authentication credentials username and password.
+func withBasicAuth(username, password string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, pass, ok := r.BasicAuth()
+		userOK := subtle.ConstantTimeCompare([]byte(user), []byte(username)) == 1
+		passOK := subtle.ConstantTimeCompare([]byte(pass), []byte(password)) == 1
+		if !ok || !userOK || !passOK {
+			w.Header().Set("WWW-Authenticate", `Basic realm="arc", charset="UTF-8"`)
+			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// withGzip compresses the responses of the clients accepting gzip, except
+// resources.zip which is already compressed.
+func withGzip(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Vary", "Accept-Encoding")
+		if !acceptsGzip(r) || strings.HasSuffix(r.URL.Path, ".zip") {
+			next.ServeHTTP(w, r)
+			return
+		}
+		// Byte ranges of the compressed body would not match Content-Range.
+		r.Header.Del("Range")
+		w.Header().Set("Content-Encoding", "gzip")
+		gw := &gzipResponseWriter{ResponseWriter: w}
+		defer gw.close()
+		next.ServeHTTP(gw, r)
+	})
+}
+
+// acceptsGzip reports whether the Accept-Encoding header of r lists gzip.
+func acceptsGzip(r *http.Request) bool {
+	for _, part := range strings.Split(r.Header.Get("Accept-Encoding"), ",") {
+		coding, _, _ := strings.Cut(part, ";")
+		if strings.EqualFold(strings.TrimSpace(coding), "gzip") {
+			return true
+		}
+	}
+	return false
+}
+
+// gzipResponseWriter compresses the response body. The gzip writer is created
+// on the first write, so that responses without a body stay empty.
+type gzipResponseWriter struct {
+	http.ResponseWriter
+	gz *gzip.Writer
+}
+
+// WriteHeader drops the Content-Length of the uncompressed body.
+func (w *gzipResponseWriter) WriteHeader(status int) {
+	w.Header().Del("Content-Length")
+	w.ResponseWriter.WriteHeader(status)
+}
+
+// Write compresses b.
+func (w *gzipResponseWriter) Write(b []byte) (int, error) {
+	if w.gz == nil {
+		w.Header().Del("Content-Length")
+		w.gz = gzip.NewWriter(w.ResponseWriter)
+	}
+	n, err := w.gz.Write(b)
+	if err != nil {
+		return n, fmt.Errorf("failed to compress response: %w", err)
+	}
+	return n, nil
+}
+
+// close flushes the compressed body.
+func (w *gzipResponseWriter) close() {
+	if w.gz != nil {
+		_ = w.gz.Close()
+	}
+}
@@ -0,0 +1,183 @@
+package server
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeFile writes content to dir/name, creating the parent directories.
+func writeFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+	p := filepath.Join(dir, filepath.FromSlash(name))
+	require.NoError(t, os.MkdirAll(filepath.Dir(p), 0o755))
+	require.NoError(t, os.WriteFile(p, []byte(content), 0o644))
+}
+
+// testOutputDir returns an output directory with two collected accounts and a
+// directory that is not an account.
+func testOutputDir(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	writeFile(t, dir, "accounts.json", `[{"account_id":"222222222222","display_name":"prod(222222222222)"},{"account_id":"999999999999"}]`)
+	writeFile(t, dir, "111111111111/files.json", `[
+		{"path":"resources/ec2.csv","display_name":"ec2"},
+		{"path":"resources/s3.csv","display_name":"s3"},
+		{"path":"resources/findings.csv","display_name":"findings","kind":"findings"}
+	]`)
+	writeFile(t, dir, "111111111111/index.html", "<html>viewer 111111111111</html>")
+	writeFile(t, dir, "111111111111/resources/ec2.csv", "Category,Name,State\nec2,web,running\nec2,batch,stopped\nec2,Worker,running\n")
+	writeFile(t, dir, "111111111111/resources/s3.csv", "Category,Name\ns3,web-assets\n")
+	writeFile(t, dir, "111111111111/resources/findings.csv", "Rule,Resource\nr1,web\n")
+	writeFile(t, dir, "222222222222/files.json", `[]`)
+	writeFile(t, dir, "222222222222/index.html", "<html>viewer 222222222222</html>")
+	writeFile(t, dir, "logs/arc.log", "log")
+	return dir
+}
+
+// newTestHandler returns the handler of a test output directory.
+func newTestHandler(t *testing.T, opts Options) http.Handler {
+	t.Helper()
+	if opts.Dir == "" {
+		opts.Dir = testOutputDir(t)
+	}
+	h, err := NewHandler(opts)
+	require.NoError(t, err)
+	return h
+}
+
+// get serves a GET request of target with the given headers.
+func get(t *testing.T, h http.Handler, target string, header map[string]string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	for k, v := range header {
+		req.Header.Set(k, v)
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestNewHandler_Errors(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeFile(t, dir, "file", "x")
+
+	_, err := NewHandler(Options{Dir: filepath.Join(dir, "missing")})
+	require.Error(t, err)
+	_, err = NewHandler(Options{Dir: filepath.Join(dir, "file")})
+	require.ErrorIs(t, err, ErrNotDirectory)
+}
+
+func TestHandler_AccountsIndex(t *testing.T) {
+	t.Parallel()
+
+	rec := get(t, newTestHandler(t, Options{}), "/", nil)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Header().Get("Content-Type"), "text/html")
+	body := rec.Body.String()
+	assert.Contains(t, body, `href="222222222222/index.html">prod(222222222222)`)
+	assert.Contains(t, body, `href="111111111111/index.html">111111111111`)
+	assert.Less(t, strings.Index(body, "222222222222/index.html"), strings.Index(body, "111111111111/index.html"))
+	assert.NotContains(t, body, "999999999999")
+	assert.NotContains(t, body, "logs")
+}
+
+func TestHandler_Static(t *testing.T) {
+	t.Parallel()
+
+	h := newTestHandler(t, Options{})
+	tests := []struct {
+		name     string
+		target   string
+		wantCode int
+		wantBody string
+	}{
+		{name: "account viewer", target: "/111111111111/", wantCode: http.StatusOK, wantBody: "viewer 111111111111"},
+		{name: "csv file", target: "/111111111111/resources/s3.csv", wantCode: http.StatusOK, wantBody: "s3,web-assets"},
+		{name: "no listing of directories without index.html", target: "/111111111111/resources/", wantCode: http.StatusNotFound},
+		{name: "missing file", target: "/111111111111/missing.csv", wantCode: http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			rec := get(t, h, tt.target, nil)
+			assert.Equal(t, tt.wantCode, rec.Code)
+			assert.Contains(t, rec.Body.String(), tt.wantBody)
+		})
+	}
+}
+
+func TestHandler_MethodNotAllowed(t *testing.T) {
+	t.Parallel()
+
+	req := httptest.NewRequest(http.MethodPost, "/api/accounts", nil)
+	rec := httptest.NewRecorder()
+	newTestHandler(t, Options{}).ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
+
+func TestHandler_Gzip(t *testing.T) {
+	t.Parallel()
+
+	h := newTestHandler(t, Options{})
+
+	rec := get(t, h, "/111111111111/resources/ec2.csv", map[string]string{"Accept-Encoding": "br, gzip;q=0.8"})
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "gzip", rec.Header().Get("Content-Encoding"))
+	assert.Empty(t, rec.Header().Get("Content-Length"))
+	assert.Contains(t, rec.Header().Values("Vary"), "Accept-Encoding")
+	zr, err := gzip.NewReader(rec.Body)
+	require.NoError(t, err)
+	body, err := io.ReadAll(zr)
+	require.NoError(t, err)
+	assert.Contains(t, string(body), "ec2,web,running")
+
+	rec = get(t, h, "/111111111111/resources/ec2.csv", nil)
+	assert.Empty(t, rec.Header().Get("Content-Encoding"))
+	assert.Contains(t, rec.Body.String(), "ec2,web,running")
+}
+
+func TestHandler_BasicAuth(t *testing.T) {
+	t.Parallel()
+
+	h := newTestHandler(t, Options{Username: "admin", Password: "secret"})
+	tests := []struct {
+		name     string
+		user     string
+		pass     string
+		noAuth   bool
+		wantCode int
+	}{
+		{name: "no credentials", noAuth: true, wantCode: http.StatusUnauthorized},
+		{name: "wrong password", user: "admin", pass: "wrong", wantCode: http.StatusUnauthorized},
+		{name: "wrong username", user: "root", pass: "secret", wantCode: http.StatusUnauthorized},
+		{name: "valid credentials", user: "admin", pass: "secret", wantCode: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			req := httptest.NewRequest(http.MethodGet, "/api/accounts", nil)
+			if !tt.noAuth {
+				req.SetBasicAuth(tt.user, tt.pass)
+			}
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+			assert.Equal(t, tt.wantCode, rec.Code)
+			if tt.wantCode == http.StatusUnauthorized {
+				assert.Contains(t, rec.Header().Get("WWW-Authenticate"), "Basic")
+			}
+		})
+	}
+}